@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	auth_services "restaurant_os/internal/api/auth/services"
 	"restaurant_os/internal/config"
 	"restaurant_os/internal/dto"
+	"restaurant_os/internal/jobs"
+	"restaurant_os/internal/mailer"
 	"restaurant_os/internal/models"
 	"restaurant_os/internal/routes"
 
@@ -37,6 +41,16 @@ func main() {
 		log.Fatalf("Error connecting to the database: %v", err)
 	}
 
+	// Password reset tokens are emailed when an SMTP relay is configured
+	if m := mailer.FromConfig(cfg); m != nil {
+		auth_services.SetResetNotifier(&auth_services.EmailResetNotifier{Mailer: m, ResetURL: cfg.PasswordResetURL})
+	} else {
+		log.Println("SMTP_HOST is not set, password reset emails are disabled")
+	}
+
+	// Background jobs (QR session sweeper, ...)
+	jobs.StartAll(context.Background())
+
 	app := fiber.New(fiber.Config{
 		ServerHeader:  "Restaurant OS",
 		AppName:       "Restaurant OS v0.1",
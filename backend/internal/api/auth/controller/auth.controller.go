@@ -1,20 +1,27 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	auth_dto "restaurant_os/internal/api/auth/dto"
 	"restaurant_os/internal/api/auth/helpers"
 	auth_services "restaurant_os/internal/api/auth/services"
 	user_dto "restaurant_os/internal/api/user/dto"
+	"restaurant_os/internal/config"
 	dto "restaurant_os/internal/dto"
 	"restaurant_os/internal/models"
+	"restaurant_os/internal/password"
 	"time"
 
+	validator "github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
 type authController struct{}
 
+var validate = validator.New()
+
 func NewAuthController() *authController {
 	return &authController{}
 }
@@ -52,7 +59,7 @@ func (ac *authController) LoginHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	if !helpers.CheckPasswordHash(loginRequest.Password, user.Password) {
+	if !password.Check(loginRequest.Password, user.Password) {
 		errMsg := "Invalid email or password"
 		return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
 			Success: false,
@@ -63,9 +70,9 @@ func (ac *authController) LoginHandler(c *fiber.Ctx) error {
 
 	// Generate tokens
 	accessToken := helpers.GenerateAccessToken(user)
-	refreshToken := helpers.GenerateRefreshToken(user)
+	refreshToken, _, err := auth_services.IssueRefreshToken(models.DataBase, user, "", c.Get(fiber.HeaderUserAgent), c.IP())
 
-	if accessToken == "" || refreshToken == "" {
+	if accessToken == "" || err != nil {
 		errMsg := "Failed to generate tokens"
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
 			Success: false,
@@ -74,25 +81,11 @@ func (ac *authController) LoginHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	userSummary := user_dto.UserSummary{
-		ID:       user.ID,
-		Email:    user.Email,
-		Name:     user.Name,
-		UserType: string(user.UserType),
-		Role: func(role *models.EmployeeRole) *string {
-			if role == nil {
-				return nil
-			}
-			str := string(*role)
-			return &str
-		}(user.Role),
-	}
-
 	response := &auth_dto.LoginResponse{
 		Token:        accessToken,
 		RefreshToken: refreshToken,
-		User:         userSummary,
-		ExpiresAt:    time.Now(),
+		User:         toUserSummary(user),
+		ExpiresAt:    time.Now().Add(helpers.AccessTokenTTL()),
 	}
 
 	return c.JSON(dto.APIResponse{
@@ -103,13 +96,283 @@ func (ac *authController) LoginHandler(c *fiber.Ctx) error {
 }
 
 func (ac *authController) RefreshTokenHandler(c *fiber.Ctx) error {
-	// Mock refresh logic
+	var req auth_dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		errMsg := "refresh_token is required"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   &errMsg,
+		})
+	}
+
+	user, refreshToken, err := auth_services.RotateRefreshToken(req.RefreshToken, c.Get(fiber.HeaderUserAgent), c.IP())
+	if err != nil {
+		errMsg := err.Error()
+		if errors.Is(err, auth_services.ErrInvalidRefreshToken) || errors.Is(err, auth_services.ErrRefreshTokenReused) {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Unauthorized",
+				Error:   &errMsg,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Failed to refresh token",
+			Error:   &errMsg,
+		})
+	}
+
+	accessToken := helpers.GenerateAccessToken(user)
+	if accessToken == "" {
+		errMsg := "Failed to generate tokens"
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
 	response := &auth_dto.LoginResponse{
-		Token:        "newToken",
-		RefreshToken: "newRefreshToken",
-		User:         user_dto.UserSummary{},
-		ExpiresAt:    time.Now().Add(time.Hour),
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User:         toUserSummary(user),
+		ExpiresAt:    time.Now().Add(helpers.AccessTokenTTL()),
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Token refreshed successfully",
+		Data:    response,
+	})
+}
+
+// LogoutHandler revokes the presented refresh token and the rest of its family
+func (ac *authController) LogoutHandler(c *fiber.Ctx) error {
+	var req auth_dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		errMsg := "refresh_token is required"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   &errMsg,
+		})
+	}
+
+	if err := auth_services.RevokeRefreshToken(req.RefreshToken); err != nil {
+		errMsg := err.Error()
+		if errors.Is(err, auth_services.ErrInvalidRefreshToken) {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Unauthorized",
+				Error:   &errMsg,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Failed to logout",
+			Error:   &errMsg,
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Logged out successfully",
+	})
+}
+
+// LogoutAllHandler revokes every refresh token of the authenticated user
+func (ac *authController) LogoutAllHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		errMsg := "User not found in context"
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error:   &errMsg,
+		})
+	}
+
+	if err := auth_services.RevokeAllUserTokens(userID); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Failed to logout from all devices",
+			Error:   &errMsg,
+		})
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Logged out from all devices successfully",
+	})
+}
+
+// ChangePasswordHandler changes the authenticated user's password
+func (ac *authController) ChangePasswordHandler(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		errMsg := "User not found in context"
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error:   &errMsg,
+		})
+	}
+
+	var req auth_dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		errMsg := "current_password and new_password are required"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   &errMsg,
+		})
 	}
 
-	return c.JSON(response)
+	if err := auth_services.ChangePassword(userID, req.CurrentPassword, req.NewPassword); err != nil {
+		return passwordErrorResponse(c, err, "Failed to change password")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Password changed successfully, please log in again",
+	})
+}
+
+// ForgotPasswordHandler issues a password reset token. The response is the same
+// whether or not the email is registered.
+func (ac *authController) ForgotPasswordHandler(c *fiber.Ctx) error {
+	var req auth_dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		errMsg := "A valid email is required"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   &errMsg,
+		})
+	}
+
+	token, expiresAt, err := auth_services.RequestPasswordReset(req.Email, c.IP())
+	if err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Failed to request password reset",
+			Error:   &errMsg,
+		})
+	}
+
+	response := &auth_dto.ForgotPasswordResponse{}
+	if token != "" {
+		// Delivery failures are only logged so the response never reveals whether the email exists
+		if err := auth_services.NotifyPasswordReset(req.Email, token, *expiresAt); err != nil {
+			log.Printf("password reset: token could not be delivered: %v", err)
+		}
+		if config.EnvConfig.GO_ENV == "development" {
+			response.ResetToken = token
+			response.ExpiresAt = expiresAt
+		}
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "If the email is registered, password reset instructions have been sent",
+		Data:    response,
+	})
+}
+
+// ResetPasswordHandler sets a new password using a reset token
+func (ac *authController) ResetPasswordHandler(c *fiber.Ctx) error {
+	var req auth_dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		errMsg := "token and new_password are required"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   &errMsg,
+		})
+	}
+
+	if err := auth_services.ResetPassword(req.Token, req.NewPassword); err != nil {
+		return passwordErrorResponse(c, err, "Failed to reset password")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Password reset successfully",
+	})
+}
+
+// passwordErrorResponse maps password service errors to HTTP status codes
+func passwordErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case password.IsPolicyError(err), errors.Is(err, auth_services.ErrPasswordUnchanged):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, auth_services.ErrIncorrectPassword), errors.Is(err, auth_services.ErrInvalidResetToken):
+		status = fiber.StatusUnauthorized
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toUserSummary(user *models.User) user_dto.UserSummary {
+	return user_dto.UserSummary{
+		ID:       user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+		UserType: string(user.UserType),
+		Role: func(role *models.EmployeeRole) *string {
+			if role == nil {
+				return nil
+			}
+			str := string(*role)
+			return &str
+		}(user.Role),
+	}
 }
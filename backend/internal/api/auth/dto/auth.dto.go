@@ -31,5 +31,23 @@ type RefreshTokenRequest struct {
 // ChangePasswordRequest represents change password request
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" validate:"required"`
-	NewPassword     string `json:"new_password" validate:"required,min=6"`
+	NewPassword     string `json:"new_password" validate:"required"` // Checked against the password policy
+}
+
+// ForgotPasswordRequest represents forgot password request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPasswordResponse represents forgot password response.
+// ResetToken is only returned in development, where no mailer is configured.
+type ForgotPasswordResponse struct {
+	ResetToken string     `json:"reset_token,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// ResetPasswordRequest represents reset password request
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"` // Checked against the password policy
 }
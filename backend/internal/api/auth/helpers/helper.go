@@ -1,6 +1,10 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"restaurant_os/internal/models"
 	"time"
@@ -8,27 +12,46 @@ import (
 	"restaurant_os/internal/config"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 )
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+// AccessTokenTTL returns the configured access token lifetime (default 1h)
+func AccessTokenTTL() time.Duration {
+	if d, err := parseDuration(config.EnvConfig.JWTAccessExpiry); err == nil {
+		return d
+	}
+	return time.Hour
+}
+
+// RefreshTokenTTL returns the configured refresh token lifetime (default 7d)
+func RefreshTokenTTL() time.Duration {
+	if d, err := parseDuration(config.EnvConfig.JWTRefreshExpiry); err == nil {
+		return d
+	}
+	return 7 * 24 * time.Hour
+}
+
+// PasswordResetTTL returns the configured password reset token lifetime (default 30m)
+func PasswordResetTTL() time.Duration {
+	if d, err := parseDuration(config.EnvConfig.PasswordResetExpiry); err == nil {
+		return d
+	}
+	return 30 * time.Minute
 }
 
-func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// GenerateOpaqueToken returns a random hex token suitable for one-time links
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
 func GenerateAccessToken(user *models.User) string {
 	secret := config.EnvConfig.JWTAccessSecret
-	expiryStr := config.EnvConfig.JWTAccessExpiry
+	duration := AccessTokenTTL()
 
-	duration := time.Hour
-	if d, err := parseDuration(expiryStr); err == nil {
-		duration = d
-	}
 	fmt.Println("user.Role", user)
 	claims := jwt.MapClaims{
 		"user_id":       user.ID,
@@ -49,13 +72,11 @@ func GenerateAccessToken(user *models.User) string {
 
 func GenerateRefreshToken(user *models.User) string {
 	secret := config.EnvConfig.JWTRefreshSecret
-	expiryStr := config.EnvConfig.JWTRefreshExpiry
+	duration := RefreshTokenTTL()
 
-	duration := 7 * 24 * time.Hour
-	if d, err := parseDuration(expiryStr); err == nil {
-		duration = d
-	}
 	claims := jwt.MapClaims{
+		"jti":           uuid.NewString(), // keeps tokens issued in the same second distinct
+		"token_type":    "refresh",
 		"user_id":       user.ID,
 		"email":         user.Email,
 		"user_type":     user.UserType,
@@ -72,6 +93,32 @@ func GenerateRefreshToken(user *models.User) string {
 	return signedToken
 }
 
+// ParseRefreshToken validates a refresh token against JWTRefreshSecret and returns its claims
+func ParseRefreshToken(tokenString string) (jwt.MapClaims, error) {
+	secret := config.EnvConfig.JWTRefreshSecret
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid or expired refresh token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["token_type"] != "refresh" {
+		return nil, errors.New("invalid refresh token claims")
+	}
+	return claims, nil
+}
+
+// HashToken returns the hex encoded SHA-256 digest used to store tokens at rest
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // parseDuration parses a string like "1h", "7d" into time.Duration
 func parseDuration(s string) (time.Duration, error) {
 	if len(s) > 1 && s[len(s)-1] == 'd' {
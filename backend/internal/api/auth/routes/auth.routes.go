@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	auth_controller "restaurant_os/internal/api/auth/controller"
+	"restaurant_os/internal/middleware"
 )
 
 func RegisterAuthRoutes(api fiber.Router) {
@@ -13,5 +14,10 @@ func RegisterAuthRoutes(api fiber.Router) {
 
 	auth.Post("/login", authController.LoginHandler)
 	auth.Post("/refresh", authController.RefreshTokenHandler)
+	auth.Post("/logout", authController.LogoutHandler)
+	auth.Post("/logout-all", middleware.RequireAuth(), authController.LogoutAllHandler)
+	auth.Post("/change-password", middleware.RequireAuth(), authController.ChangePasswordHandler)
+	auth.Post("/forgot-password", authController.ForgotPasswordHandler)
+	auth.Post("/reset-password", authController.ResetPasswordHandler)
 
 }
@@ -3,11 +3,19 @@ package services
 import (
 	"errors"
 	"fmt"
+	"restaurant_os/internal/api/auth/helpers"
 	"restaurant_os/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, all sessions for this login have been revoked")
+)
+
 func GetUserByEmail(email string) (*models.User, error) {
 	fmt.Println("Fetching user by email:", email)
 	var user models.User
@@ -23,3 +31,131 @@ func GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 
 }
+
+// IssueRefreshToken generates a refresh token for the user and persists its hash.
+// An empty familyID starts a new token family (a fresh login).
+func IssueRefreshToken(tx *gorm.DB, user *models.User, familyID, userAgent, ipAddress string) (string, *models.RefreshToken, error) {
+	token := helpers.GenerateRefreshToken(user)
+	if token == "" {
+		return "", nil, errors.New("failed to generate refresh token")
+	}
+	if familyID == "" {
+		familyID = uuid.NewString()
+	}
+
+	record := &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: helpers.HashToken(token),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(helpers.RefreshTokenTTL()),
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	}
+	if err := tx.Create(record).Error; err != nil {
+		return "", nil, fmt.Errorf("error saving refresh token: %w", err)
+	}
+	return token, record, nil
+}
+
+// RotateRefreshToken exchanges a valid refresh token for a new one in the same family.
+// Presenting a token that was already rotated or revoked revokes the whole family.
+func RotateRefreshToken(tokenString, userAgent, ipAddress string) (*models.User, string, error) {
+	if _, err := helpers.ParseRefreshToken(tokenString); err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	var user models.User
+	var newToken string
+	reused := false
+
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		var stored models.RefreshToken
+		if err := tx.Where("token_hash = ?", helpers.HashToken(tokenString)).First(&stored).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return fmt.Errorf("error fetching refresh token: %w", err)
+		}
+
+		if stored.RevokedAt != nil {
+			reused = true
+			return nil
+		}
+		if !stored.IsActive() {
+			return ErrInvalidRefreshToken
+		}
+
+		if err := tx.First(&user, stored.UserID).Error; err != nil || !user.IsActive {
+			return ErrInvalidRefreshToken
+		}
+
+		// Claim the token atomically so two concurrent refreshes cannot both succeed
+		now := time.Now()
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", stored.ID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("error revoking refresh token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			reused = true
+			return nil
+		}
+
+		token, record, err := IssueRefreshToken(tx, &user, stored.FamilyID, userAgent, ipAddress)
+		if err != nil {
+			return err
+		}
+		newToken = token
+
+		return tx.Model(&stored).Update("replaced_by_id", record.ID).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if reused {
+		if err := revokeFamilyOfToken(tokenString); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrRefreshTokenReused
+	}
+	return &user, newToken, nil
+}
+
+// RevokeRefreshToken revokes every token in the family of the given refresh token (single device logout)
+func RevokeRefreshToken(tokenString string) error {
+	if _, err := helpers.ParseRefreshToken(tokenString); err != nil {
+		return ErrInvalidRefreshToken
+	}
+	return revokeFamilyOfToken(tokenString)
+}
+
+// RevokeAllUserTokens revokes every active refresh token of a user (log out all devices)
+func RevokeAllUserTokens(userID uint) error {
+	err := models.DataBase.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error revoking refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func revokeFamilyOfToken(tokenString string) error {
+	var stored models.RefreshToken
+	if err := models.DataBase.Where("token_hash = ?", helpers.HashToken(tokenString)).First(&stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("error fetching refresh token: %w", err)
+	}
+
+	err := models.DataBase.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", stored.FamilyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token family: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"restaurant_os/internal/api/auth/helpers"
+	"restaurant_os/internal/mailer"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/password"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	ErrPasswordUnchanged = errors.New("new password must be different from the current password")
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrNoResetNotifier   = errors.New("no password reset delivery is configured")
+)
+
+// ResetNotifier delivers a password reset token to the user who asked for it
+type ResetNotifier interface {
+	NotifyPasswordReset(email, token string, expiresAt time.Time) error
+}
+
+var resetNotifier ResetNotifier
+
+// SetResetNotifier sets how reset tokens reach users; the server wires it up at start
+func SetResetNotifier(n ResetNotifier) {
+	resetNotifier = n
+}
+
+// NotifyPasswordReset hands a new reset token to the configured notifier
+func NotifyPasswordReset(email, token string, expiresAt time.Time) error {
+	if resetNotifier == nil {
+		return ErrNoResetNotifier
+	}
+	return resetNotifier.NotifyPasswordReset(email, token, expiresAt)
+}
+
+// EmailResetNotifier emails a link to the reset page, or the bare token when
+// no page is configured
+type EmailResetNotifier struct {
+	Mailer   *mailer.Mailer
+	ResetURL string
+}
+
+func (n *EmailResetNotifier) NotifyPasswordReset(email, token string, expiresAt time.Time) error {
+	instructions := "Use this token to reset your password: " + token
+	if n.ResetURL != "" {
+		separator := "?"
+		if strings.Contains(n.ResetURL, "?") {
+			separator = "&"
+		}
+		instructions = "Open this link to reset your password: " + n.ResetURL + separator + "token=" + url.QueryEscape(token)
+	}
+	body := fmt.Sprintf("A password reset was requested for your Restaurant OS account.\n\n%s\n\nIt expires at %s. If you did not ask for it, you can ignore this email.",
+		instructions, expiresAt.UTC().Format("2006-01-02 15:04 UTC"))
+	return n.Mailer.Send(email, "Reset your Restaurant OS password", body)
+}
+
+// ChangePassword verifies the current password, stores the new one and revokes
+// every refresh token so other devices have to log in again
+func ChangePassword(userID uint, currentPassword, newPassword string) error {
+	var user models.User
+	if err := models.DataBase.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("error fetching user: %w", err)
+	}
+
+	if !password.Check(currentPassword, user.Password) {
+		return ErrIncorrectPassword
+	}
+	if currentPassword == newPassword {
+		return ErrPasswordUnchanged
+	}
+	if err := password.CurrentPolicy().Validate(newPassword); err != nil {
+		return err
+	}
+
+	return models.DataBase.Transaction(func(tx *gorm.DB) error {
+		return setPassword(tx, user.ID, newPassword)
+	})
+}
+
+// RequestPasswordReset creates a single-use reset token for the user with the given email.
+// It returns an empty token (and no error) when no such user exists so callers
+// cannot use the endpoint to discover registered emails.
+func RequestPasswordReset(email, ipAddress string) (string, *time.Time, error) {
+	var user models.User
+	err := models.DataBase.Where("email = ? AND is_active = ?", email, true).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil, nil
+		}
+		return "", nil, fmt.Errorf("error fetching user: %w", err)
+	}
+
+	token, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating reset token: %w", err)
+	}
+	expiresAt := time.Now().Add(helpers.PasswordResetTTL())
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		// Only the most recently requested token stays valid
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", user.ID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.PasswordResetToken{
+			UserID:    user.ID,
+			TokenHash: helpers.HashToken(token),
+			ExpiresAt: expiresAt,
+			IPAddress: ipAddress,
+		}).Error
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("error saving reset token: %w", err)
+	}
+	return token, &expiresAt, nil
+}
+
+// ResetPassword consumes a reset token and sets the user's new password
+func ResetPassword(token, newPassword string) error {
+	if err := password.CurrentPolicy().Validate(newPassword); err != nil {
+		return err
+	}
+
+	return models.DataBase.Transaction(func(tx *gorm.DB) error {
+		var resetToken models.PasswordResetToken
+		if err := tx.Where("token_hash = ?", helpers.HashToken(token)).First(&resetToken).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidResetToken
+			}
+			return fmt.Errorf("error fetching reset token: %w", err)
+		}
+		if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+			return ErrInvalidResetToken
+		}
+
+		// Mark the token used atomically so it cannot be redeemed twice
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", resetToken.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return fmt.Errorf("error consuming reset token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidResetToken
+		}
+
+		return setPassword(tx, resetToken.UserID, newPassword)
+	})
+}
+
+func setPassword(tx *gorm.DB, userID uint, newPassword string) error {
+	hashed, err := password.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+	if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("password", hashed).Error; err != nil {
+		return fmt.Errorf("error updating password: %w", err)
+	}
+	if err := tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("error revoking refresh tokens: %w", err)
+	}
+	return nil
+}
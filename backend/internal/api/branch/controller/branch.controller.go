@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	branch_dto "restaurant_os/internal/api/branch/dto"
+	branch_services "restaurant_os/internal/api/branch/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/schedule"
+	"strings"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type branchController struct{}
+
+var validate = validator.New()
+
+func NewBranchController() *branchController {
+	return &branchController{}
+}
+
+func (bc *branchController) GetOpeningHours(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid branch ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	branch, err := branch_services.GetBranch(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return branchErrorResponse(c, err, "Failed to fetch opening hours")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Opening hours fetched successfully",
+		Data:    toOpeningHoursResponse(branch),
+	})
+}
+
+func (bc *branchController) UpdateOpeningHours(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid branch ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req branch_dto.UpdateOpeningHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, branch_dto.OpeningHoursValidationErrorMessages)
+	}
+
+	branch, err := branch_services.UpdateOpeningHours(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return branchErrorResponse(c, err, "Failed to update opening hours")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Opening hours updated successfully",
+		Data:    toOpeningHoursResponse(branch),
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// branchErrorResponse maps branch service errors to HTTP status codes
+func branchErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, branch_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, models.ErrInvalidOpeningHours):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toOpeningHoursResponse(branch *models.Branch) branch_dto.OpeningHoursResponse {
+	loc := schedule.Location(branch.Restaurant.TimeZone)
+	hours := branch.OpeningHours
+	if hours == nil {
+		hours = models.OpeningHours{}
+	}
+	return branch_dto.OpeningHoursResponse{
+		BranchID:     branch.ID,
+		TimeZone:     loc.String(),
+		IsOpen:       hours.IsOpen(time.Now().In(loc)),
+		OpeningHours: hours,
+	}
+}
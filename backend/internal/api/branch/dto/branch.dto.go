@@ -0,0 +1,27 @@
+package dto
+
+import "restaurant_os/internal/models"
+
+// ============================================================================
+// OPENING HOURS REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// UpdateOpeningHoursRequest replaces the weekly opening hours of a branch, e.g.
+// {"opening_hours": {"monday": {"open": "09:00", "close": "22:00"}, "sunday": {"closed": true}}}.
+// Times are in the restaurant's time zone; an empty object keeps the branch always open.
+type UpdateOpeningHoursRequest struct {
+	OpeningHours models.OpeningHours `json:"opening_hours" validate:"required"`
+}
+
+// OpeningHoursValidationErrorMessages maps opening hours request fields to custom messages
+var OpeningHoursValidationErrorMessages = map[string]string{
+	"OpeningHours": "Opening hours are required; send an empty object to stay always open.",
+}
+
+// OpeningHoursResponse represents the opening hours of a branch
+type OpeningHoursResponse struct {
+	BranchID     uint                `json:"branch_id"`
+	TimeZone     string              `json:"time_zone"`
+	IsOpen       bool                `json:"is_open"`
+	OpeningHours models.OpeningHours `json:"opening_hours"`
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	branch_controller "restaurant_os/internal/api/branch/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterBranchRoutes(api fiber.Router) {
+
+	branches := api.Group("/branches", middleware.RequireAuth())
+
+	branchHandler := branch_controller.NewBranchController()
+
+	branches.Get("/:id/opening-hours", branchHandler.GetOpeningHours)
+	branches.Put("/:id/opening-hours", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), branchHandler.UpdateOpeningHours)
+}
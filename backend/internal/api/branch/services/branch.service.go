@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/branch/dto"
+	menu_services "restaurant_os/internal/api/menu/services"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrBranchNotFound = errors.New("branch not found")
+
+// GetBranch returns a branch with its restaurant if the requester can access it
+func GetBranch(id uint, claims *common_dto.Claims) (*models.Branch, error) {
+	var branch models.Branch
+	if err := models.DataBase.Preload("Restaurant").First(&branch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	return &branch, nil
+}
+
+// UpdateOpeningHours validates and replaces the weekly opening hours of a branch
+func UpdateOpeningHours(id uint, req *dto.UpdateOpeningHoursRequest, claims *common_dto.Claims) (*models.Branch, error) {
+	branch, err := GetBranch(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.OpeningHours.Validate(); err != nil {
+		return nil, err
+	}
+
+	branch.OpeningHours = req.OpeningHours
+	if err := models.DataBase.Model(branch).Update("opening_hours", branch.OpeningHours).Error; err != nil {
+		return nil, fmt.Errorf("error updating opening hours: %w", err)
+	}
+
+	// The customer menu shows the opening hours
+	menu_services.InvalidateMenu(branch.ID)
+	return branch, nil
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	customer_dto "restaurant_os/internal/api/customer/dto"
+	customer_services "restaurant_os/internal/api/customer/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"strings"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type customerController struct{}
+
+var validate = validator.New()
+
+func NewCustomerController() *customerController {
+	return &customerController{}
+}
+
+// ============================================================================
+// CUSTOMERS
+// ============================================================================
+
+func (cc *customerController) GetCustomers(c *fiber.Ctx) error {
+	var query customer_dto.CustomerListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	customers, total, err := customer_services.ListCustomers(&query, middleware.GetClaims(c))
+	if err != nil {
+		return customerErrorResponse(c, err, "Failed to fetch customers")
+	}
+
+	data := make([]customer_dto.CustomerResponse, 0, len(customers))
+	for i := range customers {
+		data = append(data, toCustomerResponse(&customers[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Customers fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (cc *customerController) GetCustomer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid customer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	customer, err := customer_services.GetCustomer(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return customerErrorResponse(c, err, "Failed to fetch customer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Customer fetched successfully",
+		Data:    toCustomerResponse(customer),
+	})
+}
+
+func (cc *customerController) CreateCustomer(c *fiber.Ctx) error {
+	var req customer_dto.CreateCustomerRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, customer_dto.CustomerValidationErrorMessages)
+	}
+
+	customer, err := customer_services.CreateCustomer(&req, middleware.GetClaims(c))
+	if err != nil {
+		return customerErrorResponse(c, err, "Failed to create customer")
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Customer created successfully",
+		Data:    toCustomerResponse(customer),
+	})
+}
+
+func (cc *customerController) UpdateCustomer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid customer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req customer_dto.UpdateCustomerRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, customer_dto.CustomerValidationErrorMessages)
+	}
+
+	customer, err := customer_services.UpdateCustomer(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return customerErrorResponse(c, err, "Failed to update customer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Customer updated successfully",
+		Data:    toCustomerResponse(customer),
+	})
+}
+
+func (cc *customerController) DeleteCustomer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid customer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := customer_services.DeleteCustomer(uint(id), middleware.GetClaims(c)); err != nil {
+		return customerErrorResponse(c, err, "Failed to delete customer")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Customer deleted successfully",
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// customerErrorResponse maps customer service errors to HTTP status codes
+func customerErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, customer_services.ErrCustomerNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, customer_services.ErrCustomerExists):
+		status = fiber.StatusConflict
+	case errors.Is(err, customer_services.ErrInvalidCustomer):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toCustomerResponse(customer *models.Customer) customer_dto.CustomerResponse {
+	resp := customer_dto.CustomerResponse{
+		ID:            customer.ID,
+		RestaurantID:  customer.RestaurantID,
+		Name:          customer.Name,
+		Phone:         customer.Phone,
+		Email:         customer.Email,
+		Address:       customer.Address,
+		TotalOrders:   customer.TotalOrders,
+		TotalSpent:    customer.TotalSpent,
+		LoyaltyPoints: customer.LoyaltyPoints,
+		LastOrderAt:   customer.LastOrderAt,
+		Notes:         customer.Notes,
+		CreatedAt:     customer.CreatedAt,
+		UpdatedAt:     customer.UpdatedAt,
+	}
+	if customer.BirthDate != nil {
+		birthDate := customer.BirthDate.In(time.Local).Format(time.DateOnly)
+		resp.BirthDate = &birthDate
+	}
+	if customer.Anniversary != nil {
+		anniversary := customer.Anniversary.In(time.Local).Format(time.DateOnly)
+		resp.Anniversary = &anniversary
+	}
+	return resp
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// CUSTOMER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// CustomerListQuery represents filters for listing customers. Phone and email
+// match exactly, search matches part of the name, phone or email.
+type CustomerListQuery struct {
+	dto.PaginationQuery
+	Search string `query:"search"`
+	Phone  string `query:"phone"`
+	Email  string `query:"email"`
+}
+
+// CreateCustomerRequest represents the request to add a customer
+type CreateCustomerRequest struct {
+	RestaurantID *uint  `json:"restaurant_id,omitempty"` // SUPER_ADMIN only; defaults to the user's restaurant
+	Name         string `json:"name" validate:"required,min=2,max=100"`
+	Phone        string `json:"phone" validate:"required,max=20"`
+	Email        string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Address      string `json:"address,omitempty" validate:"max=500"`
+	BirthDate    string `json:"birth_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Anniversary  string `json:"anniversary,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes        string `json:"notes,omitempty" validate:"max=1000"`
+}
+
+// UpdateCustomerRequest represents the request to update a customer; nil fields
+// are left unchanged and an empty date clears it
+type UpdateCustomerRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=20"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Address     *string `json:"address,omitempty" validate:"omitempty,max=500"`
+	BirthDate   *string `json:"birth_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Anniversary *string `json:"anniversary,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes       *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// CustomerValidationErrorMessages maps customer request fields to custom messages
+var CustomerValidationErrorMessages = map[string]string{
+	"Name":        "Name is required and must be between 2 and 100 characters.",
+	"Phone":       "Phone is required and must be at most 20 characters.",
+	"Email":       "Email must be a valid email address.",
+	"Address":     "Address must be at most 500 characters.",
+	"BirthDate":   "Birth date must be a date like 2006-01-02.",
+	"Anniversary": "Anniversary must be a date like 2006-01-02.",
+	"Notes":       "Notes must be at most 1000 characters.",
+}
+
+// CustomerResponse represents a customer with their order aggregates
+type CustomerResponse struct {
+	ID            uint        `json:"id"`
+	RestaurantID  uint        `json:"restaurant_id"`
+	Name          string      `json:"name"`
+	Phone         string      `json:"phone"`
+	Email         string      `json:"email,omitempty"`
+	Address       string      `json:"address,omitempty"`
+	BirthDate     *string     `json:"birth_date,omitempty"`
+	Anniversary   *string     `json:"anniversary,omitempty"`
+	TotalOrders   int         `json:"total_orders"`
+	TotalSpent    money.Money `json:"total_spent"`
+	LoyaltyPoints int         `json:"loyalty_points"`
+	LastOrderAt   *time.Time  `json:"last_order_at,omitempty"`
+	Notes         string      `json:"notes,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	customer_controller "restaurant_os/internal/api/customer/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterCustomerRoutes(api fiber.Router) {
+
+	customerHandler := customer_controller.NewCustomerController()
+
+	// Front of house looks customers up and signs them up; a customer's order
+	// history is GET /orders?customer_id=
+	customers := api.Group("/customers", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "WAITER", "CASHIER", "HOST"))
+
+	customers.Get("/", customerHandler.GetCustomers)
+	customers.Post("/", customerHandler.CreateCustomer)
+	customers.Get("/:id", customerHandler.GetCustomer)
+	customers.Put("/:id", customerHandler.UpdateCustomer)
+	customers.Delete("/:id", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), customerHandler.DeleteCustomer)
+}
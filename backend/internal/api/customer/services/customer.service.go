@@ -0,0 +1,253 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/customer/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrCustomerExists   = errors.New("a customer with this phone number already exists")
+	ErrInvalidCustomer  = errors.New("invalid customer")
+)
+
+// minPhoneDigits is the shortest number accepted as a customer's phone
+const minPhoneDigits = 6
+
+// ListCustomers returns a page of the customers visible to the requester, by name
+func ListCustomers(query *dto.CustomerListQuery, claims *common_dto.Claims) ([]models.Customer, int64, error) {
+	query.Normalize()
+
+	db := scopeCustomers(models.DataBase.Model(&models.Customer{}), claims)
+	if query.Phone != "" {
+		db = db.Where("phone = ?", models.NormalizePhone(query.Phone))
+	}
+	if query.Email != "" {
+		db = db.Where("LOWER(email) = ?", strings.ToLower(strings.TrimSpace(query.Email)))
+	}
+	if search := strings.TrimSpace(query.Search); search != "" {
+		like := "%" + strings.ToLower(search) + "%"
+		phone := models.NormalizePhone(search)
+		if phone == "" {
+			phone = like
+		} else {
+			phone = "%" + phone + "%"
+		}
+		db = db.Where("(LOWER(name) LIKE ? OR LOWER(email) LIKE ? OR phone LIKE ?)", like, like, phone)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting customers: %w", err)
+	}
+
+	var customers []models.Customer
+	err := db.Order("name ASC, id ASC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&customers).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching customers: %w", err)
+	}
+	return customers, total, nil
+}
+
+// GetCustomer returns a customer if it is visible to the requester
+func GetCustomer(id uint, claims *common_dto.Claims) (*models.Customer, error) {
+	var customer models.Customer
+	if err := scopeCustomers(models.DataBase, claims).First(&customer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("error fetching customer: %w", err)
+	}
+	return &customer, nil
+}
+
+// CreateCustomer adds a customer to the requester's restaurant. A deleted
+// customer with the same phone number is restored with the new details and
+// keeps their order history.
+func CreateCustomer(req *dto.CreateCustomerRequest, claims *common_dto.Claims) (*models.Customer, error) {
+	restaurantID := claims.RestaurantID
+	if claims.IsSuperAdmin() {
+		restaurantID = req.RestaurantID
+	}
+	if restaurantID == nil {
+		return nil, fmt.Errorf("%w: restaurant_id is required", ErrInvalidCustomer)
+	}
+	if claims.IsSuperAdmin() {
+		if err := models.DataBase.Select("id").First(&models.Restaurant{}, *restaurantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: restaurant %d does not exist", ErrInvalidCustomer, *restaurantID)
+			}
+			return nil, fmt.Errorf("error fetching restaurant: %w", err)
+		}
+	}
+
+	phone, err := normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	birthDate, err := parseDate(req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+	anniversary, err := parseDate(req.Anniversary)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := &models.Customer{
+		RestaurantID: *restaurantID,
+		Name:         strings.TrimSpace(req.Name),
+		Phone:        phone,
+		Email:        strings.TrimSpace(req.Email),
+		Address:      req.Address,
+		BirthDate:    birthDate,
+		Anniversary:  anniversary,
+		Notes:        req.Notes,
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		var existing models.Customer
+		err := tx.Unscoped().Where("restaurant_id = ? AND phone = ?", customer.RestaurantID, phone).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(customer).Error; err != nil {
+				return fmt.Errorf("error creating customer: %w", err)
+			}
+			return nil
+		case err != nil:
+			return fmt.Errorf("error fetching customer: %w", err)
+		case !existing.DeletedAt.Valid:
+			return ErrCustomerExists
+		}
+
+		customer.ID = existing.ID
+		err = tx.Unscoped().Model(&existing).Updates(map[string]interface{}{
+			"name":        customer.Name,
+			"email":       customer.Email,
+			"address":     customer.Address,
+			"birth_date":  customer.BirthDate,
+			"anniversary": customer.Anniversary,
+			"notes":       customer.Notes,
+			"deleted_at":  nil,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error restoring customer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetCustomer(customer.ID, claims)
+}
+
+// UpdateCustomer applies the non-nil fields of req. The order aggregates are
+// kept by the order flow and cannot be edited.
+func UpdateCustomer(id uint, req *dto.UpdateCustomerRequest, claims *common_dto.Claims) (*models.Customer, error) {
+	customer, err := GetCustomer(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Phone != nil {
+		phone, err := normalizePhone(*req.Phone)
+		if err != nil {
+			return nil, err
+		}
+		var taken int64
+		err = models.DataBase.Unscoped().Model(&models.Customer{}).
+			Where("restaurant_id = ? AND phone = ? AND id <> ?", customer.RestaurantID, phone, customer.ID).
+			Count(&taken).Error
+		if err != nil {
+			return nil, fmt.Errorf("error checking phone number: %w", err)
+		}
+		if taken > 0 {
+			return nil, ErrCustomerExists
+		}
+		updates["phone"] = phone
+	}
+	if req.Email != nil {
+		updates["email"] = strings.TrimSpace(*req.Email)
+	}
+	if req.Address != nil {
+		updates["address"] = *req.Address
+	}
+	if req.BirthDate != nil {
+		if updates["birth_date"], err = parseDate(*req.BirthDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.Anniversary != nil {
+		if updates["anniversary"], err = parseDate(*req.Anniversary); err != nil {
+			return nil, err
+		}
+	}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+
+	if len(updates) > 0 {
+		if err := models.DataBase.Model(customer).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("error updating customer: %w", err)
+		}
+	}
+	return GetCustomer(customer.ID, claims)
+}
+
+// DeleteCustomer soft deletes a customer; their orders stay linked
+func DeleteCustomer(id uint, claims *common_dto.Claims) error {
+	customer, err := GetCustomer(id, claims)
+	if err != nil {
+		return err
+	}
+	if err := models.DataBase.Delete(customer).Error; err != nil {
+		return fmt.Errorf("error deleting customer: %w", err)
+	}
+	return nil
+}
+
+// normalizePhone returns phone as customers are stored and looked up by, or an
+// error when too few digits are left to identify anyone
+func normalizePhone(phone string) (string, error) {
+	normalized := models.NormalizePhone(phone)
+	if len(strings.TrimPrefix(normalized, "+")) < minPhoneDigits {
+		return "", fmt.Errorf("%w: phone must have at least %d digits", ErrInvalidCustomer, minPhoneDigits)
+	}
+	return normalized, nil
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: dates must look like 2006-01-02", ErrInvalidCustomer)
+	}
+	return &date, nil
+}
+
+// scopeCustomers restricts a query on customers to the requester's restaurant
+func scopeCustomers(db *gorm.DB, claims *common_dto.Claims) *gorm.DB {
+	switch {
+	case claims.IsSuperAdmin():
+		return db
+	case claims.RestaurantID != nil:
+		return db.Where("restaurant_id = ?", *claims.RestaurantID)
+	default:
+		return db.Where("1 = 0")
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultSpendPerPoint is what a customer spends for each loyalty point unless
+// LOYALTY_SPEND_PER_POINT says otherwise
+var defaultSpendPerPoint = money.FromFloat(100)
+
+// LoyaltySpendPerPoint returns how much a customer spends for each loyalty point (default 100)
+func LoyaltySpendPerPoint() money.Money {
+	if config.EnvConfig == nil {
+		return defaultSpendPerPoint
+	}
+	if spend, err := money.Parse(config.EnvConfig.LoyaltySpendPerPoint); err == nil && spend.IsPositive() {
+		return spend
+	}
+	return defaultSpendPerPoint
+}
+
+// ForOrder returns the customer an order placed on a branch belongs to: the
+// given customer, or else the one with the order's phone number, created on
+// the first order from a new number. Orders with neither are not linked.
+func ForOrder(tx *gorm.DB, branchID uint, customerID *uint, name, phone, email string) (*models.Customer, error) {
+	var restaurantID uint
+	if err := tx.Model(&models.Branch{}).Where("id = ?", branchID).Pluck("restaurant_id", &restaurantID).Error; err != nil {
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+
+	var customer models.Customer
+	if customerID != nil {
+		if err := tx.Where("id = ? AND restaurant_id = ?", *customerID, restaurantID).First(&customer).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCustomerNotFound
+			}
+			return nil, fmt.Errorf("error fetching customer: %w", err)
+		}
+		return &customer, nil
+	}
+
+	// A number too short to identify anyone is kept on the order only
+	phone, err := normalizePhone(phone)
+	if err != nil {
+		return nil, nil
+	}
+	name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+
+	err = tx.Unscoped().Where("restaurant_id = ? AND phone = ?", restaurantID, phone).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		customer = models.Customer{RestaurantID: restaurantID, Name: name, Phone: phone, Email: email}
+		if customer.Name == "" {
+			customer.Name = "Guest " + phone
+		}
+		// A concurrent first order from the same number fails on the unique
+		// index; the order transaction retries and then finds this customer
+		if err := tx.Create(&customer).Error; err != nil {
+			return nil, fmt.Errorf("error creating customer: %w", err)
+		}
+		return &customer, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching customer: %w", err)
+	}
+
+	// A returning customer who was deleted is restored, and details they did
+	// not have yet are filled in
+	updates := map[string]interface{}{}
+	if customer.DeletedAt.Valid {
+		updates["deleted_at"] = nil
+	}
+	if customer.Email == "" && email != "" {
+		updates["email"] = email
+	}
+	if len(updates) > 0 {
+		if err := tx.Unscoped().Model(&customer).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("error updating customer: %w", err)
+		}
+	}
+	return &customer, nil
+}
+
+// CreditOrder adds a completed order to its customer's aggregates, net of
+// anything already refunded on it
+func CreditOrder(tx *gorm.DB, order *models.Order) error {
+	if order.CustomerID == nil {
+		return nil
+	}
+	spent, err := creditedSpend(tx, order)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return adjustCustomer(tx, *order.CustomerID, 1, spent, &now)
+}
+
+// DebitSpend takes part of a completed order that was refunded off its
+// customer's spend. amount is in order terms, before any cash rounding.
+func DebitSpend(tx *gorm.DB, order *models.Order, amount money.Money) error {
+	if order.CustomerID == nil || order.Status != models.OrderCompleted {
+		return nil
+	}
+	return adjustCustomer(tx, *order.CustomerID, 0, amount.Neg(), nil)
+}
+
+// ReverseOrder takes a completed order that was refunded in full off its
+// customer's order count, along with whatever of its spend is still credited
+func ReverseOrder(tx *gorm.DB, order *models.Order) error {
+	if order.CustomerID == nil {
+		return nil
+	}
+	spent, err := creditedSpend(tx, order)
+	if err != nil {
+		return err
+	}
+	return adjustCustomer(tx, *order.CustomerID, -1, spent.Neg(), nil)
+}
+
+// creditedSpend is what an order counts for in its customer's spend: its total
+// less the partial refunds on it, which are taken off as they happen. A full
+// refund is not counted, it reverses the whole order instead.
+func creditedSpend(tx *gorm.DB, order *models.Order) (money.Money, error) {
+	var refunds []models.Refund
+	err := tx.Select("amount").
+		Where("order_id = ? AND type = ? AND is_full = ?", order.ID, models.RefundTypeRefund, false).
+		Find(&refunds).Error
+	if err != nil {
+		return money.Zero, fmt.Errorf("error fetching refunds: %w", err)
+	}
+	spent := order.Total
+	for _, refund := range refunds {
+		spent = spent.Sub(refund.Amount)
+	}
+	return money.Max(spent, money.Zero), nil
+}
+
+// adjustCustomer applies changes to a customer's order count and spend and
+// re-derives their loyalty points, locking the customer row so concurrent
+// orders do not lose updates. Deleted customers keep their aggregates.
+func adjustCustomer(tx *gorm.DB, customerID uint, orders int, spent money.Money, orderedAt *time.Time) error {
+	var customer models.Customer
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "total_orders", "total_spent").
+		First(&customer, customerID).Error
+	if err != nil {
+		return fmt.Errorf("error fetching customer: %w", err)
+	}
+
+	totalSpent := customer.TotalSpent.Add(spent)
+	points := 0
+	if totalSpent.IsPositive() {
+		points = int(totalSpent.Minor() / LoyaltySpendPerPoint().Minor())
+	}
+	updates := map[string]interface{}{
+		"total_orders":   customer.TotalOrders + orders,
+		"total_spent":    totalSpent,
+		"loyalty_points": points,
+	}
+	if orderedAt != nil {
+		updates["last_order_at"] = *orderedAt
+	}
+	if err := tx.Unscoped().Model(&models.Customer{}).Where("id = ?", customerID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("error updating customer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	inventory_dto "restaurant_os/internal/api/inventory/dto"
+	inventory_services "restaurant_os/internal/api/inventory/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type inventoryController struct{}
+
+var validate = validator.New()
+
+func NewInventoryController() *inventoryController {
+	return &inventoryController{}
+}
+
+// ============================================================================
+// INVENTORY ITEMS
+// ============================================================================
+
+func (ic *inventoryController) ListInventory(c *fiber.Ctx) error {
+	var query inventory_dto.InventoryListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	items, err := inventory_services.ListInventory(&query, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch inventory")
+	}
+
+	data := make([]inventory_dto.InventoryResponse, 0, len(items))
+	for i := range items {
+		data = append(data, toInventoryResponse(&items[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Inventory fetched successfully",
+		Data:    data,
+	})
+}
+
+func (ic *inventoryController) GetInventory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	item, err := inventory_services.GetInventory(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch inventory item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Inventory item fetched successfully",
+		Data:    toInventoryResponse(item),
+	})
+}
+
+func (ic *inventoryController) CreateConversion(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req inventory_dto.CreateUnitConversionRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, inventory_dto.ConversionValidationErrorMessages)
+	}
+
+	claims := middleware.GetClaims(c)
+	if _, err := inventory_services.CreateConversion(uint(id), &req, claims); err != nil {
+		return inventoryErrorResponse(c, err, "Failed to add unit conversion")
+	}
+	item, err := inventory_services.GetInventory(uint(id), claims)
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch inventory item")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Unit conversion added successfully",
+		Data:    toInventoryResponse(item),
+	})
+}
+
+func (ic *inventoryController) DeleteConversion(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	conversionID, err := c.ParamsInt("conversionId")
+	if err != nil || conversionID <= 0 {
+		errMsg := "Invalid unit conversion ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := inventory_services.DeleteConversion(uint(id), uint(conversionID), middleware.GetClaims(c)); err != nil {
+		return inventoryErrorResponse(c, err, "Failed to delete unit conversion")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Unit conversion deleted successfully",
+	})
+}
+
+func (ic *inventoryController) ReconcileInventory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	result, err := inventory_services.ReconcileInventory(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to reconcile stock")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock reconciled with the movement ledger",
+		Data:    result,
+	})
+}
+
+// ============================================================================
+// STOCK MOVEMENTS
+// ============================================================================
+
+func (ic *inventoryController) ListMovements(c *fiber.Ctx) error {
+	var query inventory_dto.MovementListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+	return ic.listMovements(c, &query)
+}
+
+func (ic *inventoryController) ListItemMovements(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var query inventory_dto.MovementListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+	// Checks that the item exists and is visible before listing its ledger
+	if _, err := inventory_services.GetInventory(uint(id), middleware.GetClaims(c)); err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock movements")
+	}
+	inventoryID := uint(id)
+	query.InventoryID = &inventoryID
+	return ic.listMovements(c, &query)
+}
+
+func (ic *inventoryController) listMovements(c *fiber.Ctx, query *inventory_dto.MovementListQuery) error {
+	movements, total, err := inventory_services.ListMovements(query, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock movements")
+	}
+
+	data := make([]inventory_dto.MovementResponse, 0, len(movements))
+	for i := range movements {
+		data = append(data, toMovementResponse(&movements[i]))
+	}
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Stock movements fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (ic *inventoryController) CreateAdjustment(c *fiber.Ctx) error {
+	var req inventory_dto.CreateAdjustmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, inventory_dto.AdjustmentValidationErrorMessages)
+	}
+
+	movement, err := inventory_services.CreateAdjustment(&req, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to adjust stock")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock adjusted successfully",
+		Data:    toMovementResponse(movement),
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// inventoryErrorResponse maps inventory service errors to HTTP status codes
+func inventoryErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, inventory_services.ErrInventoryNotFound),
+		errors.Is(err, inventory_services.ErrConversionNotFound),
+		errors.Is(err, inventory_services.ErrBatchNotFound),
+		errors.Is(err, inventory_services.ErrStockTakeNotFound),
+		errors.Is(err, inventory_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, inventory_services.ErrConversionInUse),
+		errors.Is(err, inventory_services.ErrStockTakeStatus),
+		errors.Is(err, inventory_services.ErrStockTakeOverlap):
+		status = fiber.StatusConflict
+	case errors.Is(err, inventory_services.ErrInvalidMovementQuery),
+		errors.Is(err, inventory_services.ErrInvalidWasteQuery),
+		errors.Is(err, dto.ErrBranchRequired):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, inventory_services.ErrInvalidAdjustment),
+		errors.Is(err, inventory_services.ErrInvalidConversion),
+		errors.Is(err, inventory_services.ErrInvalidWaste),
+		errors.Is(err, inventory_services.ErrBatchShort),
+		errors.Is(err, inventory_services.ErrInvalidStockCount):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toInventoryResponse(item *models.Inventory) inventory_dto.InventoryResponse {
+	resp := inventory_dto.InventoryResponse{
+		ID:           item.ID,
+		BranchID:     item.BranchID,
+		ItemName:     item.ItemName,
+		ItemCode:     item.ItemCode,
+		Category:     item.Category,
+		Unit:         string(item.Unit),
+		CurrentStock: item.CurrentStock,
+		ReorderLevel: item.ReorderLevel,
+		MaxLevel:     item.MaxLevel,
+		UnitCost:     item.UnitCost,
+		StockValue:   stock.Value(item.UnitCost, item.CurrentStock),
+		SupplierID:   item.SupplierID,
+		SupplierName: item.SupplierName,
+		LastOrdered:  item.LastOrdered,
+		ExpiryDate:   item.ExpiryDate,
+		IsLowStock:   item.CurrentStock <= item.ReorderLevel,
+	}
+	for _, conversion := range item.Conversions {
+		c := inventory_dto.UnitConversionResponse{
+			ID:          conversion.ID,
+			InventoryID: conversion.InventoryID,
+			FromUnit:    string(conversion.FromUnit),
+			ToUnit:      string(conversion.ToUnit),
+			Factor:      conversion.Factor,
+		}
+		c.StockUnits, _ = item.ToStockUnit(1, conversion.FromUnit)
+		resp.Conversions = append(resp.Conversions, c)
+	}
+	return resp
+}
+
+func toMovementResponse(m *models.StockMovement) inventory_dto.MovementResponse {
+	resp := inventory_dto.MovementResponse{
+		ID:            m.ID,
+		BranchID:      m.BranchID,
+		InventoryID:   m.InventoryID,
+		InventoryName: m.Inventory.ItemName,
+		Unit:          string(m.Inventory.Unit),
+		Type:          string(m.Type),
+		Quantity:      m.Quantity,
+		BalanceAfter:  m.BalanceAfter,
+		UnitCost:      m.UnitCost,
+		Value:         stock.Value(m.UnitCost, m.Quantity),
+		Reason:        m.Reason,
+		ReferenceType: m.ReferenceType,
+		ReferenceID:   m.ReferenceID,
+		BatchID:       m.BatchID,
+		PostedBy:      m.PostedBy,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.PostedByUser != nil {
+		resp.PostedByName = m.PostedByUser.Name
+	}
+	return resp
+}
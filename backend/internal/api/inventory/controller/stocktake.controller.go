@@ -0,0 +1,245 @@
+package controller
+
+import (
+	inventory_dto "restaurant_os/internal/api/inventory/dto"
+	inventory_services "restaurant_os/internal/api/inventory/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ============================================================================
+// STOCK TAKES
+// ============================================================================
+
+func (ic *inventoryController) ListStockTakes(c *fiber.Ctx) error {
+	var query inventory_dto.StockTakeListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	takes, total, err := inventory_services.ListStockTakes(&query, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock takes")
+	}
+
+	data := make([]inventory_dto.StockTakeResponse, 0, len(takes))
+	for i := range takes {
+		resp := toStockTakeResponse(&takes[i])
+		resp.Lines = nil
+		data = append(data, resp)
+	}
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Stock takes fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (ic *inventoryController) GetStockTake(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock take ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	take, err := inventory_services.GetStockTake(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock take")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock take fetched successfully",
+		Data:    toStockTakeResponse(take),
+	})
+}
+
+func (ic *inventoryController) StartStockTake(c *fiber.Ctx) error {
+	var req inventory_dto.StartStockTakeRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, inventory_dto.StockTakeValidationErrorMessages)
+	}
+
+	take, err := inventory_services.StartStockTake(&req, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to start stock take")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock take started successfully",
+		Data:    toStockTakeResponse(take),
+	})
+}
+
+func (ic *inventoryController) RecordCounts(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock take ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req inventory_dto.RecordCountsRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, inventory_dto.StockTakeValidationErrorMessages)
+	}
+
+	take, err := inventory_services.RecordCounts(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to record counts")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Counts recorded successfully",
+		Data:    toStockTakeResponse(take),
+	})
+}
+
+func (ic *inventoryController) SubmitStockTake(c *fiber.Ctx) error {
+	return ic.stockTakeAction(c, inventory_services.SubmitStockTake, "Failed to submit stock take", "Stock take submitted for approval")
+}
+
+func (ic *inventoryController) ApproveStockTake(c *fiber.Ctx) error {
+	return ic.stockTakeAction(c, inventory_services.ApproveStockTake, "Failed to approve stock take", "Stock take approved and variances posted")
+}
+
+func (ic *inventoryController) CancelStockTake(c *fiber.Ctx) error {
+	return ic.stockTakeAction(c, inventory_services.CancelStockTake, "Failed to cancel stock take", "Stock take cancelled successfully")
+}
+
+func (ic *inventoryController) stockTakeAction(c *fiber.Ctx, action func(uint, *dto.Claims) (*models.StockTake, error), failure, success string) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock take ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	take, err := action(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, failure)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: success,
+		Data:    toStockTakeResponse(take),
+	})
+}
+
+func (ic *inventoryController) StockTakeUsageReport(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock take ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	report, err := inventory_services.UsageReport(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to build usage report")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Usage report fetched successfully",
+		Data:    report,
+	})
+}
+
+func toStockTakeResponse(t *models.StockTake) inventory_dto.StockTakeResponse {
+	resp := inventory_dto.StockTakeResponse{
+		ID:            t.ID,
+		BranchID:      t.BranchID,
+		Category:      t.Category,
+		Status:        string(t.Status),
+		Notes:         t.Notes,
+		StartedBy:     t.StartedBy,
+		StartedByName: t.StartedByUser.Name,
+		StartedAt:     t.StartedAt,
+		SubmittedAt:   t.SubmittedAt,
+		ApprovedBy:    t.ApprovedBy,
+		ApprovedAt:    t.ApprovedAt,
+		CancelledAt:   t.CancelledAt,
+		LineCount:     len(t.Lines),
+		Lines:         make([]inventory_dto.StockTakeLineResponse, 0, len(t.Lines)),
+	}
+	if t.ApprovedByUser != nil {
+		resp.ApprovedByName = t.ApprovedByUser.Name
+	}
+	for i := range t.Lines {
+		l := &t.Lines[i]
+		line := inventory_dto.StockTakeLineResponse{
+			ID:               l.ID,
+			InventoryID:      l.InventoryID,
+			InventoryName:    l.Inventory.ItemName,
+			Category:         l.Inventory.Category,
+			Unit:             string(l.Inventory.Unit),
+			ExpectedQuantity: l.ExpectedQuantity,
+			CountedQuantity:  l.CountedQuantity,
+			UnitCost:         l.UnitCost,
+			CountedBy:        l.CountedBy,
+			CountedAt:        l.CountedAt,
+			Notes:            l.Notes,
+			StockMovementID:  l.StockMovementID,
+		}
+		resp.ExpectedValue = resp.ExpectedValue.Add(stock.Value(l.UnitCost, l.ExpectedQuantity))
+		if l.CountedQuantity != nil {
+			variance := l.Variance()
+			value := stock.Value(l.UnitCost, variance)
+			line.Variance = &variance
+			line.VarianceValue = &value
+			resp.CountedLines++
+			resp.CountedValue = resp.CountedValue.Add(stock.Value(l.UnitCost, *l.CountedQuantity))
+			resp.VarianceValue = resp.VarianceValue.Add(value)
+			if value.IsNegative() {
+				resp.ShrinkageValue = resp.ShrinkageValue.Sub(value)
+			}
+		}
+		resp.Lines = append(resp.Lines, line)
+	}
+	return resp
+}
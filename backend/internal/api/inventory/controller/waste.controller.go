@@ -0,0 +1,178 @@
+package controller
+
+import (
+	inventory_dto "restaurant_os/internal/api/inventory/dto"
+	inventory_services "restaurant_os/internal/api/inventory/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ============================================================================
+// BATCHES
+// ============================================================================
+
+func (ic *inventoryController) ListBatches(c *fiber.Ctx) error {
+	var query inventory_dto.BatchListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+	return ic.listBatches(c, &query)
+}
+
+func (ic *inventoryController) ListItemBatches(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid inventory ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var query inventory_dto.BatchListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+	// Checks that the item exists and is visible before listing its batches
+	if _, err := inventory_services.GetInventory(uint(id), middleware.GetClaims(c)); err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock batches")
+	}
+	inventoryID := uint(id)
+	query.InventoryID = &inventoryID
+	return ic.listBatches(c, &query)
+}
+
+func (ic *inventoryController) listBatches(c *fiber.Ctx, query *inventory_dto.BatchListQuery) error {
+	batches, total, err := inventory_services.ListBatches(query, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch stock batches")
+	}
+
+	now := time.Now()
+	data := make([]inventory_dto.BatchResponse, 0, len(batches))
+	for i := range batches {
+		data = append(data, toBatchResponse(&batches[i], now))
+	}
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Stock batches fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+// ============================================================================
+// WASTE LOG
+// ============================================================================
+
+func (ic *inventoryController) ListWaste(c *fiber.Ctx) error {
+	var query inventory_dto.WasteListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	entries, total, err := inventory_services.ListWaste(&query, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to fetch waste log")
+	}
+
+	data := make([]inventory_dto.WasteResponse, 0, len(entries))
+	for i := range entries {
+		data = append(data, toWasteResponse(&entries[i]))
+	}
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Waste log fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (ic *inventoryController) LogWaste(c *fiber.Ctx) error {
+	var req inventory_dto.LogWasteRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, inventory_dto.WasteValidationErrorMessages)
+	}
+
+	entry, err := inventory_services.LogWaste(&req, middleware.GetClaims(c))
+	if err != nil {
+		return inventoryErrorResponse(c, err, "Failed to log waste")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Waste logged successfully",
+		Data:    toWasteResponse(entry),
+	})
+}
+
+func toBatchResponse(b *models.StockBatch, now time.Time) inventory_dto.BatchResponse {
+	return inventory_dto.BatchResponse{
+		ID:                b.ID,
+		BranchID:          b.BranchID,
+		InventoryID:       b.InventoryID,
+		InventoryName:     b.Inventory.ItemName,
+		Unit:              string(b.Inventory.Unit),
+		BatchNumber:       b.BatchNumber,
+		ReceivedQuantity:  b.ReceivedQuantity,
+		RemainingQuantity: b.RemainingQuantity,
+		UnitCost:          b.UnitCost,
+		ExpiryDate:        b.ExpiryDate,
+		IsExpired:         b.IsExpired(now),
+		ReceivedAt:        b.ReceivedAt,
+		ExpiryNotifiedAt:  b.ExpiryNotifiedAt,
+		DepletedAt:        b.DepletedAt,
+	}
+}
+
+func toWasteResponse(w *models.WasteLog) inventory_dto.WasteResponse {
+	resp := inventory_dto.WasteResponse{
+		ID:              w.ID,
+		BranchID:        w.BranchID,
+		InventoryID:     w.InventoryID,
+		InventoryName:   w.Inventory.ItemName,
+		Unit:            string(w.Inventory.Unit),
+		BatchID:         w.BatchID,
+		Reason:          string(w.Reason),
+		Quantity:        w.Quantity,
+		UnitCost:        w.UnitCost,
+		Cost:            w.Cost,
+		Notes:           w.Notes,
+		StockMovementID: w.StockMovementID,
+		LoggedBy:        w.LoggedBy,
+		LoggedByName:    w.LoggedByUser.Name,
+		CreatedAt:       w.CreatedAt,
+	}
+	if w.Batch != nil {
+		resp.BatchNumber = w.Batch.BatchNumber
+	}
+	return resp
+}
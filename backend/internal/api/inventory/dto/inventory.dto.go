@@ -0,0 +1,324 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// INVENTORY REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// InventoryListQuery represents filters for listing inventory items
+type InventoryListQuery struct {
+	BranchID   *uint  `query:"branch_id"`
+	Category   string `query:"category"`
+	SupplierID *uint  `query:"supplier_id"`
+	LowStock   bool   `query:"low_stock"` // Only items at or below their reorder level
+}
+
+// InventoryResponse represents an inventory item with its stock on hand
+type InventoryResponse struct {
+	ID           uint                     `json:"id"`
+	BranchID     uint                     `json:"branch_id"`
+	ItemName     string                   `json:"item_name"`
+	ItemCode     string                   `json:"item_code,omitempty"`
+	Category     string                   `json:"category,omitempty"`
+	Unit         string                   `json:"unit"`
+	CurrentStock float64                  `json:"current_stock"`
+	ReorderLevel float64                  `json:"reorder_level"`
+	MaxLevel     float64                  `json:"max_level"`
+	UnitCost     money.Money              `json:"unit_cost"`
+	StockValue   money.Money              `json:"stock_value"`
+	SupplierID   *uint                    `json:"supplier_id,omitempty"`
+	SupplierName string                   `json:"supplier_name,omitempty"`
+	LastOrdered  *time.Time               `json:"last_ordered,omitempty"`
+	ExpiryDate   *time.Time               `json:"expiry_date,omitempty"` // Earliest expiry of the batches on hand
+	IsLowStock   bool                     `json:"is_low_stock"`
+	Conversions  []UnitConversionResponse `json:"conversions,omitempty"` // Single items only
+}
+
+// CreateUnitConversionRequest adds a per-item conversion: 1 from_unit = factor to_unit
+type CreateUnitConversionRequest struct {
+	FromUnit string  `json:"from_unit" validate:"required,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"`
+	ToUnit   string  `json:"to_unit" validate:"required,oneof=KG GRAM LITER ML PIECE PACK BOTTLE,nefield=FromUnit"`
+	Factor   float64 `json:"factor" validate:"required,gt=0"`
+}
+
+// ConversionValidationErrorMessages maps unit conversion request fields to custom messages
+var ConversionValidationErrorMessages = map[string]string{
+	"FromUnit": "From unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+	"ToUnit":   "To unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE and differ from the from unit.",
+	"Factor":   "Factor must be greater than 0.",
+}
+
+// UnitConversionResponse represents a per-item unit conversion
+type UnitConversionResponse struct {
+	ID          uint    `json:"id"`
+	InventoryID uint    `json:"inventory_id"`
+	FromUnit    string  `json:"from_unit"`
+	ToUnit      string  `json:"to_unit"`
+	Factor      float64 `json:"factor"`                // to_unit quantity in one from_unit
+	StockUnits  float64 `json:"stock_units,omitempty"` // One from_unit in the unit the item is stocked in
+}
+
+// ============================================================================
+// STOCK MOVEMENT REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// MovementListQuery represents filters for listing stock movements; from and to
+// are dates (YYYY-MM-DD) in the server time zone, both inclusive
+type MovementListQuery struct {
+	dto.PaginationQuery
+	BranchID    *uint  `query:"branch_id"`
+	InventoryID *uint  `query:"inventory_id"`
+	Type        string `query:"type"`
+	From        string `query:"from"`
+	To          string `query:"to"`
+}
+
+// CreateAdjustmentRequest posts a manual correction to the stock of an item
+type CreateAdjustmentRequest struct {
+	InventoryID uint    `json:"inventory_id" validate:"required"`
+	Quantity    float64 `json:"quantity" validate:"required"`                                                 // Signed: negative takes stock out
+	Unit        string  `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Defaults to the inventory unit
+	Reason      string  `json:"reason" validate:"required,min=3,max=500"`
+	Type        string  `json:"type,omitempty" validate:"omitempty,oneof=ADJUSTMENT SUPPLIER_RETURN"` // Defaults to ADJUSTMENT; SUPPLIER_RETURN takes stock out
+}
+
+// AdjustmentValidationErrorMessages maps adjustment request fields to custom messages
+var AdjustmentValidationErrorMessages = map[string]string{
+	"InventoryID": "Inventory ID is required.",
+	"Quantity":    "Quantity is required and cannot be 0.",
+	"Unit":        "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+	"Reason":      "Reason is required and must be between 3 and 500 characters.",
+	"Type":        "Type must be ADJUSTMENT or SUPPLIER_RETURN.",
+}
+
+// MovementResponse represents one entry of the stock ledger
+type MovementResponse struct {
+	ID            uint        `json:"id"`
+	BranchID      uint        `json:"branch_id"`
+	InventoryID   uint        `json:"inventory_id"`
+	InventoryName string      `json:"inventory_name"`
+	Unit          string      `json:"unit"`
+	Type          string      `json:"type"`
+	Quantity      float64     `json:"quantity"`
+	BalanceAfter  float64     `json:"balance_after"`
+	UnitCost      money.Money `json:"unit_cost"`
+	Value         money.Money `json:"value"` // Quantity valued at the unit cost, signed
+	Reason        string      `json:"reason,omitempty"`
+	ReferenceType string      `json:"reference_type,omitempty"`
+	ReferenceID   *uint       `json:"reference_id,omitempty"`
+	BatchID       *uint       `json:"batch_id,omitempty"`
+	PostedBy      *uint       `json:"posted_by,omitempty"`
+	PostedByName  string      `json:"posted_by_name,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// ReconcileResponse reports the stock of an item before and after it was reset
+// to the sum of its movements
+type ReconcileResponse struct {
+	InventoryID   uint    `json:"inventory_id"`
+	PreviousStock float64 `json:"previous_stock"`
+	LedgerStock   float64 `json:"ledger_stock"`
+	Drift         float64 `json:"drift"` // Previous stock minus ledger stock
+}
+
+// ============================================================================
+// STOCK TAKE REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// StockTakeListQuery represents filters for listing stock takes
+type StockTakeListQuery struct {
+	dto.PaginationQuery
+	BranchID *uint  `query:"branch_id"`
+	Status   string `query:"status"`
+}
+
+// StartStockTakeRequest starts a count of a branch's stock
+type StartStockTakeRequest struct {
+	BranchID *uint  `json:"branch_id,omitempty"`                  // Defaults to the user's branch
+	Category string `json:"category,omitempty" validate:"max=50"` // Only count items of this inventory category
+	Notes    string `json:"notes,omitempty" validate:"max=1000"`
+}
+
+// StockCountInput is the counted quantity of one inventory item
+type StockCountInput struct {
+	InventoryID     uint     `json:"inventory_id" validate:"required"`
+	CountedQuantity *float64 `json:"counted_quantity" validate:"required,min=0"`
+	Unit            string   `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Defaults to the inventory unit
+	Notes           string   `json:"notes,omitempty" validate:"max=500"`
+}
+
+// RecordCountsRequest records counts on a stock take in progress; a later count
+// of the same item replaces the earlier one
+type RecordCountsRequest struct {
+	Counts []StockCountInput `json:"counts" validate:"required,min=1,max=500,dive"`
+}
+
+// StockTakeValidationErrorMessages maps stock take request fields to custom messages
+var StockTakeValidationErrorMessages = map[string]string{
+	"Category":        "Category must be at most 50 characters.",
+	"Notes":           "Notes are too long.",
+	"Counts":          "Between 1 and 500 counts are required.",
+	"InventoryID":     "Each count needs an inventory_id.",
+	"CountedQuantity": "Each count needs a counted_quantity of 0 or more.",
+	"Unit":            "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+}
+
+// StockTakeLineResponse represents one counted item with its variance
+type StockTakeLineResponse struct {
+	ID               uint         `json:"id"`
+	InventoryID      uint         `json:"inventory_id"`
+	InventoryName    string       `json:"inventory_name"`
+	Category         string       `json:"category,omitempty"`
+	Unit             string       `json:"unit"`
+	ExpectedQuantity float64      `json:"expected_quantity"`
+	CountedQuantity  *float64     `json:"counted_quantity"`
+	Variance         *float64     `json:"variance,omitempty"` // Counted minus expected
+	UnitCost         money.Money  `json:"unit_cost"`
+	VarianceValue    *money.Money `json:"variance_value,omitempty"`
+	CountedBy        *uint        `json:"counted_by,omitempty"`
+	CountedAt        *time.Time   `json:"counted_at,omitempty"`
+	Notes            string       `json:"notes,omitempty"`
+	StockMovementID  *uint        `json:"stock_movement_id,omitempty"`
+}
+
+// StockTakeResponse represents a stock take with its lines and variance totals
+type StockTakeResponse struct {
+	ID             uint                    `json:"id"`
+	BranchID       uint                    `json:"branch_id"`
+	Category       string                  `json:"category,omitempty"`
+	Status         string                  `json:"status"`
+	Notes          string                  `json:"notes,omitempty"`
+	StartedBy      uint                    `json:"started_by"`
+	StartedByName  string                  `json:"started_by_name,omitempty"`
+	StartedAt      time.Time               `json:"started_at"`
+	SubmittedAt    *time.Time              `json:"submitted_at,omitempty"`
+	ApprovedBy     *uint                   `json:"approved_by,omitempty"`
+	ApprovedByName string                  `json:"approved_by_name,omitempty"`
+	ApprovedAt     *time.Time              `json:"approved_at,omitempty"`
+	CancelledAt    *time.Time              `json:"cancelled_at,omitempty"`
+	LineCount      int                     `json:"line_count"`
+	CountedLines   int                     `json:"counted_lines"`
+	ExpectedValue  money.Money             `json:"expected_value"`
+	CountedValue   money.Money             `json:"counted_value"`   // Counted lines only
+	VarianceValue  money.Money             `json:"variance_value"`  // Net of gains and losses
+	ShrinkageValue money.Money             `json:"shrinkage_value"` // Losses only, as a positive amount
+	Lines          []StockTakeLineResponse `json:"lines,omitempty"`
+}
+
+// UsageReportLine compares what sales should have used of an item with what
+// the counts show was used since the previous approved count
+type UsageReportLine struct {
+	InventoryID      uint        `json:"inventory_id"`
+	InventoryName    string      `json:"inventory_name"`
+	Unit             string      `json:"unit"`
+	PeriodStart      *time.Time  `json:"period_start,omitempty"` // Start of the previous approved count, nil when counting from the opening balance
+	OpeningQuantity  float64     `json:"opening_quantity"`
+	ReceivedQuantity float64     `json:"received_quantity"` // Purchases and transfers in, less transfers out and supplier returns
+	AdjustedQuantity float64     `json:"adjusted_quantity"` // Manual adjustments, signed
+	ClosingQuantity  float64     `json:"closing_quantity"`
+	TheoreticalUsage float64     `json:"theoretical_usage"` // Recipe usage of sales, net of restocked refunds
+	RecordedWaste    float64     `json:"recorded_waste"`
+	ActualUsage      float64     `json:"actual_usage"`      // Opening + received + adjusted - closing
+	UnexplainedUsage float64     `json:"unexplained_usage"` // Actual - theoretical - waste
+	UnitCost         money.Money `json:"unit_cost"`
+	TheoreticalValue money.Money `json:"theoretical_value"`
+	ActualValue      money.Money `json:"actual_value"`
+	UnexplainedValue money.Money `json:"unexplained_value"`
+}
+
+// UsageReportResponse represents the theoretical versus actual usage of a stock take
+type UsageReportResponse struct {
+	StockTakeID      uint              `json:"stock_take_id"`
+	BranchID         uint              `json:"branch_id"`
+	PeriodEnd        time.Time         `json:"period_end"`
+	TheoreticalValue money.Money       `json:"theoretical_value"`
+	ActualValue      money.Money       `json:"actual_value"`
+	UnexplainedValue money.Money       `json:"unexplained_value"`
+	Lines            []UsageReportLine `json:"lines"`
+}
+
+// ============================================================================
+// BATCH AND WASTE REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// BatchListQuery represents filters for listing stock batches
+type BatchListQuery struct {
+	dto.PaginationQuery
+	BranchID        *uint `query:"branch_id"`
+	InventoryID     *uint `query:"inventory_id"`
+	ExpiringWithin  *int  `query:"expiring_within"`  // Only batches expiring within this many days, expired ones included
+	IncludeDepleted bool  `query:"include_depleted"` // Also list used up batches
+}
+
+// BatchResponse represents one batch of an inventory item
+type BatchResponse struct {
+	ID                uint        `json:"id"`
+	BranchID          uint        `json:"branch_id"`
+	InventoryID       uint        `json:"inventory_id"`
+	InventoryName     string      `json:"inventory_name"`
+	Unit              string      `json:"unit"`
+	BatchNumber       string      `json:"batch_number,omitempty"`
+	ReceivedQuantity  float64     `json:"received_quantity"`
+	RemainingQuantity float64     `json:"remaining_quantity"`
+	UnitCost          money.Money `json:"unit_cost"`
+	ExpiryDate        *time.Time  `json:"expiry_date,omitempty"`
+	IsExpired         bool        `json:"is_expired"`
+	ReceivedAt        time.Time   `json:"received_at"`
+	ExpiryNotifiedAt  *time.Time  `json:"expiry_notified_at,omitempty"`
+	DepletedAt        *time.Time  `json:"depleted_at,omitempty"`
+}
+
+// WasteListQuery represents filters for listing the waste log; from and to are
+// dates (YYYY-MM-DD) in the server time zone, both inclusive
+type WasteListQuery struct {
+	dto.PaginationQuery
+	BranchID    *uint  `query:"branch_id"`
+	InventoryID *uint  `query:"inventory_id"`
+	Reason      string `query:"reason"`
+	From        string `query:"from"`
+	To          string `query:"to"`
+}
+
+// LogWasteRequest records stock thrown away
+type LogWasteRequest struct {
+	InventoryID uint    `json:"inventory_id" validate:"required"`
+	BatchID     *uint   `json:"batch_id,omitempty"` // Take all of it from this batch instead of the oldest ones
+	Quantity    float64 `json:"quantity" validate:"required,gt=0"`
+	Unit        string  `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Defaults to the inventory unit
+	Reason      string  `json:"reason" validate:"required,oneof=EXPIRED SPOILED DROPPED OVER_PREP"`
+	Notes       string  `json:"notes,omitempty" validate:"max=500"`
+}
+
+// WasteValidationErrorMessages maps waste request fields to custom messages
+var WasteValidationErrorMessages = map[string]string{
+	"InventoryID": "Inventory ID is required.",
+	"Quantity":    "Quantity must be greater than 0.",
+	"Unit":        "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+	"Reason":      "Reason must be one of EXPIRED, SPOILED, DROPPED or OVER_PREP.",
+	"Notes":       "Notes must be at most 500 characters.",
+}
+
+// WasteResponse represents one entry of the waste log
+type WasteResponse struct {
+	ID              uint        `json:"id"`
+	BranchID        uint        `json:"branch_id"`
+	InventoryID     uint        `json:"inventory_id"`
+	InventoryName   string      `json:"inventory_name"`
+	Unit            string      `json:"unit"`
+	BatchID         *uint       `json:"batch_id,omitempty"`
+	BatchNumber     string      `json:"batch_number,omitempty"`
+	Reason          string      `json:"reason"`
+	Quantity        float64     `json:"quantity"`
+	UnitCost        money.Money `json:"unit_cost"`
+	Cost            money.Money `json:"cost"`
+	Notes           string      `json:"notes,omitempty"`
+	StockMovementID *uint       `json:"stock_movement_id,omitempty"`
+	LoggedBy        uint        `json:"logged_by"`
+	LoggedByName    string      `json:"logged_by_name,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
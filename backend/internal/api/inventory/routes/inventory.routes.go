@@ -0,0 +1,40 @@
+package routes
+
+import (
+	inventory_controller "restaurant_os/internal/api/inventory/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterInventoryRoutes(api fiber.Router) {
+
+	inventory := api.Group("/inventory", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"))
+
+	inventoryHandler := inventory_controller.NewInventoryController()
+	supervisors := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER")
+
+	inventory.Get("/", inventoryHandler.ListInventory)
+	inventory.Get("/movements", inventoryHandler.ListMovements)
+	inventory.Post("/movements", supervisors, inventoryHandler.CreateAdjustment)
+	inventory.Get("/batches", inventoryHandler.ListBatches)
+	inventory.Get("/waste", inventoryHandler.ListWaste)
+	inventory.Post("/waste", inventoryHandler.LogWaste)
+	inventory.Get("/:id", inventoryHandler.GetInventory)
+	inventory.Get("/:id/movements", inventoryHandler.ListItemMovements)
+	inventory.Get("/:id/batches", inventoryHandler.ListItemBatches)
+	inventory.Post("/:id/conversions", supervisors, inventoryHandler.CreateConversion)
+	inventory.Delete("/:id/conversions/:conversionId", supervisors, inventoryHandler.DeleteConversion)
+	inventory.Post("/:id/reconcile", supervisors, inventoryHandler.ReconcileInventory)
+
+	stockTakes := api.Group("/stock-takes", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"))
+
+	stockTakes.Get("/", inventoryHandler.ListStockTakes)
+	stockTakes.Post("/", supervisors, inventoryHandler.StartStockTake)
+	stockTakes.Get("/:id", inventoryHandler.GetStockTake)
+	stockTakes.Put("/:id/counts", inventoryHandler.RecordCounts)
+	stockTakes.Post("/:id/submit", inventoryHandler.SubmitStockTake)
+	stockTakes.Post("/:id/approve", supervisors, inventoryHandler.ApproveStockTake)
+	stockTakes.Post("/:id/cancel", supervisors, inventoryHandler.CancelStockTake)
+	stockTakes.Get("/:id/usage-report", supervisors, inventoryHandler.StockTakeUsageReport)
+}
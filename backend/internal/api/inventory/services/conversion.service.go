@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/inventory/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+)
+
+var (
+	ErrConversionNotFound = errors.New("unit conversion not found")
+	ErrInvalidConversion  = errors.New("invalid unit conversion")
+	ErrConversionInUse    = errors.New("unit conversion is in use")
+)
+
+// CreateConversion adds a per-item conversion. Units that already convert into
+// each other are rejected so that an item never has two ratios for a pair, and
+// one of the units must convert to the unit the item is stocked in.
+func CreateConversion(inventoryID uint, req *dto.CreateUnitConversionRequest, claims *common_dto.Claims) (*models.UnitConversion, error) {
+	item, err := GetInventory(inventoryID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &models.UnitConversion{
+		InventoryID: item.ID,
+		FromUnit:    models.InventoryUnit(req.FromUnit),
+		ToUnit:      models.InventoryUnit(req.ToUnit),
+		Factor:      req.Factor,
+	}
+	if existing, err := conversion.FromUnit.ConvertUsing(1, conversion.ToUnit, item.Conversions); err == nil {
+		return nil, fmt.Errorf("%w: 1 %s is already %g %s for %s", ErrInvalidConversion, conversion.FromUnit, existing, conversion.ToUnit, item.ItemName)
+	}
+	withNew := append(append([]models.UnitConversion(nil), item.Conversions...), *conversion)
+	if _, err := conversion.FromUnit.ConvertUsing(1, item.Unit, withNew); err != nil {
+		return nil, fmt.Errorf("%w: neither %s nor %s converts to %s, the unit %s is stocked in",
+			ErrInvalidConversion, conversion.FromUnit, conversion.ToUnit, item.Unit, item.ItemName)
+	}
+
+	if err := models.DataBase.Create(conversion).Error; err != nil {
+		return nil, fmt.Errorf("error creating unit conversion: %w", err)
+	}
+	return conversion, nil
+}
+
+// DeleteConversion removes a per-item conversion unless a recipe still needs it
+// to convert its quantities
+func DeleteConversion(inventoryID, conversionID uint, claims *common_dto.Claims) error {
+	item, err := GetInventory(inventoryID, claims)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]models.UnitConversion, 0, len(item.Conversions))
+	found := false
+	for _, c := range item.Conversions {
+		if c.ID == conversionID {
+			found = true
+			continue
+		}
+		remaining = append(remaining, c)
+	}
+	if !found {
+		return ErrConversionNotFound
+	}
+
+	var units []models.InventoryUnit
+	err = models.DataBase.Model(&models.RecipeIngredient{}).
+		Where("inventory_id = ?", item.ID).
+		Distinct("unit").Pluck("unit", &units).Error
+	if err != nil {
+		return fmt.Errorf("error fetching recipe units: %w", err)
+	}
+	var stranded []string
+	for _, unit := range units {
+		if _, err := unit.ConvertUsing(1, item.Unit, remaining); err != nil {
+			stranded = append(stranded, string(unit))
+		}
+	}
+	if len(stranded) > 0 {
+		return fmt.Errorf("%w: recipes use %s of %s", ErrConversionInUse, strings.Join(stranded, ", "), item.ItemName)
+	}
+
+	if err := models.DataBase.Delete(&models.UnitConversion{}, conversionID).Error; err != nil {
+		return fmt.Errorf("error deleting unit conversion: %w", err)
+	}
+	return nil
+}
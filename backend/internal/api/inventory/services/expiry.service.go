@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/notify"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExpiryCheckInterval returns how often the expiry check runs (default 24h)
+func ExpiryCheckInterval() time.Duration {
+	if config.EnvConfig == nil {
+		return 24 * time.Hour
+	}
+	if d, err := time.ParseDuration(config.EnvConfig.ExpiryCheckInterval); err == nil && d > 0 {
+		return d
+	}
+	return 24 * time.Hour
+}
+
+// ExpiryAlertDays returns how many days ahead the expiry check looks (default 3)
+func ExpiryAlertDays() int {
+	if config.EnvConfig == nil {
+		return 3
+	}
+	if days, err := strconv.Atoi(config.EnvConfig.ExpiryAlertDays); err == nil && days >= 0 {
+		return days
+	}
+	return 3
+}
+
+// CheckExpiringBatches flags the batches with stock left that expire within
+// days of now, expired ones included. Each batch is flagged once, with an
+// INVENTORY_EXPIRY notification to the managers of its branch, and returns the
+// number of batches flagged.
+func CheckExpiringBatches(now time.Time, days int) (int, error) {
+	var batches []models.StockBatch
+	err := models.DataBase.Preload("Inventory").
+		Where("remaining_quantity > 0 AND expiry_date IS NOT NULL AND expiry_notified_at IS NULL").
+		Where("expiry_date < ?", expiryCutoff(now, days)).
+		Where("inventory_id IN (?)", models.DataBase.Model(&models.Inventory{}).Select("id")).
+		Order("branch_id ASC, expiry_date ASC, id ASC").
+		Find(&batches).Error
+	if err != nil {
+		return 0, fmt.Errorf("error fetching expiring batches: %w", err)
+	}
+
+	flagged := 0
+	for i := range batches {
+		ok, err := notifyExpiry(&batches[i], now)
+		if err != nil {
+			return flagged, err
+		}
+		if ok {
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+// RunExpiryCheck is the scheduled job entry point
+func RunExpiryCheck() error {
+	flagged, err := CheckExpiringBatches(time.Now(), ExpiryAlertDays())
+	if err != nil {
+		return err
+	}
+	if flagged > 0 {
+		log.Printf("expiry check: %d batches flagged", flagged)
+	}
+	return nil
+}
+
+// notifyExpiry flags a batch and tells its branch managers. The flag is set
+// conditionally so that concurrent checks notify once.
+func notifyExpiry(batch *models.StockBatch, now time.Time) (bool, error) {
+	flagged := false
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.StockBatch{}).Where("id = ? AND expiry_notified_at IS NULL", batch.ID).Update("expiry_notified_at", now)
+		if res.Error != nil {
+			return fmt.Errorf("error flagging batch: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		item := &batch.Inventory
+		name := item.ItemName
+		if batch.BatchNumber != "" {
+			name += " (batch " + batch.BatchNumber + ")"
+		}
+		expiry := batch.ExpiryDate.In(time.Local).Format(time.DateOnly)
+		verb := "expires on"
+		if batch.IsExpired(now) {
+			verb = "expired on"
+		}
+		inventoryID := item.ID
+		base := models.Notification{
+			Type:    models.NotificationInventoryExpiry,
+			Title:   "Expiring stock: " + item.ItemName,
+			Message: fmt.Sprintf("%g %s of %s %s %s", batch.RemainingQuantity, item.Unit, name, verb, expiry),
+			Data: fmt.Sprintf(`{"batch_id":%d,"remaining_quantity":%g,"unit":%q,"expiry_date":%q}`,
+				batch.ID, batch.RemainingQuantity, item.Unit, expiry),
+			InventoryID: &inventoryID,
+		}
+
+		if _, err := notify.BranchManagers(tx, batch.BranchID, base); err != nil {
+			return err
+		}
+		flagged = true
+		return nil
+	})
+	return flagged, err
+}
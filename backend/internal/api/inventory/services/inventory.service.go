@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/inventory/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInventoryNotFound    = stock.ErrInventoryNotFound
+	ErrInvalidAdjustment    = errors.New("invalid stock adjustment")
+	ErrInvalidMovementQuery = errors.New("invalid stock movement filter")
+)
+
+// ListInventory returns the inventory items visible to the requester
+func ListInventory(query *dto.InventoryListQuery, claims *common_dto.Claims) ([]models.Inventory, error) {
+	db := claims.ScopeBranches(models.DataBase.Model(&models.Inventory{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.Category != "" {
+		db = db.Where("category = ?", query.Category)
+	}
+	if query.SupplierID != nil {
+		db = db.Where("supplier_id = ?", *query.SupplierID)
+	}
+	if query.LowStock {
+		db = db.Where("current_stock <= reorder_level")
+	}
+
+	var items []models.Inventory
+	if err := db.Order("branch_id ASC, item_name ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("error fetching inventory: %w", err)
+	}
+	return items, nil
+}
+
+// GetInventory returns an inventory item if its branch is visible to the requester
+func GetInventory(id uint, claims *common_dto.Claims) (*models.Inventory, error) {
+	var item models.Inventory
+	err := models.DataBase.Preload("Branch").
+		Preload("Conversions", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&item, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInventoryNotFound
+		}
+		return nil, fmt.Errorf("error fetching inventory item: %w", err)
+	}
+	if !claims.CanAccessBranch(item.BranchID, item.Branch.RestaurantID) {
+		// Do not reveal stock of other tenants
+		return nil, ErrInventoryNotFound
+	}
+	return &item, nil
+}
+
+// ListMovements returns a page of stock movements visible to the requester, newest first
+func ListMovements(query *dto.MovementListQuery, claims *common_dto.Claims) ([]models.StockMovement, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.StockMovement{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.InventoryID != nil {
+		db = db.Where("inventory_id = ?", *query.InventoryID)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", strings.ToUpper(query.Type))
+	}
+	if query.From != "" {
+		from, err := time.ParseInLocation(time.DateOnly, query.From, time.Local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: from must be a date like 2006-01-02", ErrInvalidMovementQuery)
+		}
+		db = db.Where("created_at >= ?", from)
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation(time.DateOnly, query.To, time.Local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: to must be a date like 2006-01-02", ErrInvalidMovementQuery)
+		}
+		db = db.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting stock movements: %w", err)
+	}
+
+	var movements []models.StockMovement
+	err := preloadMovement(db).
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&movements).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching stock movements: %w", err)
+	}
+	return movements, total, nil
+}
+
+// CreateAdjustment posts a manual correction with its reason to the stock ledger,
+// or goods sent back to the supplier when the request is a SUPPLIER_RETURN
+func CreateAdjustment(req *dto.CreateAdjustmentRequest, claims *common_dto.Claims) (*models.StockMovement, error) {
+	item, err := GetInventory(req.InventoryID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if req.Unit != "" {
+		if quantity, err = item.ToStockUnit(req.Quantity, models.InventoryUnit(req.Unit)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAdjustment, err)
+		}
+	}
+	if quantity > -0.0005 && quantity < 0.0005 {
+		return nil, fmt.Errorf("%w: quantity rounds to 0 %s", ErrInvalidAdjustment, item.Unit)
+	}
+	movementType := models.MovementAdjustment
+	if req.Type != "" {
+		movementType = models.StockMovementType(req.Type)
+	}
+	if movementType == models.MovementSupplierReturn && quantity > 0 {
+		return nil, fmt.Errorf("%w: a supplier return must take stock out", ErrInvalidAdjustment)
+	}
+
+	var movement *models.StockMovement
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		movement, err = stock.Post(tx, stock.Movement{
+			InventoryID: item.ID,
+			Type:        movementType,
+			Quantity:    quantity,
+			Reason:      strings.TrimSpace(req.Reason),
+			PostedBy:    &claims.UserID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getMovement(movement.ID)
+}
+
+// ReconcileInventory resets the current stock of an item to the sum of its
+// movements and returns the stock before and after
+func ReconcileInventory(id uint, claims *common_dto.Claims) (*dto.ReconcileResponse, error) {
+	item, err := GetInventory(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReconcileResponse{InventoryID: item.ID}
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		resp.PreviousStock, resp.LedgerStock, err = stock.Reconcile(tx, item.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Drift = stock.RoundQuantity(resp.PreviousStock - resp.LedgerStock)
+	return resp, nil
+}
+
+func getMovement(id uint) (*models.StockMovement, error) {
+	var movement models.StockMovement
+	if err := preloadMovement(models.DataBase).First(&movement, id).Error; err != nil {
+		return nil, fmt.Errorf("error fetching stock movement: %w", err)
+	}
+	return &movement, nil
+}
+
+func preloadMovement(db *gorm.DB) *gorm.DB {
+	return db.Preload("Inventory").Preload("PostedByUser")
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/inventory/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStockTakeNotFound = errors.New("stock take not found")
+	ErrBranchNotFound    = errors.New("branch not found")
+	ErrStockTakeStatus   = errors.New("stock take status does not allow this")
+	ErrStockTakeOverlap  = errors.New("another stock take of these items is still open")
+	ErrInvalidStockCount = errors.New("invalid stock count")
+)
+
+// ListStockTakes returns a page of stock takes visible to the requester, newest first
+func ListStockTakes(query *dto.StockTakeListQuery, claims *common_dto.Claims) ([]models.StockTake, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.StockTake{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", strings.ToUpper(query.Status))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting stock takes: %w", err)
+	}
+
+	var takes []models.StockTake
+	err := preloadStockTake(db).
+		Order("started_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&takes).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching stock takes: %w", err)
+	}
+	return takes, total, nil
+}
+
+// GetStockTake returns a stock take with its lines if its branch is visible to the requester
+func GetStockTake(id uint, claims *common_dto.Claims) (*models.StockTake, error) {
+	var take models.StockTake
+	if err := preloadStockTake(claims.ScopeBranches(models.DataBase, "branch_id")).First(&take, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStockTakeNotFound
+		}
+		return nil, fmt.Errorf("error fetching stock take: %w", err)
+	}
+	return &take, nil
+}
+
+// StartStockTake freezes the expected quantity and unit cost of every item of
+// the branch, or of one category, for counting
+func StartStockTake(req *dto.StartStockTakeRequest, claims *common_dto.Claims) (*models.StockTake, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+	category := strings.TrimSpace(req.Category)
+
+	take := &models.StockTake{
+		BranchID:  branch.ID,
+		Category:  category,
+		Status:    models.StockTakeInProgress,
+		Notes:     req.Notes,
+		StartedBy: claims.UserID,
+		StartedAt: time.Now(),
+	}
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		// Two open takes of the same items would post their variances twice
+		open := tx.Model(&models.StockTake{}).
+			Where("branch_id = ? AND status IN ?", branch.ID, []models.StockTakeStatus{models.StockTakeInProgress, models.StockTakeSubmitted})
+		if category != "" {
+			open = open.Where("category = '' OR category = ?", category)
+		}
+		var count int64
+		if err := open.Count(&count).Error; err != nil {
+			return fmt.Errorf("error checking open stock takes: %w", err)
+		}
+		if count > 0 {
+			return ErrStockTakeOverlap
+		}
+
+		items := tx.Where("branch_id = ?", branch.ID)
+		if category != "" {
+			items = items.Where("category = ?", category)
+		}
+		var inventory []models.Inventory
+		if err := items.Order("item_name ASC, id ASC").Find(&inventory).Error; err != nil {
+			return fmt.Errorf("error fetching inventory: %w", err)
+		}
+		if len(inventory) == 0 {
+			return fmt.Errorf("%w: there are no inventory items to count", ErrInvalidStockCount)
+		}
+		for _, item := range inventory {
+			take.Lines = append(take.Lines, models.StockTakeLine{
+				InventoryID:      item.ID,
+				ExpectedQuantity: item.CurrentStock,
+				UnitCost:         item.UnitCost,
+			})
+		}
+
+		if err := tx.Omit("Branch", "StartedByUser", "ApprovedByUser", "Lines.Inventory").Create(take).Error; err != nil {
+			return fmt.Errorf("error creating stock take: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetStockTake(take.ID, claims)
+}
+
+// RecordCounts stores counted quantities, converted to the inventory unit, on a
+// stock take in progress
+func RecordCounts(id uint, req *dto.RecordCountsRequest, claims *common_dto.Claims) (*models.StockTake, error) {
+	take, err := GetStockTake(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if take.Status != models.StockTakeInProgress {
+		return nil, fmt.Errorf("%w: counts can only be recorded while the take is in progress", ErrStockTakeStatus)
+	}
+
+	lines := make(map[uint]*models.StockTakeLine, len(take.Lines))
+	for i := range take.Lines {
+		lines[take.Lines[i].InventoryID] = &take.Lines[i]
+	}
+	now := time.Now()
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		for _, in := range req.Counts {
+			line, ok := lines[in.InventoryID]
+			if !ok {
+				return fmt.Errorf("%w: inventory item %d is not part of this stock take", ErrInvalidStockCount, in.InventoryID)
+			}
+			counted := *in.CountedQuantity
+			if in.Unit != "" {
+				if counted, err = line.Inventory.ToStockUnit(counted, models.InventoryUnit(in.Unit)); err != nil {
+					return fmt.Errorf("%w: %s: %v", ErrInvalidStockCount, line.Inventory.ItemName, err)
+				}
+			}
+			counted = stock.RoundQuantity(counted)
+			err := tx.Model(&models.StockTakeLine{}).Where("id = ?", line.ID).Updates(map[string]interface{}{
+				"counted_quantity": counted,
+				"counted_by":       claims.UserID,
+				"counted_at":       now,
+				"notes":            in.Notes,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("error recording count: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetStockTake(take.ID, claims)
+}
+
+// SubmitStockTake closes counting once every line has a count
+func SubmitStockTake(id uint, claims *common_dto.Claims) (*models.StockTake, error) {
+	take, err := GetStockTake(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if take.Status != models.StockTakeInProgress {
+		return nil, fmt.Errorf("%w: only stock takes in progress can be submitted", ErrStockTakeStatus)
+	}
+	var missing []string
+	for _, line := range take.Lines {
+		if line.CountedQuantity == nil {
+			missing = append(missing, line.Inventory.ItemName)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %d item(s) are not counted yet: %s", ErrInvalidStockCount, len(missing), strings.Join(missing, ", "))
+	}
+
+	if err := setStockTakeStatus(models.DataBase, take, models.StockTakeSubmitted, map[string]interface{}{"submitted_at": time.Now()}); err != nil {
+		return nil, err
+	}
+	return GetStockTake(take.ID, claims)
+}
+
+// ApproveStockTake posts the variance of every counted line as a STOCK_TAKE
+// movement. Sales during the count moved stock after the expected quantities
+// were frozen, so the variance is applied rather than the counted quantity.
+func ApproveStockTake(id uint, claims *common_dto.Claims) (*models.StockTake, error) {
+	take, err := GetStockTake(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if take.Status != models.StockTakeSubmitted {
+		return nil, fmt.Errorf("%w: only submitted stock takes can be approved", ErrStockTakeStatus)
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := setStockTakeStatus(tx, take, models.StockTakeApproved, map[string]interface{}{
+			"approved_by": claims.UserID,
+			"approved_at": now,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Lines are in name order; post in ID order so concurrent postings lock rows alike
+		lines := append([]models.StockTakeLine(nil), take.Lines...)
+		sort.Slice(lines, func(i, j int) bool { return lines[i].InventoryID < lines[j].InventoryID })
+		for _, line := range lines {
+			variance := line.Variance()
+			if variance == 0 {
+				continue
+			}
+			unitCost := line.UnitCost
+			movement, err := stock.Post(tx, stock.Movement{
+				InventoryID:   line.InventoryID,
+				Type:          models.MovementStockTake,
+				Quantity:      variance,
+				UnitCost:      &unitCost,
+				Reason:        fmt.Sprintf("stock take %d: counted %g, expected %g", take.ID, *line.CountedQuantity, line.ExpectedQuantity),
+				ReferenceType: "stock_take",
+				ReferenceID:   &take.ID,
+				PostedBy:      &claims.UserID,
+			})
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&models.StockTakeLine{}).Where("id = ?", line.ID).Update("stock_movement_id", movement.ID).Error; err != nil {
+				return fmt.Errorf("error linking stock movement: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetStockTake(take.ID, claims)
+}
+
+// CancelStockTake abandons a stock take that was not approved
+func CancelStockTake(id uint, claims *common_dto.Claims) (*models.StockTake, error) {
+	take, err := GetStockTake(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if take.Status != models.StockTakeInProgress && take.Status != models.StockTakeSubmitted {
+		return nil, fmt.Errorf("%w: stock take is already %s", ErrStockTakeStatus, strings.ToLower(string(take.Status)))
+	}
+	if err := setStockTakeStatus(models.DataBase, take, models.StockTakeCancelled, map[string]interface{}{"cancelled_at": time.Now()}); err != nil {
+		return nil, err
+	}
+	return GetStockTake(take.ID, claims)
+}
+
+// UsageReport compares the theoretical usage of each counted item, from the
+// recipes of what was sold, with the actual usage shown by the counts. The
+// period runs from the start of the previous approved count of the item, or
+// from its opening balance, to the start of this take.
+func UsageReport(id uint, claims *common_dto.Claims) (*dto.UsageReportResponse, error) {
+	take, err := GetStockTake(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if take.Status != models.StockTakeSubmitted && take.Status != models.StockTakeApproved {
+		return nil, fmt.Errorf("%w: the usage report needs a submitted or approved stock take", ErrStockTakeStatus)
+	}
+
+	resp := &dto.UsageReportResponse{
+		StockTakeID: take.ID,
+		BranchID:    take.BranchID,
+		PeriodEnd:   take.StartedAt,
+		Lines:       make([]dto.UsageReportLine, 0, len(take.Lines)),
+	}
+	for i := range take.Lines {
+		line, err := usageOf(take, &take.Lines[i])
+		if err != nil {
+			return nil, err
+		}
+		resp.TheoreticalValue = resp.TheoreticalValue.Add(line.TheoreticalValue)
+		resp.ActualValue = resp.ActualValue.Add(line.ActualValue)
+		resp.UnexplainedValue = resp.UnexplainedValue.Add(line.UnexplainedValue)
+		resp.Lines = append(resp.Lines, *line)
+	}
+	return resp, nil
+}
+
+// usageOf sums the ledger of one item between its previous approved count and this one
+func usageOf(take *models.StockTake, line *models.StockTakeLine) (*dto.UsageReportLine, error) {
+	report := &dto.UsageReportLine{
+		InventoryID:     line.InventoryID,
+		InventoryName:   line.Inventory.ItemName,
+		Unit:            string(line.Inventory.Unit),
+		ClosingQuantity: *line.CountedQuantity,
+		UnitCost:        line.UnitCost,
+	}
+
+	var previous models.StockTakeLine
+	err := models.DataBase.Select("stock_take_lines.*").
+		Joins("JOIN stock_takes ON stock_takes.id = stock_take_lines.stock_take_id").
+		Where("stock_take_lines.inventory_id = ? AND stock_takes.status = ? AND stock_takes.started_at < ?",
+			line.InventoryID, models.StockTakeApproved, take.StartedAt).
+		Order("stock_takes.started_at DESC").
+		Limit(1).
+		Find(&previous).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching previous stock take: %w", err)
+	}
+
+	movements := models.DataBase.Model(&models.StockMovement{}).
+		Where("inventory_id = ? AND created_at < ?", line.InventoryID, take.StartedAt)
+	if previous.ID != 0 {
+		var start time.Time
+		err := models.DataBase.Model(&models.StockTake{}).Where("id = ?", previous.StockTakeID).Pluck("started_at", &start).Error
+		if err != nil {
+			return nil, fmt.Errorf("error fetching previous stock take: %w", err)
+		}
+		report.PeriodStart = &start
+		report.OpeningQuantity = *previous.CountedQuantity
+		movements = movements.Where("created_at >= ?", start)
+	}
+
+	var sums []struct {
+		Type     models.StockMovementType
+		Quantity float64
+	}
+	if err := movements.Select("type, SUM(quantity) AS quantity").Group("type").Scan(&sums).Error; err != nil {
+		return nil, fmt.Errorf("error summing stock movements: %w", err)
+	}
+	for _, sum := range sums {
+		switch sum.Type {
+		case models.MovementOpening:
+			if previous.ID == 0 {
+				report.OpeningQuantity += sum.Quantity
+			}
+		case models.MovementPurchase, models.MovementTransferIn, models.MovementTransferOut, models.MovementSupplierReturn:
+			report.ReceivedQuantity += sum.Quantity
+		case models.MovementAdjustment:
+			report.AdjustedQuantity += sum.Quantity
+		case models.MovementSale:
+			report.TheoreticalUsage -= sum.Quantity
+		case models.MovementWaste:
+			report.RecordedWaste -= sum.Quantity
+		}
+		// STOCK_TAKE movements only bring the books in line with the previous count
+	}
+
+	report.OpeningQuantity = stock.RoundQuantity(report.OpeningQuantity)
+	report.ReceivedQuantity = stock.RoundQuantity(report.ReceivedQuantity)
+	report.AdjustedQuantity = stock.RoundQuantity(report.AdjustedQuantity)
+	report.TheoreticalUsage = stock.RoundQuantity(report.TheoreticalUsage)
+	report.RecordedWaste = stock.RoundQuantity(report.RecordedWaste)
+	report.ActualUsage = stock.RoundQuantity(report.OpeningQuantity + report.ReceivedQuantity + report.AdjustedQuantity - report.ClosingQuantity)
+	report.UnexplainedUsage = stock.RoundQuantity(report.ActualUsage - report.TheoreticalUsage - report.RecordedWaste)
+	report.TheoreticalValue = stock.Value(line.UnitCost, report.TheoreticalUsage)
+	report.ActualValue = stock.Value(line.UnitCost, report.ActualUsage)
+	report.UnexplainedValue = stock.Value(line.UnitCost, report.UnexplainedUsage)
+	return report, nil
+}
+
+// setStockTakeStatus moves a stock take on from the status it was read with, so
+// that concurrent requests cannot both act on it
+func setStockTakeStatus(tx *gorm.DB, take *models.StockTake, status models.StockTakeStatus, updates map[string]interface{}) error {
+	updates["status"] = status
+	res := tx.Model(&models.StockTake{}).Where("id = ? AND status = ?", take.ID, take.Status).Updates(updates)
+	if res.Error != nil {
+		return fmt.Errorf("error updating stock take: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: stock take changed, try again", ErrStockTakeStatus)
+	}
+	return nil
+}
+
+func resolveBranch(requested *uint, claims *common_dto.Claims) (*models.Branch, error) {
+	branchID, err := claims.ResolveBranchID(requested)
+	if err != nil {
+		return nil, err
+	}
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, branchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	return &branch, nil
+}
+
+func preloadStockTake(db *gorm.DB) *gorm.DB {
+	return db.Preload("StartedByUser").Preload("ApprovedByUser").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Select("stock_take_lines.*").
+				Joins("JOIN inventories ON inventories.id = stock_take_lines.inventory_id").
+				Order("inventories.item_name ASC, stock_take_lines.id ASC")
+		}).
+		Preload("Lines.Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Lines.Inventory.Conversions")
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/inventory/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBatchNotFound     = stock.ErrBatchNotFound
+	ErrBatchShort        = stock.ErrBatchShort
+	ErrInvalidWaste      = errors.New("invalid waste entry")
+	ErrInvalidWasteQuery = errors.New("invalid waste query")
+)
+
+// ListBatches returns a page of stock batches visible to the requester, oldest
+// first, or soonest to expire first when filtering on expiry
+func ListBatches(query *dto.BatchListQuery, claims *common_dto.Claims) ([]models.StockBatch, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.StockBatch{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.InventoryID != nil {
+		db = db.Where("inventory_id = ?", *query.InventoryID)
+	}
+	if !query.IncludeDepleted {
+		db = db.Where("remaining_quantity > 0")
+	}
+	order := "received_at ASC, id ASC"
+	if query.ExpiringWithin != nil {
+		if *query.ExpiringWithin < 0 {
+			return nil, 0, fmt.Errorf("%w: expiring_within cannot be negative", ErrInvalidWasteQuery)
+		}
+		db = db.Where("expiry_date IS NOT NULL AND expiry_date < ?", expiryCutoff(time.Now(), *query.ExpiringWithin))
+		order = "expiry_date ASC, id ASC"
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting stock batches: %w", err)
+	}
+
+	var batches []models.StockBatch
+	err := db.Preload("Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order(order).
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&batches).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching stock batches: %w", err)
+	}
+	return batches, total, nil
+}
+
+// ListWaste returns a page of the waste log visible to the requester, newest first
+func ListWaste(query *dto.WasteListQuery, claims *common_dto.Claims) ([]models.WasteLog, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.WasteLog{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.InventoryID != nil {
+		db = db.Where("inventory_id = ?", *query.InventoryID)
+	}
+	if query.Reason != "" {
+		db = db.Where("reason = ?", strings.ToUpper(query.Reason))
+	}
+	if query.From != "" {
+		from, err := time.ParseInLocation(time.DateOnly, query.From, time.Local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: from must be a date like 2006-01-02", ErrInvalidWasteQuery)
+		}
+		db = db.Where("created_at >= ?", from)
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation(time.DateOnly, query.To, time.Local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: to must be a date like 2006-01-02", ErrInvalidWasteQuery)
+		}
+		db = db.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting waste log: %w", err)
+	}
+
+	var entries []models.WasteLog
+	err := preloadWaste(db).
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching waste log: %w", err)
+	}
+	return entries, total, nil
+}
+
+// LogWaste records stock thrown away and posts it as a WASTE movement. The cost
+// is the wasted quantity at the item's average unit cost, the value the ledger
+// takes out.
+func LogWaste(req *dto.LogWasteRequest, claims *common_dto.Claims) (*models.WasteLog, error) {
+	item, err := GetInventory(req.InventoryID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if req.Unit != "" {
+		if quantity, err = item.ToStockUnit(req.Quantity, models.InventoryUnit(req.Unit)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWaste, err)
+		}
+	}
+	quantity = stock.RoundQuantity(quantity)
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity rounds to 0 %s", ErrInvalidWaste, item.Unit)
+	}
+
+	entry := &models.WasteLog{
+		BranchID:    item.BranchID,
+		InventoryID: item.ID,
+		BatchID:     req.BatchID,
+		Reason:      models.WasteReason(req.Reason),
+		Quantity:    quantity,
+		Notes:       strings.TrimSpace(req.Notes),
+		LoggedBy:    claims.UserID,
+	}
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Inventory", "Batch", "LoggedByUser").Create(entry).Error; err != nil {
+			return fmt.Errorf("error creating waste entry: %w", err)
+		}
+
+		reason := "waste: " + strings.ToLower(strings.ReplaceAll(req.Reason, "_", " "))
+		if entry.Notes != "" {
+			reason += ", " + entry.Notes
+		}
+		movement, err := stock.Post(tx, stock.Movement{
+			InventoryID:   item.ID,
+			Type:          models.MovementWaste,
+			Quantity:      -quantity,
+			Reason:        reason,
+			ReferenceType: "waste_log",
+			ReferenceID:   &entry.ID,
+			PostedBy:      &claims.UserID,
+			BatchID:       req.BatchID,
+		})
+		if err != nil {
+			return err
+		}
+
+		entry.StockMovementID = &movement.ID
+		entry.UnitCost = movement.UnitCost
+		entry.Cost = stock.Value(movement.UnitCost, quantity)
+		err = tx.Model(entry).Updates(map[string]interface{}{
+			"stock_movement_id": entry.StockMovementID,
+			"unit_cost":         entry.UnitCost,
+			"cost":              entry.Cost,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error updating waste entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var logged models.WasteLog
+	if err := preloadWaste(models.DataBase).First(&logged, entry.ID).Error; err != nil {
+		return nil, fmt.Errorf("error fetching waste entry: %w", err)
+	}
+	return &logged, nil
+}
+
+// expiryCutoff returns the start of the day after the last day that counts as
+// expiring within days of now
+func expiryCutoff(now time.Time, days int) time.Time {
+	year, month, day := now.In(time.Local).Date()
+	return time.Date(year, month, day+days+1, 0, 0, 0, 0, time.Local)
+}
+
+func preloadWaste(db *gorm.DB) *gorm.DB {
+	return db.Preload("Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Batch").Preload("LoggedByUser")
+}
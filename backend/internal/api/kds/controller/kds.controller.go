@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	kds_dto "restaurant_os/internal/api/kds/dto"
+	kds_services "restaurant_os/internal/api/kds/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/realtime"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// heartbeatInterval keeps idle streams open through proxies and detects closed connections
+const heartbeatInterval = 20 * time.Second
+
+type kdsController struct{}
+
+func NewKDSController() *kdsController {
+	return &kdsController{}
+}
+
+// GetTickets returns the branch kitchen queue grouped by order, oldest first
+func (kc *kdsController) GetTickets(c *fiber.Ctx) error {
+	branchID, err := kc.authorizedBranch(c)
+	if err != nil {
+		return kdsErrorResponse(c, err, "Failed to fetch kitchen tickets")
+	}
+
+	var query kds_dto.KDSQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	tickets, err := kds_services.GetTickets(branchID, &query)
+	if err != nil {
+		return kdsErrorResponse(c, err, "Failed to fetch kitchen tickets")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Kitchen tickets fetched successfully",
+		Data:    tickets,
+	})
+}
+
+// GetItems returns the branch kitchen queue as a flat list of items, oldest first
+func (kc *kdsController) GetItems(c *fiber.Ctx) error {
+	branchID, err := kc.authorizedBranch(c)
+	if err != nil {
+		return kdsErrorResponse(c, err, "Failed to fetch kitchen items")
+	}
+
+	var query kds_dto.KDSQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	items, err := kds_services.GetQueueItems(branchID, &query)
+	if err != nil {
+		return kdsErrorResponse(c, err, "Failed to fetch kitchen items")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Kitchen items fetched successfully",
+		Data:    items,
+	})
+}
+
+// Stream pushes ticket updates to kitchen screens as Server-Sent Events
+// whenever an order is created or one of its items changes status
+func (kc *kdsController) Stream(c *fiber.Ctx) error {
+	branchID, err := kc.authorizedBranch(c)
+	if err != nil {
+		return kdsErrorResponse(c, err, "Failed to open kitchen stream")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	events, unsubscribe := realtime.Subscribe(branchID)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		fmt.Fprintf(w, "event: ready\ndata: {\"branch_id\":%d}\n\n", branchID)
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				ticket, err := kds_services.GetTicket(event.OrderID)
+				if err != nil {
+					log.Printf("kds stream: branch %d, order %d: %v", branchID, event.OrderID, err)
+					continue
+				}
+				payload, _ := json.Marshal(kds_dto.KDSStreamMessage{
+					Type:        event.Type,
+					OrderID:     event.OrderID,
+					OrderItemID: event.OrderItemID,
+					Ticket:      ticket,
+					At:          event.At,
+				})
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			// A failed flush means the screen disconnected
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+	return nil
+}
+
+func (kc *kdsController) authorizedBranch(c *fiber.Ctx) (uint, error) {
+	branchID, err := c.ParamsInt("branchId")
+	if err != nil || branchID <= 0 {
+		return 0, errInvalidBranchID
+	}
+	if err := kds_services.AuthorizeBranch(uint(branchID), middleware.GetClaims(c)); err != nil {
+		return 0, err
+	}
+	return uint(branchID), nil
+}
+
+var errInvalidBranchID = errors.New("invalid branch ID")
+
+// kdsErrorResponse maps KDS service errors to HTTP status codes
+func kdsErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidBranchID), errors.Is(err, kds_services.ErrInvalidStatus):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, kds_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden):
+		status = fiber.StatusForbidden
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// KITCHEN DISPLAY SYSTEM REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// KDSQuery represents filters for the kitchen queue
+type KDSQuery struct {
+	Status      string `query:"status"`       // Comma separated item statuses, defaults to PENDING,PREPARING,READY
+	OverdueOnly bool   `query:"overdue_only"` // Only return overdue items
+}
+
+// KDSItem represents a single order item on a kitchen screen
+type KDSItem struct {
+	ID               uint          `json:"id"`
+	OrderID          uint          `json:"order_id"`
+	OrderNumber      string        `json:"order_number,omitempty"`
+	MenuItemID       uint          `json:"menu_item_id"`
+	Name             string        `json:"name"`
+	VariantName      string        `json:"variant_name,omitempty"`
+	Modifiers        []KDSModifier `json:"modifiers"`
+	ComboName        string        `json:"combo_name,omitempty"` // Combo the item was ordered in
+	Quantity         int           `json:"quantity"`
+	Notes            string        `json:"notes,omitempty"`
+	Status           string        `json:"status"`
+	PrepTime         int           `json:"prep_time"` // minutes
+	OrderedAt        time.Time     `json:"ordered_at"`
+	DueAt            time.Time     `json:"due_at"`
+	RemainingSeconds int64         `json:"remaining_seconds"` // negative when overdue
+	Overdue          bool          `json:"overdue"`
+}
+
+// KDSModifier represents a modifier the kitchen must apply, e.g. "Spice level: Hot"
+type KDSModifier struct {
+	Group string `json:"group"`
+	Name  string `json:"name"`
+}
+
+// KDSTicket represents an order with its kitchen items
+type KDSTicket struct {
+	OrderID     uint      `json:"order_id"`
+	OrderNumber string    `json:"order_number"`
+	OrderType   string    `json:"order_type"`
+	OrderStatus string    `json:"order_status"`
+	TableID     *uint     `json:"table_id,omitempty"`
+	TableNumber string    `json:"table_number,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	AgeSeconds  int64     `json:"age_seconds"`
+	Overdue     bool      `json:"overdue"` // At least one item is overdue
+	Items       []KDSItem `json:"items"`
+}
+
+// KDSStreamMessage is pushed to kitchen screens over the event stream.
+// Ticket is nil when the order no longer has anything for the kitchen.
+type KDSStreamMessage struct {
+	Type        string     `json:"type"`
+	OrderID     uint       `json:"order_id"`
+	OrderItemID uint       `json:"order_item_id,omitempty"`
+	Ticket      *KDSTicket `json:"ticket"`
+	At          time.Time  `json:"at"`
+}
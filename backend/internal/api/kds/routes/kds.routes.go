@@ -0,0 +1,21 @@
+package routes
+
+import (
+	kds_controller "restaurant_os/internal/api/kds/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterKDSRoutes(api fiber.Router) {
+
+	kdsHandler := kds_controller.NewKDSController()
+	kitchenRoles := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF", "KITCHEN_STAFF", "WAITER")
+
+	// EventSource cannot send headers, so the stream also accepts ?access_token=
+	api.Get("/kds/branches/:branchId/stream", middleware.TokenFromQuery(), middleware.RequireAuth(), kitchenRoles, kdsHandler.Stream)
+
+	kds := api.Group("/kds", middleware.RequireAuth(), kitchenRoles)
+	kds.Get("/branches/:branchId/tickets", kdsHandler.GetTickets)
+	kds.Get("/branches/:branchId/items", kdsHandler.GetItems)
+}
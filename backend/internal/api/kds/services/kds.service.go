@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/kds/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBranchNotFound = errors.New("branch not found")
+	ErrInvalidStatus  = errors.New("invalid item status")
+)
+
+// defaultStatuses are the item statuses a kitchen screen shows
+var defaultStatuses = []models.OrderItemStatus{models.OrderItemPending, models.OrderItemPreparing, models.OrderItemReady}
+
+// hiddenOrderStatuses are orders the kitchen no longer needs to see
+var hiddenOrderStatuses = []models.OrderStatus{models.OrderServed, models.OrderCompleted, models.OrderCancelled, models.OrderRefunded}
+
+// AuthorizeBranch checks the branch exists and the requester may view it
+func AuthorizeBranch(branchID uint, claims *common_dto.Claims) error {
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, branchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBranchNotFound
+		}
+		return fmt.Errorf("error fetching branch: %w", err)
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return common_dto.ErrBranchForbidden
+	}
+	return nil
+}
+
+// GetQueueItems returns the kitchen items of a branch, oldest first
+func GetQueueItems(branchID uint, query *dto.KDSQuery) ([]dto.KDSItem, error) {
+	tickets, err := GetTickets(branchID, query)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.KDSItem, 0)
+	for _, ticket := range tickets {
+		items = append(items, ticket.Items...)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].OrderedAt.Before(items[j].OrderedAt)
+	})
+	return items, nil
+}
+
+// GetTickets returns the kitchen items of a branch grouped by order, oldest order first
+func GetTickets(branchID uint, query *dto.KDSQuery) ([]dto.KDSTicket, error) {
+	statuses, err := parseStatuses(query.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []models.Order
+	err = models.DataBase.
+		Preload("Table").
+		Preload("OrderItems", "status IN ?", statuses, func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("OrderItems.MenuItem").
+		Preload("OrderItems.Modifiers").
+		Preload("Combos").
+		Where("branch_id = ? AND status NOT IN ?", branchID, hiddenOrderStatuses).
+		Order("created_at ASC").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching kitchen queue: %w", err)
+	}
+
+	now := time.Now()
+	tickets := make([]dto.KDSTicket, 0, len(orders))
+	for i := range orders {
+		ticket := buildTicket(&orders[i], now)
+		if query.OverdueOnly {
+			ticket.Items = filterOverdue(ticket.Items)
+		}
+		if len(ticket.Items) == 0 {
+			continue
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// GetTicket returns the kitchen ticket of a single order, or nil when the
+// kitchen has nothing left to show for it
+func GetTicket(orderID uint) (*dto.KDSTicket, error) {
+	var order models.Order
+	err := models.DataBase.
+		Preload("Table").
+		Preload("OrderItems", "status IN ?", defaultStatuses, func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("OrderItems.MenuItem").
+		Preload("OrderItems.Modifiers").
+		Preload("Combos").
+		First(&order, orderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error fetching kitchen ticket: %w", err)
+	}
+	for _, hidden := range hiddenOrderStatuses {
+		if order.Status == hidden {
+			return nil, nil
+		}
+	}
+
+	ticket := buildTicket(&order, time.Now())
+	if len(ticket.Items) == 0 {
+		return nil, nil
+	}
+	return &ticket, nil
+}
+
+func buildTicket(order *models.Order, now time.Time) dto.KDSTicket {
+	ticket := dto.KDSTicket{
+		OrderID:     order.ID,
+		OrderNumber: order.OrderNumber,
+		OrderType:   string(order.OrderType),
+		OrderStatus: string(order.Status),
+		TableID:     order.TableID,
+		Notes:       order.Notes,
+		CreatedAt:   order.CreatedAt,
+		AgeSeconds:  int64(now.Sub(order.CreatedAt).Seconds()),
+		Items:       make([]dto.KDSItem, 0, len(order.OrderItems)),
+	}
+	if order.Table != nil {
+		ticket.TableNumber = order.Table.Number
+	}
+
+	combos := make(map[uint]string, len(order.Combos))
+	for _, combo := range order.Combos {
+		combos[combo.ID] = combo.Name
+	}
+
+	for _, item := range order.OrderItems {
+		// Countdown runs from when the item was ordered
+		dueAt := item.CreatedAt.Add(time.Duration(item.MenuItem.PrepTime) * time.Minute)
+		stillCooking := item.Status == models.OrderItemPending || item.Status == models.OrderItemPreparing
+		kdsItem := dto.KDSItem{
+			ID:               item.ID,
+			OrderID:          order.ID,
+			OrderNumber:      order.OrderNumber,
+			MenuItemID:       item.MenuItemID,
+			Name:             item.MenuItem.Name,
+			VariantName:      item.VariantName,
+			Modifiers:        make([]dto.KDSModifier, 0, len(item.Modifiers)),
+			Quantity:         item.Quantity,
+			Notes:            item.Notes,
+			Status:           string(item.Status),
+			PrepTime:         item.MenuItem.PrepTime,
+			OrderedAt:        item.CreatedAt,
+			DueAt:            dueAt,
+			RemainingSeconds: int64(dueAt.Sub(now).Seconds()),
+			Overdue:          stillCooking && now.After(dueAt),
+		}
+		if item.OrderComboID != nil {
+			kdsItem.ComboName = combos[*item.OrderComboID]
+		}
+		for _, m := range item.Modifiers {
+			kdsItem.Modifiers = append(kdsItem.Modifiers, dto.KDSModifier{Group: m.GroupName, Name: m.Name})
+		}
+		if kdsItem.Overdue {
+			ticket.Overdue = true
+		}
+		ticket.Items = append(ticket.Items, kdsItem)
+	}
+	return ticket
+}
+
+func filterOverdue(items []dto.KDSItem) []dto.KDSItem {
+	overdue := make([]dto.KDSItem, 0, len(items))
+	for _, item := range items {
+		if item.Overdue {
+			overdue = append(overdue, item)
+		}
+	}
+	return overdue
+}
+
+// parseStatuses turns "PENDING,PREPARING" into item statuses, defaulting to the kitchen statuses
+func parseStatuses(raw string) ([]models.OrderItemStatus, error) {
+	if strings.TrimSpace(raw) == "" {
+		return defaultStatuses, nil
+	}
+	var statuses []models.OrderItemStatus
+	for _, part := range strings.Split(raw, ",") {
+		status := models.OrderItemStatus(strings.ToUpper(strings.TrimSpace(part)))
+		switch status {
+		case models.OrderItemPending, models.OrderItemPreparing, models.OrderItemReady, models.OrderItemServed, models.OrderItemCancelled:
+			statuses = append(statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidStatus, part)
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,1093 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	menu_dto "restaurant_os/internal/api/menu/dto"
+	menu_services "restaurant_os/internal/api/menu/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type menuController struct{}
+
+var validate = dto.NewValidator()
+
+func NewMenuController() *menuController {
+	return &menuController{}
+}
+
+// ============================================================================
+// CATEGORIES
+// ============================================================================
+
+func (mc *menuController) GetCategories(c *fiber.Ctx) error {
+	var query menu_dto.CategoryListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	categories, err := menu_services.ListCategories(&query, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu categories")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu categories fetched successfully",
+		Data:    toCategoryResponses(categories),
+	})
+}
+
+func (mc *menuController) GetCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu category ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	category, err := menu_services.GetCategory(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu category")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu category fetched successfully",
+		Data:    toCategoryResponse(category),
+	})
+}
+
+func (mc *menuController) CreateCategory(c *fiber.Ctx) error {
+	var req menu_dto.CreateCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.CategoryValidationErrorMessages)
+	}
+
+	category, err := menu_services.CreateCategory(&req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create menu category")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu category created successfully",
+		Data:    toCategoryResponse(category),
+	})
+}
+
+func (mc *menuController) UpdateCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu category ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.CategoryValidationErrorMessages)
+	}
+
+	category, err := menu_services.UpdateCategory(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update menu category")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu category updated successfully",
+		Data:    toCategoryResponse(category),
+	})
+}
+
+func (mc *menuController) DeleteCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu category ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteCategory(uint(id), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete menu category")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu category deleted successfully",
+	})
+}
+
+func (mc *menuController) ReorderCategories(c *fiber.Ctx) error {
+	var req menu_dto.ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ReorderValidationErrorMessages)
+	}
+
+	categories, err := menu_services.ReorderCategories(&req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to reorder menu categories")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu categories reordered successfully",
+		Data:    toCategoryResponses(categories),
+	})
+}
+
+// ============================================================================
+// ITEMS
+// ============================================================================
+
+func (mc *menuController) GetMenuItems(c *fiber.Ctx) error {
+	var query menu_dto.MenuItemListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	items, total, err := menu_services.ListMenuItems(&query, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu items")
+	}
+
+	data := make([]menu_dto.MenuItemResponse, 0, len(items))
+	for i := range items {
+		data = append(data, toMenuItemResponse(&items[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Menu items fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (mc *menuController) GetMenuItem(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	item, err := menu_services.GetMenuItem(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu item fetched successfully",
+		Data:    toMenuItemResponse(item),
+	})
+}
+
+func (mc *menuController) CreateMenuItem(c *fiber.Ctx) error {
+	var req menu_dto.CreateMenuItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.MenuItemValidationErrorMessages)
+	}
+
+	item, err := menu_services.CreateMenuItem(&req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create menu item")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu item created successfully",
+		Data:    toMenuItemResponse(item),
+	})
+}
+
+func (mc *menuController) UpdateMenuItem(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateMenuItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.MenuItemValidationErrorMessages)
+	}
+
+	item, err := menu_services.UpdateMenuItem(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update menu item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu item updated successfully",
+		Data:    toMenuItemResponse(item),
+	})
+}
+
+// SetAvailability marks an item available again or sold out (86'd)
+func (mc *menuController) SetAvailability(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.SetAvailabilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.SetAvailabilityValidationErrorMessages)
+	}
+
+	item, err := menu_services.SetAvailability(uint(id), *req.Available, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update menu item availability")
+	}
+
+	message := "Menu item marked as available"
+	if !*req.Available {
+		message = "Menu item marked as sold out"
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    toMenuItemResponse(item),
+	})
+}
+
+func (mc *menuController) DeleteMenuItem(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteMenuItem(uint(id), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete menu item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu item deleted successfully",
+	})
+}
+
+func (mc *menuController) ReorderMenuItems(c *fiber.Ctx) error {
+	var req menu_dto.ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ReorderValidationErrorMessages)
+	}
+
+	if err := menu_services.ReorderMenuItems(&req, middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to reorder menu items")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu items reordered successfully",
+	})
+}
+
+// ============================================================================
+// VARIANTS AND MODIFIER GROUPS
+// ============================================================================
+
+func (mc *menuController) CreateVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.CreateVariantRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.VariantValidationErrorMessages)
+	}
+
+	variant, err := menu_services.CreateVariant(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create variant")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Variant created successfully",
+		Data:    toVariantResponse(variant),
+	})
+}
+
+func (mc *menuController) UpdateVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	variantID, err := c.ParamsInt("variantId")
+	if err != nil || variantID <= 0 {
+		errMsg := "Invalid variant ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateVariantRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.VariantValidationErrorMessages)
+	}
+
+	variant, err := menu_services.UpdateVariant(uint(id), uint(variantID), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update variant")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Variant updated successfully",
+		Data:    toVariantResponse(variant),
+	})
+}
+
+func (mc *menuController) DeleteVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	variantID, err := c.ParamsInt("variantId")
+	if err != nil || variantID <= 0 {
+		errMsg := "Invalid variant ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteVariant(uint(id), uint(variantID), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete variant")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Variant deleted successfully",
+	})
+}
+
+func (mc *menuController) CreateModifierGroup(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.CreateModifierGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ModifierGroupValidationErrorMessages)
+	}
+
+	group, err := menu_services.CreateModifierGroup(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create modifier group")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Modifier group created successfully",
+		Data:    toModifierGroupResponse(group),
+	})
+}
+
+func (mc *menuController) UpdateModifierGroup(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	groupID, err := c.ParamsInt("groupId")
+	if err != nil || groupID <= 0 {
+		errMsg := "Invalid modifier group ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateModifierGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ModifierGroupValidationErrorMessages)
+	}
+
+	group, err := menu_services.UpdateModifierGroup(uint(id), uint(groupID), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update modifier group")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Modifier group updated successfully",
+		Data:    toModifierGroupResponse(group),
+	})
+}
+
+func (mc *menuController) DeleteModifierGroup(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	groupID, err := c.ParamsInt("groupId")
+	if err != nil || groupID <= 0 {
+		errMsg := "Invalid modifier group ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteModifierGroup(uint(id), uint(groupID), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete modifier group")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Modifier group deleted successfully",
+	})
+}
+
+// ============================================================================
+// COMBOS
+// ============================================================================
+
+func (mc *menuController) GetCombos(c *fiber.Ctx) error {
+	var query menu_dto.ComboListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	combos, err := menu_services.ListCombos(&query, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch combos")
+	}
+
+	data := make([]menu_dto.ComboResponse, 0, len(combos))
+	for i := range combos {
+		data = append(data, toComboResponse(&combos[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Combos fetched successfully",
+		Data:    data,
+	})
+}
+
+func (mc *menuController) GetCombo(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid combo ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	combo, err := menu_services.GetCombo(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch combo")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Combo fetched successfully",
+		Data:    toComboResponse(combo),
+	})
+}
+
+func (mc *menuController) CreateCombo(c *fiber.Ctx) error {
+	var req menu_dto.CreateComboRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ComboValidationErrorMessages)
+	}
+
+	combo, err := menu_services.CreateCombo(&req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create combo")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Combo created successfully",
+		Data:    toComboResponse(combo),
+	})
+}
+
+func (mc *menuController) UpdateCombo(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid combo ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateComboRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ComboValidationErrorMessages)
+	}
+
+	combo, err := menu_services.UpdateCombo(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update combo")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Combo updated successfully",
+		Data:    toComboResponse(combo),
+	})
+}
+
+func (mc *menuController) DeleteCombo(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid combo ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteCombo(uint(id), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete combo")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Combo deleted successfully",
+	})
+}
+
+// ============================================================================
+// SCHEDULES
+// ============================================================================
+
+func (mc *menuController) GetSchedules(c *fiber.Ctx) error {
+	var query menu_dto.ScheduleListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	schedules, err := menu_services.ListSchedules(&query, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu schedules")
+	}
+
+	data := make([]menu_dto.ScheduleResponse, 0, len(schedules))
+	for i := range schedules {
+		data = append(data, toScheduleResponse(&schedules[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu schedules fetched successfully",
+		Data:    data,
+	})
+}
+
+func (mc *menuController) GetSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid schedule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	schedule, err := menu_services.GetSchedule(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to fetch menu schedule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu schedule fetched successfully",
+		Data:    toScheduleResponse(schedule),
+	})
+}
+
+func (mc *menuController) CreateSchedule(c *fiber.Ctx) error {
+	var req menu_dto.CreateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	req.Type = strings.ToUpper(req.Type)
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ScheduleValidationErrorMessages)
+	}
+
+	schedule, err := menu_services.CreateSchedule(&req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to create menu schedule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu schedule created successfully",
+		Data:    toScheduleResponse(schedule),
+	})
+}
+
+func (mc *menuController) UpdateSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid schedule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req menu_dto.UpdateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, menu_dto.ScheduleValidationErrorMessages)
+	}
+
+	schedule, err := menu_services.UpdateSchedule(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return menuErrorResponse(c, err, "Failed to update menu schedule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu schedule updated successfully",
+		Data:    toScheduleResponse(schedule),
+	})
+}
+
+func (mc *menuController) DeleteSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid schedule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := menu_services.DeleteSchedule(uint(id), middleware.GetClaims(c)); err != nil {
+		return menuErrorResponse(c, err, "Failed to delete menu schedule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu schedule deleted successfully",
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// menuErrorResponse maps menu service errors to HTTP status codes
+func menuErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, menu_services.ErrCategoryNotFound), errors.Is(err, menu_services.ErrMenuItemNotFound),
+		errors.Is(err, menu_services.ErrBranchNotFound), errors.Is(err, menu_services.ErrVariantNotFound),
+		errors.Is(err, menu_services.ErrModifierGroupNotFound), errors.Is(err, menu_services.ErrComboNotFound),
+		errors.Is(err, menu_services.ErrScheduleNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, menu_services.ErrCategoryNotEmpty):
+		status = fiber.StatusConflict
+	case errors.Is(err, menu_services.ErrInvalidCategory), errors.Is(err, menu_services.ErrInvalidReorder),
+		errors.Is(err, menu_services.ErrModifierOptionNotFound), errors.Is(err, menu_services.ErrInvalidSelectionLimits),
+		errors.Is(err, menu_services.ErrInvalidComboItem), errors.Is(err, menu_services.ErrInvalidSchedule):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, dto.ErrBranchRequired):
+		status = fiber.StatusBadRequest
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toCategoryResponses(categories []models.MenuCategory) []menu_dto.CategoryResponse {
+	data := make([]menu_dto.CategoryResponse, 0, len(categories))
+	for i := range categories {
+		data = append(data, toCategoryResponse(&categories[i]))
+	}
+	return data
+}
+
+func toCategoryResponse(category *models.MenuCategory) menu_dto.CategoryResponse {
+	return menu_dto.CategoryResponse{
+		ID:          category.ID,
+		BranchID:    category.BranchID,
+		Name:        category.Name,
+		Description: category.Description,
+		SortOrder:   category.SortOrder,
+		IsActive:    category.IsActive,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func toMenuItemResponse(item *models.MenuItem) menu_dto.MenuItemResponse {
+	response := menu_dto.MenuItemResponse{
+		ID:             item.ID,
+		BranchID:       item.BranchID,
+		CategoryID:     item.CategoryID,
+		Name:           item.Name,
+		Description:    item.Description,
+		Price:          item.Price,
+		CostPrice:      item.CostPrice,
+		Available:      item.Available,
+		IsVegetarian:   item.IsVegetarian,
+		IsVegan:        item.IsVegan,
+		IsGlutenFree:   item.IsGlutenFree,
+		Spiciness:      item.Spiciness,
+		PrepTime:       item.PrepTime,
+		ImageURL:       item.ImageURL,
+		Ingredients:    menu_services.SplitList(item.Ingredients),
+		Allergens:      menu_services.SplitList(item.Allergens),
+		SortOrder:      item.SortOrder,
+		Variants:       make([]menu_dto.VariantResponse, 0, len(item.Variants)),
+		ModifierGroups: make([]menu_dto.ModifierGroupResponse, 0, len(item.ModifierGroups)),
+		CreatedAt:      item.CreatedAt,
+		UpdatedAt:      item.UpdatedAt,
+	}
+	if item.Category != nil {
+		response.CategoryName = item.Category.Name
+	}
+	for i := range item.Variants {
+		response.Variants = append(response.Variants, toVariantResponse(&item.Variants[i]))
+	}
+	for i := range item.ModifierGroups {
+		response.ModifierGroups = append(response.ModifierGroups, toModifierGroupResponse(&item.ModifierGroups[i]))
+	}
+	return response
+}
+
+func toVariantResponse(variant *models.MenuItemVariant) menu_dto.VariantResponse {
+	return menu_dto.VariantResponse{
+		ID:        variant.ID,
+		Name:      variant.Name,
+		Price:     variant.Price,
+		IsDefault: variant.IsDefault,
+		Available: variant.Available,
+		SortOrder: variant.SortOrder,
+	}
+}
+
+func toModifierGroupResponse(group *models.ModifierGroup) menu_dto.ModifierGroupResponse {
+	options := make([]menu_dto.ModifierOptionResponse, 0, len(group.Options))
+	for _, o := range group.Options {
+		options = append(options, menu_dto.ModifierOptionResponse{
+			ID:         o.ID,
+			Name:       o.Name,
+			PriceDelta: o.PriceDelta,
+			Available:  o.Available,
+			SortOrder:  o.SortOrder,
+		})
+	}
+	return menu_dto.ModifierGroupResponse{
+		ID:            group.ID,
+		MenuItemID:    group.MenuItemID,
+		Name:          group.Name,
+		IsRequired:    group.IsRequired,
+		MinSelections: group.MinSelections,
+		MaxSelections: group.MaxSelections,
+		SortOrder:     group.SortOrder,
+		Options:       options,
+	}
+}
+
+func toComboResponse(combo *models.Combo) menu_dto.ComboResponse {
+	slots := make([]menu_dto.ComboSlotResponse, 0, len(combo.Slots))
+	for _, slot := range combo.Slots {
+		options := make([]menu_dto.ComboSlotOptionResponse, 0, len(slot.Options))
+		for _, o := range slot.Options {
+			option := menu_dto.ComboSlotOptionResponse{
+				ID:           o.ID,
+				MenuItemID:   o.MenuItemID,
+				MenuItemName: o.MenuItem.Name,
+				VariantID:    o.VariantID,
+				PriceDelta:   o.PriceDelta,
+			}
+			if o.Variant != nil {
+				option.VariantName = o.Variant.Name
+			}
+			options = append(options, option)
+		}
+		slots = append(slots, menu_dto.ComboSlotResponse{
+			ID:        slot.ID,
+			Name:      slot.Name,
+			SortOrder: slot.SortOrder,
+			Options:   options,
+		})
+	}
+	return menu_dto.ComboResponse{
+		ID:          combo.ID,
+		BranchID:    combo.BranchID,
+		Name:        combo.Name,
+		Description: combo.Description,
+		Price:       combo.Price,
+		ImageURL:    combo.ImageURL,
+		Available:   combo.Available,
+		SortOrder:   combo.SortOrder,
+		Slots:       slots,
+		CreatedAt:   combo.CreatedAt,
+		UpdatedAt:   combo.UpdatedAt,
+	}
+}
+
+func toScheduleResponse(s *models.MenuSchedule) menu_dto.ScheduleResponse {
+	resp := menu_dto.ScheduleResponse{
+		ID:              s.ID,
+		BranchID:        s.BranchID,
+		Name:            s.Name,
+		Type:            string(s.Type),
+		CategoryID:      s.CategoryID,
+		MenuItemID:      s.MenuItemID,
+		Days:            menu_services.SplitList(s.Days),
+		StartTime:       s.StartTime,
+		EndTime:         s.EndTime,
+		DiscountPercent: s.DiscountPercent,
+		IsActive:        s.IsActive,
+		ActiveNow:       menu_services.ScheduleActiveNow(s),
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	if s.Category != nil {
+		resp.CategoryName = s.Category.Name
+	}
+	if s.MenuItem != nil {
+		resp.MenuItemName = s.MenuItem.Name
+	}
+	return resp
+}
@@ -0,0 +1,516 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// MENU CATEGORY REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// CreateCategoryRequest represents create menu category request
+type CreateCategoryRequest struct {
+	BranchID    *uint  `json:"branch_id,omitempty"` // Defaults to the user's branch
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+	SortOrder   *int   `json:"sort_order,omitempty" validate:"omitempty,min=0"` // Defaults to after the last category
+	IsActive    *bool  `json:"is_active,omitempty"`
+}
+
+// UpdateCategoryRequest represents update menu category request
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+	SortOrder   *int    `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+// CategoryValidationErrorMessages maps category request fields to custom messages
+var CategoryValidationErrorMessages = map[string]string{
+	"Name":        "Name is required and must be at most 100 characters.",
+	"Description": "Description must be at most 1000 characters.",
+	"SortOrder":   "Sort order cannot be negative.",
+}
+
+// ReorderRequest sets the display order of categories or items: the first ID gets
+// sort order 0, the next 1, and so on. IDs must all belong to the branch.
+type ReorderRequest struct {
+	BranchID *uint  `json:"branch_id,omitempty"`
+	IDs      []uint `json:"ids" validate:"required,min=1,max=500,dive,required"`
+}
+
+// ReorderValidationErrorMessages maps ReorderRequest fields to custom messages
+var ReorderValidationErrorMessages = map[string]string{
+	"IDs": "Between 1 and 500 IDs are required.",
+}
+
+// CategoryListQuery represents filters for listing menu categories
+type CategoryListQuery struct {
+	BranchID *uint `query:"branch_id"`
+	IsActive *bool `query:"is_active"`
+}
+
+// CategoryResponse represents menu category response
+type CategoryResponse struct {
+	ID          uint      `json:"id"`
+	BranchID    uint      `json:"branch_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	SortOrder   int       `json:"sort_order"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ============================================================================
+// MENU ITEM REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// CreateMenuItemRequest represents create menu item request
+type CreateMenuItemRequest struct {
+	BranchID     *uint       `json:"branch_id,omitempty"` // Defaults to the user's branch
+	CategoryID   *uint       `json:"category_id,omitempty"`
+	Name         string      `json:"name" validate:"required,max=100"`
+	Description  string      `json:"description,omitempty" validate:"max=2000"`
+	Price        money.Money `json:"price" validate:"required,gt=0"`
+	CostPrice    money.Money `json:"cost_price,omitempty" validate:"min=0"`
+	Available    *bool       `json:"available,omitempty"`
+	IsVegetarian bool        `json:"is_vegetarian"`
+	IsVegan      bool        `json:"is_vegan"` // Implies vegetarian
+	IsGlutenFree bool        `json:"is_gluten_free"`
+	Spiciness    int         `json:"spiciness" validate:"min=0,max=5"`
+	PrepTime     *int        `json:"prep_time,omitempty" validate:"omitempty,min=0,max=240"` // minutes, default 15
+	ImageURL     string      `json:"image_url,omitempty" validate:"omitempty,url,max=500"`
+	Ingredients  []string    `json:"ingredients,omitempty" validate:"max=50,dive,required,max=100"`
+	Allergens    []string    `json:"allergens,omitempty" validate:"max=20,dive,required,max=50"`
+	SortOrder    *int        `json:"sort_order,omitempty" validate:"omitempty,min=0"` // Defaults to after the last item
+}
+
+// UpdateMenuItemRequest represents update menu item request.
+// A category_id of 0 moves the item out of its category.
+type UpdateMenuItemRequest struct {
+	CategoryID   *uint        `json:"category_id,omitempty"`
+	Name         *string      `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description  *string      `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Price        *money.Money `json:"price,omitempty" validate:"omitempty,gt=0"`
+	CostPrice    *money.Money `json:"cost_price,omitempty" validate:"omitempty,min=0"`
+	Available    *bool        `json:"available,omitempty"`
+	IsVegetarian *bool        `json:"is_vegetarian,omitempty"`
+	IsVegan      *bool        `json:"is_vegan,omitempty"`
+	IsGlutenFree *bool        `json:"is_gluten_free,omitempty"`
+	Spiciness    *int         `json:"spiciness,omitempty" validate:"omitempty,min=0,max=5"`
+	PrepTime     *int         `json:"prep_time,omitempty" validate:"omitempty,min=0,max=240"`
+	ImageURL     *string      `json:"image_url,omitempty" validate:"omitempty,url,max=500"`
+	Ingredients  *[]string    `json:"ingredients,omitempty" validate:"omitempty,max=50,dive,required,max=100"`
+	Allergens    *[]string    `json:"allergens,omitempty" validate:"omitempty,max=20,dive,required,max=50"`
+	SortOrder    *int         `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+}
+
+// MenuItemValidationErrorMessages maps menu item request fields to custom messages
+var MenuItemValidationErrorMessages = map[string]string{
+	"Name":        "Name is required and must be at most 100 characters.",
+	"Description": "Description must be at most 2000 characters.",
+	"Price":       "Price is required and must be greater than 0.",
+	"CostPrice":   "Cost price cannot be negative.",
+	"Spiciness":   "Spiciness must be between 0 and 5.",
+	"PrepTime":    "Prep time must be between 0 and 240 minutes.",
+	"ImageURL":    "Image URL must be a valid URL of at most 500 characters.",
+	"Ingredients": "At most 50 ingredients of up to 100 characters each.",
+	"Allergens":   "At most 20 allergens of up to 50 characters each.",
+	"SortOrder":   "Sort order cannot be negative.",
+}
+
+// SetAvailabilityRequest marks an item available or sold out (86'd)
+type SetAvailabilityRequest struct {
+	Available *bool `json:"available" validate:"required"`
+}
+
+// SetAvailabilityValidationErrorMessages maps SetAvailabilityRequest fields to custom messages
+var SetAvailabilityValidationErrorMessages = map[string]string{
+	"Available": "Available is required.",
+}
+
+// DietaryFilter narrows menu items by dietary flags, spiciness and allergens
+type DietaryFilter struct {
+	IsVegetarian     *bool  `query:"is_vegetarian"`
+	IsVegan          *bool  `query:"is_vegan"`
+	IsGlutenFree     *bool  `query:"is_gluten_free"`
+	MaxSpiciness     *int   `query:"max_spiciness"`
+	ExcludeAllergens string `query:"exclude_allergens"` // Comma-separated, e.g. "nuts,dairy"
+}
+
+// IsEmpty reports whether no filter is set
+func (f *DietaryFilter) IsEmpty() bool {
+	return f.IsVegetarian == nil && f.IsVegan == nil && f.IsGlutenFree == nil && f.MaxSpiciness == nil && f.ExcludeAllergens == ""
+}
+
+// MenuItemListQuery represents filters for listing menu items
+type MenuItemListQuery struct {
+	dto.PaginationQuery
+	DietaryFilter
+	BranchID   *uint  `query:"branch_id"`
+	CategoryID *uint  `query:"category_id"`
+	Available  *bool  `query:"available"`
+	Search     string `query:"search"`
+}
+
+// MenuItemResponse represents menu item response for staff
+type MenuItemResponse struct {
+	ID             uint                    `json:"id"`
+	BranchID       uint                    `json:"branch_id"`
+	CategoryID     *uint                   `json:"category_id,omitempty"`
+	CategoryName   string                  `json:"category_name,omitempty"`
+	Name           string                  `json:"name"`
+	Description    string                  `json:"description,omitempty"`
+	Price          money.Money             `json:"price"`
+	CostPrice      money.Money             `json:"cost_price"`
+	Available      bool                    `json:"available"`
+	IsVegetarian   bool                    `json:"is_vegetarian"`
+	IsVegan        bool                    `json:"is_vegan"`
+	IsGlutenFree   bool                    `json:"is_gluten_free"`
+	Spiciness      int                     `json:"spiciness"`
+	PrepTime       int                     `json:"prep_time"`
+	ImageURL       string                  `json:"image_url,omitempty"`
+	Ingredients    []string                `json:"ingredients"`
+	Allergens      []string                `json:"allergens"`
+	SortOrder      int                     `json:"sort_order"`
+	Variants       []VariantResponse       `json:"variants"`
+	ModifierGroups []ModifierGroupResponse `json:"modifier_groups"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+}
+
+// ============================================================================
+// VARIANT AND MODIFIER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// CreateVariantRequest represents create menu item variant request, e.g. a size
+type CreateVariantRequest struct {
+	Name      string      `json:"name" validate:"required,max=50"`
+	Price     money.Money `json:"price" validate:"required,gt=0"` // Replaces the item price
+	IsDefault bool        `json:"is_default"`                     // Used when an order does not pick a variant
+	Available *bool       `json:"available,omitempty"`
+	SortOrder *int        `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+}
+
+// UpdateVariantRequest represents update menu item variant request
+type UpdateVariantRequest struct {
+	Name      *string      `json:"name,omitempty" validate:"omitempty,max=50"`
+	Price     *money.Money `json:"price,omitempty" validate:"omitempty,gt=0"`
+	IsDefault *bool        `json:"is_default,omitempty"`
+	Available *bool        `json:"available,omitempty"`
+	SortOrder *int         `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+}
+
+// VariantValidationErrorMessages maps variant request fields to custom messages
+var VariantValidationErrorMessages = map[string]string{
+	"Name":      "Name is required and must be at most 50 characters.",
+	"Price":     "Price is required and must be greater than 0.",
+	"SortOrder": "Sort order cannot be negative.",
+}
+
+// ModifierOptionInput is one choice of a modifier group. Options with an ID
+// update the existing option; options without one are added.
+type ModifierOptionInput struct {
+	ID         *uint       `json:"id,omitempty"`
+	Name       string      `json:"name" validate:"required,max=100"`
+	PriceDelta money.Money `json:"price_delta"` // Added to the unit price, may be negative
+	Available  *bool       `json:"available,omitempty"`
+}
+
+// CreateModifierGroupRequest represents create modifier group request
+type CreateModifierGroupRequest struct {
+	Name          string                `json:"name" validate:"required,max=100"`
+	IsRequired    bool                  `json:"is_required"`
+	MinSelections int                   `json:"min_selections" validate:"min=0,max=30"`
+	MaxSelections int                   `json:"max_selections" validate:"min=0,max=30"` // 0 = no limit
+	SortOrder     *int                  `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+	Options       []ModifierOptionInput `json:"options" validate:"required,min=1,max=30,dive"`
+}
+
+// UpdateModifierGroupRequest represents update modifier group request.
+// When options are given they replace the group's options: listed options are
+// kept in the given order and options left out are removed.
+type UpdateModifierGroupRequest struct {
+	Name          *string                `json:"name,omitempty" validate:"omitempty,max=100"`
+	IsRequired    *bool                  `json:"is_required,omitempty"`
+	MinSelections *int                   `json:"min_selections,omitempty" validate:"omitempty,min=0,max=30"`
+	MaxSelections *int                   `json:"max_selections,omitempty" validate:"omitempty,min=0,max=30"`
+	SortOrder     *int                   `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+	Options       *[]ModifierOptionInput `json:"options,omitempty" validate:"omitempty,min=1,max=30,dive"`
+}
+
+// ModifierGroupValidationErrorMessages maps modifier group request fields to custom messages
+var ModifierGroupValidationErrorMessages = map[string]string{
+	"Name":          "Name is required and must be at most 100 characters.",
+	"MinSelections": "Minimum selections must be between 0 and 30.",
+	"MaxSelections": "Maximum selections must be between 0 and 30.",
+	"SortOrder":     "Sort order cannot be negative.",
+	"Options":       "Between 1 and 30 options are required.",
+}
+
+// VariantResponse represents menu item variant response
+type VariantResponse struct {
+	ID        uint        `json:"id"`
+	Name      string      `json:"name"`
+	Price     money.Money `json:"price"`
+	IsDefault bool        `json:"is_default"`
+	Available bool        `json:"available"`
+	SortOrder int         `json:"sort_order"`
+}
+
+// ModifierOptionResponse represents modifier option response
+type ModifierOptionResponse struct {
+	ID         uint        `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+	Available  bool        `json:"available"`
+	SortOrder  int         `json:"sort_order"`
+}
+
+// ModifierGroupResponse represents modifier group response
+type ModifierGroupResponse struct {
+	ID            uint                     `json:"id"`
+	MenuItemID    uint                     `json:"menu_item_id"`
+	Name          string                   `json:"name"`
+	IsRequired    bool                     `json:"is_required"`
+	MinSelections int                      `json:"min_selections"`
+	MaxSelections int                      `json:"max_selections"`
+	SortOrder     int                      `json:"sort_order"`
+	Options       []ModifierOptionResponse `json:"options"`
+}
+
+// ============================================================================
+// COMBO REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// ComboSlotOptionInput is a menu item that may fill a combo slot
+type ComboSlotOptionInput struct {
+	MenuItemID uint        `json:"menu_item_id" validate:"required"`
+	VariantID  *uint       `json:"variant_id,omitempty"`                   // Variant served in the combo, the item's default when omitted
+	PriceDelta money.Money `json:"price_delta,omitempty" validate:"min=0"` // Upcharge for premium choices
+}
+
+// ComboSlotInput is one choice within a combo, e.g. "Starter" with the items allowed in it
+type ComboSlotInput struct {
+	Name    string                 `json:"name" validate:"required,max=100"`
+	Options []ComboSlotOptionInput `json:"options" validate:"required,min=1,max=50,dive"`
+}
+
+// CreateComboRequest represents create combo request
+type CreateComboRequest struct {
+	BranchID    *uint            `json:"branch_id,omitempty"` // Defaults to the user's branch
+	Name        string           `json:"name" validate:"required,max=100"`
+	Description string           `json:"description,omitempty" validate:"max=2000"`
+	Price       money.Money      `json:"price" validate:"required,gt=0"`
+	ImageURL    string           `json:"image_url,omitempty" validate:"omitempty,url,max=255"`
+	Available   *bool            `json:"available,omitempty"` // Defaults to true
+	SortOrder   *int             `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+	Slots       []ComboSlotInput `json:"slots" validate:"required,min=1,max=10,dive"`
+}
+
+// UpdateComboRequest represents update combo request. When slots are given they
+// replace the combo's slots as a whole.
+type UpdateComboRequest struct {
+	Name        *string           `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string           `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Price       *money.Money      `json:"price,omitempty" validate:"omitempty,gt=0"`
+	ImageURL    *string           `json:"image_url,omitempty" validate:"omitempty,url,max=255"`
+	Available   *bool             `json:"available,omitempty"`
+	SortOrder   *int              `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+	Slots       *[]ComboSlotInput `json:"slots,omitempty" validate:"omitempty,min=1,max=10,dive"`
+}
+
+// ComboValidationErrorMessages maps combo request fields to custom messages
+var ComboValidationErrorMessages = map[string]string{
+	"Name":        "Name is required and must be at most 100 characters.",
+	"Description": "Description must be at most 2000 characters.",
+	"Price":       "Price is required and must be greater than 0.",
+	"ImageURL":    "Image URL must be a valid URL of at most 255 characters.",
+	"SortOrder":   "Sort order cannot be negative.",
+	"Slots":       "Between 1 and 10 slots are required.",
+	"Options":     "Each slot needs between 1 and 50 options.",
+	"MenuItemID":  "Each slot option needs a menu_item_id.",
+	"PriceDelta":  "Slot option upcharges cannot be negative.",
+}
+
+// ComboListQuery represents filters for listing combos
+type ComboListQuery struct {
+	BranchID  *uint `query:"branch_id"`
+	Available *bool `query:"available"`
+}
+
+// ComboSlotOptionResponse represents a combo slot option response
+type ComboSlotOptionResponse struct {
+	ID           uint        `json:"id"`
+	MenuItemID   uint        `json:"menu_item_id"`
+	MenuItemName string      `json:"menu_item_name"`
+	VariantID    *uint       `json:"variant_id,omitempty"`
+	VariantName  string      `json:"variant_name,omitempty"`
+	PriceDelta   money.Money `json:"price_delta"`
+}
+
+// ComboSlotResponse represents a combo slot response
+type ComboSlotResponse struct {
+	ID        uint                      `json:"id"`
+	Name      string                    `json:"name"`
+	SortOrder int                       `json:"sort_order"`
+	Options   []ComboSlotOptionResponse `json:"options"`
+}
+
+// ComboResponse represents combo response
+type ComboResponse struct {
+	ID          uint                `json:"id"`
+	BranchID    uint                `json:"branch_id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Price       money.Money         `json:"price"`
+	ImageURL    string              `json:"image_url,omitempty"`
+	Available   bool                `json:"available"`
+	SortOrder   int                 `json:"sort_order"`
+	Slots       []ComboSlotResponse `json:"slots"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// ============================================================================
+// MENU SCHEDULE REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// CreateScheduleRequest represents create menu schedule request. A schedule applies
+// to exactly one category or item.
+type CreateScheduleRequest struct {
+	BranchID        *uint    `json:"branch_id,omitempty"` // Defaults to the user's branch
+	Name            string   `json:"name" validate:"required,max=100"`
+	Type            string   `json:"type" validate:"required,oneof=AVAILABILITY PRICE"`
+	CategoryID      *uint    `json:"category_id,omitempty" validate:"required_without=MenuItemID,excluded_with=MenuItemID"`
+	MenuItemID      *uint    `json:"menu_item_id,omitempty" validate:"required_without=CategoryID,excluded_with=CategoryID"`
+	Days            []string `json:"days,omitempty" validate:"max=7"` // Day names such as "mon" or "saturday", every day when empty
+	StartTime       string   `json:"start_time" validate:"required"`  // HH:MM
+	EndTime         string   `json:"end_time" validate:"required"`    // HH:MM, before start_time for windows past midnight
+	DiscountPercent float64  `json:"discount_percent,omitempty" validate:"min=0,max=100"`
+	IsActive        *bool    `json:"is_active,omitempty"` // Defaults to true
+}
+
+// UpdateScheduleRequest represents update menu schedule request; the category or
+// item a schedule applies to cannot change
+type UpdateScheduleRequest struct {
+	Name            *string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	Days            *[]string `json:"days,omitempty" validate:"omitempty,max=7"`
+	StartTime       *string   `json:"start_time,omitempty"`
+	EndTime         *string   `json:"end_time,omitempty"`
+	DiscountPercent *float64  `json:"discount_percent,omitempty" validate:"omitempty,min=0,max=100"`
+	IsActive        *bool     `json:"is_active,omitempty"`
+}
+
+// ScheduleValidationErrorMessages maps menu schedule request fields to custom messages
+var ScheduleValidationErrorMessages = map[string]string{
+	"Name":            "Name is required and must be at most 100 characters.",
+	"Type":            "Type must be AVAILABILITY or PRICE.",
+	"CategoryID":      "Exactly one of category_id and menu_item_id is required.",
+	"MenuItemID":      "Exactly one of category_id and menu_item_id is required.",
+	"Days":            "At most 7 days may be given.",
+	"StartTime":       "Start time is required.",
+	"EndTime":         "End time is required.",
+	"DiscountPercent": "Discount percent must be between 0 and 100.",
+}
+
+// ScheduleListQuery represents filters for listing menu schedules
+type ScheduleListQuery struct {
+	BranchID   *uint  `query:"branch_id"`
+	CategoryID *uint  `query:"category_id"`
+	MenuItemID *uint  `query:"menu_item_id"`
+	Type       string `query:"type"`
+	IsActive   *bool  `query:"is_active"`
+}
+
+// ScheduleResponse represents menu schedule response
+type ScheduleResponse struct {
+	ID              uint      `json:"id"`
+	BranchID        uint      `json:"branch_id"`
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`
+	CategoryID      *uint     `json:"category_id,omitempty"`
+	CategoryName    string    `json:"category_name,omitempty"`
+	MenuItemID      *uint     `json:"menu_item_id,omitempty"`
+	MenuItemName    string    `json:"menu_item_name,omitempty"`
+	Days            []string  `json:"days"`
+	StartTime       string    `json:"start_time"`
+	EndTime         string    `json:"end_time"`
+	DiscountPercent float64   `json:"discount_percent,omitempty"`
+	IsActive        bool      `json:"is_active"`
+	ActiveNow       bool      `json:"active_now"` // In the restaurant's time zone
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ============================================================================
+// PUBLIC MENU STRUCTS
+// ============================================================================
+
+// PublicMenuItem represents a menu item shown to customers
+type PublicMenuItem struct {
+	ID             uint                  `json:"id"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description,omitempty"`
+	Price          money.Money           `json:"price"`
+	RegularPrice   *money.Money          `json:"regular_price,omitempty"` // Set while a scheduled discount applies
+	IsVegetarian   bool                  `json:"is_vegetarian"`
+	IsVegan        bool                  `json:"is_vegan"`
+	IsGlutenFree   bool                  `json:"is_gluten_free"`
+	Spiciness      int                   `json:"spiciness"`
+	PrepTime       int                   `json:"prep_time"`
+	ImageURL       string                `json:"image_url,omitempty"`
+	Allergens      []string              `json:"allergens"`
+	Variants       []PublicVariant       `json:"variants,omitempty"`
+	ModifierGroups []PublicModifierGroup `json:"modifier_groups,omitempty"`
+}
+
+// PublicVariant represents an orderable variant of a menu item
+type PublicVariant struct {
+	ID           uint         `json:"id"`
+	Name         string       `json:"name"`
+	Price        money.Money  `json:"price"`
+	RegularPrice *money.Money `json:"regular_price,omitempty"` // Set while a scheduled discount applies
+	IsDefault    bool         `json:"is_default"`
+}
+
+// PublicModifierOption represents an orderable modifier option
+type PublicModifierOption struct {
+	ID         uint        `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// PublicModifierGroup represents a modifier group with its available options
+type PublicModifierGroup struct {
+	ID            uint                   `json:"id"`
+	Name          string                 `json:"name"`
+	IsRequired    bool                   `json:"is_required"`
+	MinSelections int                    `json:"min_selections"`
+	MaxSelections int                    `json:"max_selections"` // 0 = no limit
+	Options       []PublicModifierOption `json:"options"`
+}
+
+// PublicMenuCategory represents an active category with its available items
+type PublicMenuCategory struct {
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Items       []PublicMenuItem `json:"items"`
+}
+
+// PublicMenuResponse is the read-only menu of a branch shown to customers. Only
+// the categories and items served at the time of the request are listed.
+type PublicMenuResponse struct {
+	BranchID     uint                 `json:"branch_id"`
+	BranchName   string               `json:"branch_name"`
+	Currency     string               `json:"currency"`
+	TimeZone     string               `json:"time_zone"`
+	IsOpen       bool                 `json:"is_open"`
+	OpeningHours models.OpeningHours  `json:"opening_hours,omitempty"`
+	Categories   []PublicMenuCategory `json:"categories"` // Items without a category are listed under "Other"
+}
@@ -0,0 +1,52 @@
+package routes
+
+import (
+	menu_controller "restaurant_os/internal/api/menu/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterMenuRoutes(api fiber.Router) {
+
+	menu := api.Group("/menu", middleware.RequireAuth())
+
+	menuHandler := menu_controller.NewMenuController()
+	editRoles := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER")
+
+	menu.Get("/categories", menuHandler.GetCategories)
+	menu.Post("/categories", editRoles, menuHandler.CreateCategory)
+	menu.Put("/categories/reorder", editRoles, menuHandler.ReorderCategories)
+	menu.Get("/categories/:id", menuHandler.GetCategory)
+	menu.Put("/categories/:id", editRoles, menuHandler.UpdateCategory)
+	menu.Delete("/categories/:id", editRoles, menuHandler.DeleteCategory)
+
+	menu.Get("/items", menuHandler.GetMenuItems)
+	menu.Post("/items", editRoles, menuHandler.CreateMenuItem)
+	menu.Put("/items/reorder", editRoles, menuHandler.ReorderMenuItems)
+	menu.Get("/items/:id", menuHandler.GetMenuItem)
+	menu.Put("/items/:id", editRoles, menuHandler.UpdateMenuItem)
+	// The kitchen can 86 an item when it runs out
+	menu.Patch("/items/:id/availability", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"), menuHandler.SetAvailability)
+	menu.Delete("/items/:id", editRoles, menuHandler.DeleteMenuItem)
+
+	menu.Post("/items/:id/variants", editRoles, menuHandler.CreateVariant)
+	menu.Put("/items/:id/variants/:variantId", editRoles, menuHandler.UpdateVariant)
+	menu.Delete("/items/:id/variants/:variantId", editRoles, menuHandler.DeleteVariant)
+
+	menu.Post("/items/:id/modifier-groups", editRoles, menuHandler.CreateModifierGroup)
+	menu.Put("/items/:id/modifier-groups/:groupId", editRoles, menuHandler.UpdateModifierGroup)
+	menu.Delete("/items/:id/modifier-groups/:groupId", editRoles, menuHandler.DeleteModifierGroup)
+
+	menu.Get("/combos", menuHandler.GetCombos)
+	menu.Post("/combos", editRoles, menuHandler.CreateCombo)
+	menu.Get("/combos/:id", menuHandler.GetCombo)
+	menu.Put("/combos/:id", editRoles, menuHandler.UpdateCombo)
+	menu.Delete("/combos/:id", editRoles, menuHandler.DeleteCombo)
+
+	menu.Get("/schedules", menuHandler.GetSchedules)
+	menu.Post("/schedules", editRoles, menuHandler.CreateSchedule)
+	menu.Get("/schedules/:id", menuHandler.GetSchedule)
+	menu.Put("/schedules/:id", editRoles, menuHandler.UpdateSchedule)
+	menu.Delete("/schedules/:id", editRoles, menuHandler.DeleteSchedule)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ListCategories returns the menu categories visible to the requester in display order
+func ListCategories(query *dto.CategoryListQuery, claims *common_dto.Claims) ([]models.MenuCategory, error) {
+	db := claims.ScopeBranches(models.DataBase.Model(&models.MenuCategory{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+
+	var categories []models.MenuCategory
+	if err := db.Order("branch_id ASC, sort_order ASC, id ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("error fetching menu categories: %w", err)
+	}
+	return categories, nil
+}
+
+// GetCategory returns a menu category if it is visible to the requester
+func GetCategory(id uint, claims *common_dto.Claims) (*models.MenuCategory, error) {
+	var category models.MenuCategory
+	if err := claims.ScopeBranches(models.DataBase, "branch_id").First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu category: %w", err)
+	}
+	return &category, nil
+}
+
+// CreateCategory adds a category to a branch menu, after the last one unless a sort order is given
+func CreateCategory(req *dto.CreateCategoryRequest, claims *common_dto.Claims) (*models.MenuCategory, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	category := &models.MenuCategory{
+		BranchID:    branch.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		IsActive:    true,
+	}
+	if req.SortOrder != nil {
+		category.SortOrder = *req.SortOrder
+	} else if category.SortOrder, err = nextSortOrder(&models.MenuCategory{}, branch.ID); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Branch", "MenuItems").Create(category).Error; err != nil {
+			return fmt.Errorf("error creating menu category: %w", err)
+		}
+		// A false is_active would be replaced by the column default on insert
+		if req.IsActive != nil && !*req.IsActive {
+			if err := tx.Model(category).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("error creating menu category: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(branch.ID)
+	return GetCategory(category.ID, claims)
+}
+
+// UpdateCategory applies the non-nil fields of req
+func UpdateCategory(id uint, req *dto.UpdateCategoryRequest, claims *common_dto.Claims) (*models.MenuCategory, error) {
+	category, err := GetCategory(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		category.Description = *req.Description
+	}
+	if req.SortOrder != nil {
+		category.SortOrder = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	if err := models.DataBase.Omit("Branch", "MenuItems").Save(category).Error; err != nil {
+		return nil, fmt.Errorf("error updating menu category: %w", err)
+	}
+
+	InvalidateMenu(category.BranchID)
+	return GetCategory(category.ID, claims)
+}
+
+// DeleteCategory soft deletes an empty menu category
+func DeleteCategory(id uint, claims *common_dto.Claims) error {
+	category, err := GetCategory(id, claims)
+	if err != nil {
+		return err
+	}
+
+	var items int64
+	if err := models.DataBase.Model(&models.MenuItem{}).Where("category_id = ?", category.ID).Count(&items).Error; err != nil {
+		return fmt.Errorf("error counting menu items: %w", err)
+	}
+	if items > 0 {
+		return ErrCategoryNotEmpty
+	}
+
+	if err := models.DataBase.Delete(category).Error; err != nil {
+		return fmt.Errorf("error deleting menu category: %w", err)
+	}
+
+	InvalidateMenu(category.BranchID)
+	return nil
+}
+
+// ReorderCategories sets the display order of a branch's categories
+func ReorderCategories(req *dto.ReorderRequest, claims *common_dto.Claims) ([]models.MenuCategory, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := reorder(&models.MenuCategory{}, branch.ID, req.IDs); err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(branch.ID)
+	return ListCategories(&dto.CategoryListQuery{BranchID: &branch.ID}, claims)
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVariantNotFound        = errors.New("variant not found")
+	ErrModifierGroupNotFound  = errors.New("modifier group not found")
+	ErrModifierOptionNotFound = errors.New("modifier option does not belong to the group")
+	ErrInvalidSelectionLimits = errors.New("minimum selections cannot exceed the maximum or the number of options")
+)
+
+// CreateVariant adds a variant to a menu item. A default variant replaces the previous default.
+func CreateVariant(itemID uint, req *dto.CreateVariantRequest, claims *common_dto.Claims) (*models.MenuItemVariant, error) {
+	item, err := GetMenuItem(itemID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &models.MenuItemVariant{
+		MenuItemID: item.ID,
+		Name:       strings.TrimSpace(req.Name),
+		Price:      req.Price,
+		IsDefault:  req.IsDefault,
+		Available:  true,
+		SortOrder:  len(item.Variants),
+	}
+	if req.SortOrder != nil {
+		variant.SortOrder = *req.SortOrder
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if variant.IsDefault {
+			if err := clearDefaultVariant(tx, item.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(variant).Error; err != nil {
+			return fmt.Errorf("error creating variant: %w", err)
+		}
+		// A false available would be replaced by the column default on insert
+		if req.Available != nil && !*req.Available {
+			if err := tx.Model(variant).Update("available", false).Error; err != nil {
+				return fmt.Errorf("error creating variant: %w", err)
+			}
+			variant.Available = false
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(item.BranchID)
+	return variant, nil
+}
+
+// UpdateVariant applies the non-nil fields of req
+func UpdateVariant(itemID, variantID uint, req *dto.UpdateVariantRequest, claims *common_dto.Claims) (*models.MenuItemVariant, error) {
+	item, variant, err := getVariant(itemID, variantID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		variant.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Price != nil {
+		variant.Price = *req.Price
+	}
+	if req.IsDefault != nil {
+		variant.IsDefault = *req.IsDefault
+	}
+	if req.Available != nil {
+		variant.Available = *req.Available
+	}
+	if req.SortOrder != nil {
+		variant.SortOrder = *req.SortOrder
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if req.IsDefault != nil && *req.IsDefault {
+			if err := clearDefaultVariant(tx, item.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(variant).Error; err != nil {
+			return fmt.Errorf("error updating variant: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(item.BranchID)
+	return variant, nil
+}
+
+// DeleteVariant soft deletes a variant; placed orders keep the variant name
+func DeleteVariant(itemID, variantID uint, claims *common_dto.Claims) error {
+	item, variant, err := getVariant(itemID, variantID, claims)
+	if err != nil {
+		return err
+	}
+	if err := models.DataBase.Delete(variant).Error; err != nil {
+		return fmt.Errorf("error deleting variant: %w", err)
+	}
+
+	InvalidateMenu(item.BranchID)
+	return nil
+}
+
+// CreateModifierGroup adds a modifier group with its options to a menu item
+func CreateModifierGroup(itemID uint, req *dto.CreateModifierGroupRequest, claims *common_dto.Claims) (*models.ModifierGroup, error) {
+	item, err := GetMenuItem(itemID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &models.ModifierGroup{
+		MenuItemID:    item.ID,
+		Name:          strings.TrimSpace(req.Name),
+		IsRequired:    req.IsRequired,
+		MinSelections: req.MinSelections,
+		MaxSelections: req.MaxSelections,
+		SortOrder:     len(item.ModifierGroups),
+	}
+	if req.SortOrder != nil {
+		group.SortOrder = *req.SortOrder
+	}
+	if err := checkSelectionLimits(group, len(req.Options)); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Create(group).Error; err != nil {
+			return fmt.Errorf("error creating modifier group: %w", err)
+		}
+		return saveOptions(tx, group, req.Options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(item.BranchID)
+	_, group, err = getModifierGroup(item.ID, group.ID, claims)
+	return group, err
+}
+
+// UpdateModifierGroup applies the non-nil fields of req; options are replaced as a whole
+func UpdateModifierGroup(itemID, groupID uint, req *dto.UpdateModifierGroupRequest, claims *common_dto.Claims) (*models.ModifierGroup, error) {
+	item, group, err := getModifierGroup(itemID, groupID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		group.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.IsRequired != nil {
+		group.IsRequired = *req.IsRequired
+		if !group.IsRequired && req.MinSelections == nil {
+			group.MinSelections = 0
+		}
+	}
+	if req.MinSelections != nil {
+		group.MinSelections = *req.MinSelections
+	}
+	if req.MaxSelections != nil {
+		group.MaxSelections = *req.MaxSelections
+	}
+	if req.SortOrder != nil {
+		group.SortOrder = *req.SortOrder
+	}
+	optionCount := len(group.Options)
+	if req.Options != nil {
+		optionCount = len(*req.Options)
+	}
+	if err := checkSelectionLimits(group, optionCount); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Save(group).Error; err != nil {
+			return fmt.Errorf("error updating modifier group: %w", err)
+		}
+		if req.Options == nil {
+			return nil
+		}
+		return saveOptions(tx, group, *req.Options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(item.BranchID)
+	_, group, err = getModifierGroup(item.ID, group.ID, claims)
+	return group, err
+}
+
+// DeleteModifierGroup soft deletes a modifier group and its options; placed orders
+// keep the chosen option names
+func DeleteModifierGroup(itemID, groupID uint, claims *common_dto.Claims) error {
+	item, group, err := getModifierGroup(itemID, groupID, claims)
+	if err != nil {
+		return err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("modifier_group_id = ?", group.ID).Delete(&models.ModifierOption{}).Error; err != nil {
+			return fmt.Errorf("error deleting modifier options: %w", err)
+		}
+		if err := tx.Delete(group).Error; err != nil {
+			return fmt.Errorf("error deleting modifier group: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	InvalidateMenu(item.BranchID)
+	return nil
+}
+
+func getVariant(itemID, variantID uint, claims *common_dto.Claims) (*models.MenuItem, *models.MenuItemVariant, error) {
+	item, err := GetMenuItem(itemID, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	var variant models.MenuItemVariant
+	if err := models.DataBase.Where("id = ? AND menu_item_id = ?", variantID, item.ID).First(&variant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrVariantNotFound
+		}
+		return nil, nil, fmt.Errorf("error fetching variant: %w", err)
+	}
+	return item, &variant, nil
+}
+
+func getModifierGroup(itemID, groupID uint, claims *common_dto.Claims) (*models.MenuItem, *models.ModifierGroup, error) {
+	item, err := GetMenuItem(itemID, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	var group models.ModifierGroup
+	err = models.DataBase.Preload("Options", bySortOrder).
+		Where("id = ? AND menu_item_id = ?", groupID, item.ID).
+		First(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrModifierGroupNotFound
+		}
+		return nil, nil, fmt.Errorf("error fetching modifier group: %w", err)
+	}
+	return item, &group, nil
+}
+
+// saveOptions makes the group's options match the inputs: listed options are
+// updated or added in the given order and the others are removed
+func saveOptions(tx *gorm.DB, group *models.ModifierGroup, inputs []dto.ModifierOptionInput) error {
+	var existing []models.ModifierOption
+	if err := tx.Where("modifier_group_id = ?", group.ID).Find(&existing).Error; err != nil {
+		return fmt.Errorf("error fetching modifier options: %w", err)
+	}
+	byID := make(map[uint]*models.ModifierOption, len(existing))
+	for i := range existing {
+		byID[existing[i].ID] = &existing[i]
+	}
+
+	kept := make(map[uint]bool, len(inputs))
+	for i, input := range inputs {
+		option := &models.ModifierOption{ModifierGroupID: group.ID, Available: true}
+		if input.ID != nil {
+			found, ok := byID[*input.ID]
+			if !ok || kept[*input.ID] {
+				return fmt.Errorf("%w: %d", ErrModifierOptionNotFound, *input.ID)
+			}
+			option = found
+			kept[option.ID] = true
+		}
+		option.Name = strings.TrimSpace(input.Name)
+		option.PriceDelta = input.PriceDelta
+		option.SortOrder = i
+		if input.Available != nil {
+			option.Available = *input.Available
+		}
+		if err := tx.Save(option).Error; err != nil {
+			return fmt.Errorf("error saving modifier option: %w", err)
+		}
+		// A false available would be replaced by the column default on insert
+		if input.ID == nil && input.Available != nil && !*input.Available {
+			if err := tx.Model(option).Update("available", false).Error; err != nil {
+				return fmt.Errorf("error saving modifier option: %w", err)
+			}
+		}
+	}
+
+	for _, option := range existing {
+		if kept[option.ID] {
+			continue
+		}
+		if err := tx.Delete(&models.ModifierOption{}, option.ID).Error; err != nil {
+			return fmt.Errorf("error removing modifier option: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkSelectionLimits normalises a required group to at least one selection and
+// checks the limits can be met with the number of options
+func checkSelectionLimits(group *models.ModifierGroup, options int) error {
+	if group.IsRequired && group.MinSelections < 1 {
+		group.MinSelections = 1
+	}
+	if group.MaxSelections > 0 && group.MinSelections > group.MaxSelections {
+		return ErrInvalidSelectionLimits
+	}
+	if group.MinSelections > options {
+		return ErrInvalidSelectionLimits
+	}
+	return nil
+}
+
+func clearDefaultVariant(tx *gorm.DB, itemID uint) error {
+	if err := tx.Model(&models.MenuItemVariant{}).Where("menu_item_id = ? AND is_default = ?", itemID, true).Update("is_default", false).Error; err != nil {
+		return fmt.Errorf("error updating default variant: %w", err)
+	}
+	return nil
+}
+
+// preloadChoices loads the variants and modifier groups of menu items in display order
+func preloadChoices(db *gorm.DB) *gorm.DB {
+	return db.Preload("Variants", bySortOrder).
+		Preload("ModifierGroups", bySortOrder).
+		Preload("ModifierGroups.Options", bySortOrder)
+}
+
+func bySortOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrComboNotFound    = errors.New("combo not found")
+	ErrInvalidComboItem = errors.New("combo options must be menu items of the same branch")
+)
+
+// ListCombos returns the combos visible to the requester in display order
+func ListCombos(query *dto.ComboListQuery, claims *common_dto.Claims) ([]models.Combo, error) {
+	db := preloadSlots(claims.ScopeBranches(models.DataBase.Model(&models.Combo{}), "branch_id"))
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.Available != nil {
+		db = db.Where("available = ?", *query.Available)
+	}
+
+	var combos []models.Combo
+	if err := db.Order("branch_id ASC, sort_order ASC, id ASC").Find(&combos).Error; err != nil {
+		return nil, fmt.Errorf("error fetching combos: %w", err)
+	}
+	return combos, nil
+}
+
+// GetCombo returns a combo with its slots if it is visible to the requester
+func GetCombo(id uint, claims *common_dto.Claims) (*models.Combo, error) {
+	var combo models.Combo
+	if err := preloadSlots(claims.ScopeBranches(models.DataBase, "branch_id")).First(&combo, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrComboNotFound
+		}
+		return nil, fmt.Errorf("error fetching combo: %w", err)
+	}
+	return &combo, nil
+}
+
+// CreateCombo adds a combo to a branch menu, after the last one unless a sort order is given
+func CreateCombo(req *dto.CreateComboRequest, claims *common_dto.Claims) (*models.Combo, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	combo := &models.Combo{
+		BranchID:    branch.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Price:       req.Price,
+		ImageURL:    req.ImageURL,
+		Available:   true,
+	}
+	if req.SortOrder != nil {
+		combo.SortOrder = *req.SortOrder
+	} else if combo.SortOrder, err = nextSortOrder(&models.Combo{}, branch.ID); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Branch", "Slots").Create(combo).Error; err != nil {
+			return fmt.Errorf("error creating combo: %w", err)
+		}
+		// A false available would be replaced by the column default on insert
+		if req.Available != nil && !*req.Available {
+			if err := tx.Model(combo).Update("available", false).Error; err != nil {
+				return fmt.Errorf("error creating combo: %w", err)
+			}
+		}
+		return saveSlots(tx, combo, req.Slots)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetCombo(combo.ID, claims)
+}
+
+// UpdateCombo applies the non-nil fields of req; slots are replaced as a whole
+func UpdateCombo(id uint, req *dto.UpdateComboRequest, claims *common_dto.Claims) (*models.Combo, error) {
+	combo, err := GetCombo(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		combo.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		combo.Description = *req.Description
+	}
+	if req.Price != nil {
+		combo.Price = *req.Price
+	}
+	if req.ImageURL != nil {
+		combo.ImageURL = *req.ImageURL
+	}
+	if req.Available != nil {
+		combo.Available = *req.Available
+	}
+	if req.SortOrder != nil {
+		combo.SortOrder = *req.SortOrder
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Branch", "Slots").Save(combo).Error; err != nil {
+			return fmt.Errorf("error updating combo: %w", err)
+		}
+		if req.Slots == nil {
+			return nil
+		}
+		if err := deleteSlots(tx, combo.ID); err != nil {
+			return err
+		}
+		return saveSlots(tx, combo, *req.Slots)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetCombo(combo.ID, claims)
+}
+
+// DeleteCombo soft deletes a combo with its slots; placed orders keep the combo name
+func DeleteCombo(id uint, claims *common_dto.Claims) error {
+	combo, err := GetCombo(id, claims)
+	if err != nil {
+		return err
+	}
+
+	return models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := deleteSlots(tx, combo.ID); err != nil {
+			return err
+		}
+		if err := tx.Delete(combo).Error; err != nil {
+			return fmt.Errorf("error deleting combo: %w", err)
+		}
+		return nil
+	})
+}
+
+// saveSlots creates the slots of a combo in the given order after checking that
+// every option is a menu item of the combo's branch and any variant belongs to it
+func saveSlots(tx *gorm.DB, combo *models.Combo, inputs []dto.ComboSlotInput) error {
+	var itemIDs []uint
+	for _, slot := range inputs {
+		for _, option := range slot.Options {
+			itemIDs = append(itemIDs, option.MenuItemID)
+		}
+	}
+	var items []models.MenuItem
+	if err := tx.Preload("Variants").Where("branch_id = ? AND id IN ?", combo.BranchID, itemIDs).Find(&items).Error; err != nil {
+		return fmt.Errorf("error fetching menu items: %w", err)
+	}
+	byID := make(map[uint]*models.MenuItem, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+
+	for i, input := range inputs {
+		slot := models.ComboSlot{ComboID: combo.ID, Name: strings.TrimSpace(input.Name), SortOrder: i}
+		for j, o := range input.Options {
+			item, ok := byID[o.MenuItemID]
+			if !ok {
+				return fmt.Errorf("%w: menu item %d", ErrInvalidComboItem, o.MenuItemID)
+			}
+			if o.VariantID != nil && !hasVariant(item, *o.VariantID) {
+				return fmt.Errorf("%w: variant %d is not a variant of %s", ErrInvalidComboItem, *o.VariantID, item.Name)
+			}
+			slot.Options = append(slot.Options, models.ComboSlotOption{
+				MenuItemID: item.ID,
+				VariantID:  o.VariantID,
+				PriceDelta: o.PriceDelta,
+				SortOrder:  j,
+			})
+		}
+		if err := tx.Omit("Options.MenuItem", "Options.Variant").Create(&slot).Error; err != nil {
+			return fmt.Errorf("error creating combo slot: %w", err)
+		}
+	}
+	return nil
+}
+
+func deleteSlots(tx *gorm.DB, comboID uint) error {
+	slots := tx.Model(&models.ComboSlot{}).Select("id").Where("combo_id = ?", comboID)
+	if err := tx.Where("combo_slot_id IN (?)", slots).Delete(&models.ComboSlotOption{}).Error; err != nil {
+		return fmt.Errorf("error removing combo slot options: %w", err)
+	}
+	if err := tx.Where("combo_id = ?", comboID).Delete(&models.ComboSlot{}).Error; err != nil {
+		return fmt.Errorf("error removing combo slots: %w", err)
+	}
+	return nil
+}
+
+func hasVariant(item *models.MenuItem, variantID uint) bool {
+	for _, v := range item.Variants {
+		if v.ID == variantID {
+			return true
+		}
+	}
+	return false
+}
+
+// preloadSlots loads the slots of combos with their options' menu items and variants
+func preloadSlots(db *gorm.DB) *gorm.DB {
+	return db.Preload("Slots", bySortOrder).
+		Preload("Slots.Options", bySortOrder).
+		Preload("Slots.Options.MenuItem").
+		Preload("Slots.Options.Variant")
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ListMenuItems returns a page of the menu items visible to the requester in display order
+func ListMenuItems(query *dto.MenuItemListQuery, claims *common_dto.Claims) ([]models.MenuItem, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.MenuItem{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.CategoryID != nil {
+		db = db.Where("category_id = ?", *query.CategoryID)
+	}
+	if query.Available != nil {
+		db = db.Where("available = ?", *query.Available)
+	}
+	if search := strings.TrimSpace(query.Search); search != "" {
+		db = db.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(search)+"%")
+	}
+	db = filterDietary(db, &query.DietaryFilter)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting menu items: %w", err)
+	}
+
+	var items []models.MenuItem
+	err := preloadChoices(db.Preload("Category")).
+		Order("branch_id ASC, sort_order ASC, id ASC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching menu items: %w", err)
+	}
+	return items, total, nil
+}
+
+// GetMenuItem returns a menu item if it is visible to the requester
+func GetMenuItem(id uint, claims *common_dto.Claims) (*models.MenuItem, error) {
+	var item models.MenuItem
+	if err := preloadChoices(claims.ScopeBranches(models.DataBase, "branch_id")).Preload("Category").First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMenuItemNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu item: %w", err)
+	}
+	return &item, nil
+}
+
+// CreateMenuItem adds an item to a branch menu. Vegan items are always vegetarian.
+func CreateMenuItem(req *dto.CreateMenuItemRequest, claims *common_dto.Claims) (*models.MenuItem, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCategory(req.CategoryID, branch.ID); err != nil {
+		return nil, err
+	}
+
+	item := &models.MenuItem{
+		BranchID:     branch.ID,
+		CategoryID:   req.CategoryID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  req.Description,
+		Price:        req.Price,
+		CostPrice:    req.CostPrice,
+		Available:    true,
+		IsVegetarian: req.IsVegetarian || req.IsVegan,
+		IsVegan:      req.IsVegan,
+		IsGlutenFree: req.IsGlutenFree,
+		Spiciness:    req.Spiciness,
+		ImageURL:     req.ImageURL,
+		Ingredients:  JoinList(req.Ingredients),
+		Allergens:    JoinList(req.Allergens),
+	}
+	if req.PrepTime != nil {
+		item.PrepTime = *req.PrepTime
+	}
+	if req.SortOrder != nil {
+		item.SortOrder = *req.SortOrder
+	} else if item.SortOrder, err = nextSortOrder(&models.MenuItem{}, branch.ID); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Branch", "Category").Create(item).Error; err != nil {
+			return fmt.Errorf("error creating menu item: %w", err)
+		}
+		// Explicit zero values would be replaced by the column defaults on insert
+		zeroes := map[string]interface{}{}
+		if req.Available != nil && !*req.Available {
+			zeroes["available"] = false
+		}
+		if req.PrepTime != nil && *req.PrepTime == 0 {
+			zeroes["prep_time"] = 0
+		}
+		if len(zeroes) > 0 {
+			if err := tx.Model(item).Updates(zeroes).Error; err != nil {
+				return fmt.Errorf("error creating menu item: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(branch.ID)
+	return GetMenuItem(item.ID, claims)
+}
+
+// UpdateMenuItem applies the non-nil fields of req. Setting an item vegan makes it
+// vegetarian, and an item that is no longer vegetarian is no longer vegan.
+func UpdateMenuItem(id uint, req *dto.UpdateMenuItemRequest, claims *common_dto.Claims) (*models.MenuItem, error) {
+	item, err := GetMenuItem(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CategoryID != nil {
+		if *req.CategoryID == 0 {
+			item.CategoryID = nil
+		} else {
+			if err := validateCategory(req.CategoryID, item.BranchID); err != nil {
+				return nil, err
+			}
+			item.CategoryID = req.CategoryID
+		}
+		item.Category = nil
+	}
+	if req.Name != nil {
+		item.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		item.Description = *req.Description
+	}
+	if req.Price != nil {
+		item.Price = *req.Price
+	}
+	if req.CostPrice != nil {
+		item.CostPrice = *req.CostPrice
+	}
+	if req.Available != nil {
+		item.Available = *req.Available
+	}
+	if req.IsVegetarian != nil {
+		item.IsVegetarian = *req.IsVegetarian
+		if !item.IsVegetarian {
+			item.IsVegan = false
+		}
+	}
+	if req.IsVegan != nil {
+		item.IsVegan = *req.IsVegan
+		if item.IsVegan {
+			item.IsVegetarian = true
+		}
+	}
+	if req.IsGlutenFree != nil {
+		item.IsGlutenFree = *req.IsGlutenFree
+	}
+	if req.Spiciness != nil {
+		item.Spiciness = *req.Spiciness
+	}
+	if req.PrepTime != nil {
+		item.PrepTime = *req.PrepTime
+	}
+	if req.ImageURL != nil {
+		item.ImageURL = *req.ImageURL
+	}
+	if req.Ingredients != nil {
+		item.Ingredients = JoinList(*req.Ingredients)
+	}
+	if req.Allergens != nil {
+		item.Allergens = JoinList(*req.Allergens)
+	}
+	if req.SortOrder != nil {
+		item.SortOrder = *req.SortOrder
+	}
+
+	if err := models.DataBase.Omit("Branch", "Category", "Variants", "ModifierGroups").Save(item).Error; err != nil {
+		return nil, fmt.Errorf("error updating menu item: %w", err)
+	}
+
+	InvalidateMenu(item.BranchID)
+	return GetMenuItem(item.ID, claims)
+}
+
+// SetAvailability marks a menu item available or sold out (86'd). Sold out items
+// disappear from the customer menu and cannot be ordered until made available again.
+func SetAvailability(id uint, available bool, claims *common_dto.Claims) (*models.MenuItem, error) {
+	item, err := GetMenuItem(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := models.DataBase.Model(item).Update("available", available).Error; err != nil {
+		return nil, fmt.Errorf("error updating menu item availability: %w", err)
+	}
+	item.Available = available
+
+	InvalidateMenu(item.BranchID)
+	return item, nil
+}
+
+// DeleteMenuItem soft deletes a menu item; placed orders keep their lines
+func DeleteMenuItem(id uint, claims *common_dto.Claims) error {
+	item, err := GetMenuItem(id, claims)
+	if err != nil {
+		return err
+	}
+	if err := models.DataBase.Delete(item).Error; err != nil {
+		return fmt.Errorf("error deleting menu item: %w", err)
+	}
+
+	InvalidateMenu(item.BranchID)
+	return nil
+}
+
+// ReorderMenuItems sets the display order of a branch's items
+func ReorderMenuItems(req *dto.ReorderRequest, claims *common_dto.Claims) error {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return err
+	}
+	if err := reorder(&models.MenuItem{}, branch.ID, req.IDs); err != nil {
+		return err
+	}
+
+	InvalidateMenu(branch.ID)
+	return nil
+}
+
+// validateCategory checks that a category exists in the given branch
+func validateCategory(categoryID *uint, branchID uint) error {
+	if categoryID == nil {
+		return nil
+	}
+	var category models.MenuCategory
+	if err := models.DataBase.Select("id", "branch_id").First(&category, *categoryID).Error; err != nil || category.BranchID != branchID {
+		return ErrInvalidCategory
+	}
+	return nil
+}
+
+// filterDietary narrows a menu item query by dietary flags, spiciness and allergens.
+// Allergens are stored as comma-separated text, so they are matched on whole entries.
+func filterDietary(db *gorm.DB, filter *dto.DietaryFilter) *gorm.DB {
+	if filter.IsVegetarian != nil {
+		db = db.Where("is_vegetarian = ?", *filter.IsVegetarian)
+	}
+	if filter.IsVegan != nil {
+		db = db.Where("is_vegan = ?", *filter.IsVegan)
+	}
+	if filter.IsGlutenFree != nil {
+		db = db.Where("is_gluten_free = ?", *filter.IsGlutenFree)
+	}
+	if filter.MaxSpiciness != nil {
+		db = db.Where("spiciness <= ?", *filter.MaxSpiciness)
+	}
+	for _, allergen := range SplitList(filter.ExcludeAllergens) {
+		db = db.Where("(',' || LOWER(REPLACE(COALESCE(allergens, ''), ' ', '')) || ',') NOT LIKE ?", "%,"+allergenKey(allergen)+",%")
+	}
+	return db
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBranchNotFound   = errors.New("branch not found")
+	ErrCategoryNotFound = errors.New("menu category not found")
+	ErrMenuItemNotFound = errors.New("menu item not found")
+	ErrInvalidCategory  = errors.New("menu category does not belong to the item's branch")
+	ErrCategoryNotEmpty = errors.New("menu category still has items; move or delete them first")
+	ErrInvalidReorder   = errors.New("ids must be distinct and all belong to the branch")
+)
+
+// resolveBranch picks the branch a write operates on and checks the requester may act on it
+func resolveBranch(requested *uint, claims *common_dto.Claims) (*models.Branch, error) {
+	branchID, err := claims.ResolveBranchID(requested)
+	if err != nil {
+		return nil, err
+	}
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, branchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	return &branch, nil
+}
+
+// nextSortOrder returns the sort order that places a new row after the branch's last one
+func nextSortOrder(model interface{}, branchID uint) (int, error) {
+	var last *int
+	if err := models.DataBase.Model(model).Where("branch_id = ?", branchID).Select("MAX(sort_order)").Scan(&last).Error; err != nil {
+		return 0, fmt.Errorf("error fetching sort order: %w", err)
+	}
+	if last == nil {
+		return 0, nil
+	}
+	return *last + 1, nil
+}
+
+// reorder gives the listed rows of a branch consecutive sort orders from 0 in the given order.
+// Rows not listed keep their sort order.
+func reorder(model interface{}, branchID uint, ids []uint) error {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return ErrInvalidReorder
+		}
+		seen[id] = true
+	}
+
+	return models.DataBase.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(model).Where("id IN ? AND branch_id = ?", ids, branchID).Count(&count).Error; err != nil {
+			return fmt.Errorf("error reordering: %w", err)
+		}
+		if count != int64(len(ids)) {
+			return ErrInvalidReorder
+		}
+		for i, id := range ids {
+			if err := tx.Model(model).Where("id = ?", id).Update("sort_order", i).Error; err != nil {
+				return fmt.Errorf("error reordering: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// JoinList stores a list such as allergens as comma-separated text
+func JoinList(values []string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SplitList reads a list stored as comma-separated text
+func SplitList(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// allergenKey normalises an allergen for matching: "Tree Nuts" and "tree nuts" are the same
+func allergenKey(allergen string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(allergen), " ", ""))
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/schedule"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type cachedMenu struct {
+	menu      dto.PublicMenuResponse // Full menu before schedules are applied
+	rules     *schedule.RuleSet
+	etag      string
+	expiresAt time.Time
+}
+
+// menuCache keeps the customer menu of each branch in memory. Every menu write
+// invalidates its branch; the TTL only bounds staleness from changes made elsewhere.
+// A generation counter keeps a build that raced with an invalidation from being stored.
+var menuCache = struct {
+	sync.RWMutex
+	entries     map[uint]cachedMenu
+	generations map[uint]uint64
+}{
+	entries:     map[uint]cachedMenu{},
+	generations: map[uint]uint64{},
+}
+
+// PublicMenu returns the customer menu of a branch narrowed by the dietary filter,
+// together with an ETag that changes whenever the returned menu does. Menu schedules
+// are applied at request time: categories and items outside their availability
+// windows are hidden and scheduled discounts are shown against the regular price.
+func PublicMenu(branchID uint, filter *dto.DietaryFilter) (*dto.PublicMenuResponse, string, error) {
+	entry, err := cachedBranchMenu(branchID)
+	if err != nil {
+		return nil, "", err
+	}
+	timed := !entry.rules.IsEmpty() || len(entry.menu.OpeningHours) > 0
+	filtered := filter != nil && !filter.IsEmpty()
+	if !timed && !filtered {
+		menu := entry.menu
+		return &menu, entry.etag, nil
+	}
+
+	now := time.Now()
+	menu := entry.menu
+	menu.IsOpen = entry.rules.IsOpen(now)
+	menu.Categories = make([]dto.PublicMenuCategory, 0, len(entry.menu.Categories))
+	for _, category := range entry.menu.Categories {
+		if category.ID != 0 && !entry.rules.CategoryAvailable(category.ID, now) {
+			continue
+		}
+		items := make([]dto.PublicMenuItem, 0, len(category.Items))
+		for _, item := range category.Items {
+			if filtered && !matchesDietary(&item, filter) {
+				continue
+			}
+			if scheduleItem(&item, category.ID, entry.rules, now) {
+				items = append(items, item)
+			}
+		}
+		// Categories emptied by the filter or the schedules are dropped
+		if len(items) > 0 || (len(category.Items) == 0 && !filtered) {
+			category.Items = items
+			menu.Categories = append(menu.Categories, category)
+		}
+	}
+	return &menu, menuETag(&menu), nil
+}
+
+// InvalidateMenu drops the cached customer menu of a branch
+func InvalidateMenu(branchID uint) {
+	menuCache.Lock()
+	defer menuCache.Unlock()
+	delete(menuCache.entries, branchID)
+	menuCache.generations[branchID]++
+}
+
+// MenuCacheTTL returns how long a branch menu is served from memory (default 5m)
+func MenuCacheTTL() time.Duration {
+	if config.EnvConfig != nil {
+		if d, err := time.ParseDuration(config.EnvConfig.MenuCacheTTL); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 5 * time.Minute
+}
+
+func cachedBranchMenu(branchID uint) (cachedMenu, error) {
+	menuCache.RLock()
+	entry, ok := menuCache.entries[branchID]
+	generation := menuCache.generations[branchID]
+	menuCache.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	menu, err := buildPublicMenu(branchID)
+	if err != nil {
+		return cachedMenu{}, err
+	}
+	rules, err := schedule.LoadRules(models.DataBase, branchID)
+	if err != nil {
+		return cachedMenu{}, err
+	}
+	menu.IsOpen = rules.IsOpen(time.Now())
+	entry = cachedMenu{menu: *menu, rules: rules, etag: menuETag(menu), expiresAt: time.Now().Add(MenuCacheTTL())}
+
+	menuCache.Lock()
+	if menuCache.generations[branchID] == generation {
+		menuCache.entries[branchID] = entry
+	}
+	menuCache.Unlock()
+	return entry, nil
+}
+
+// buildPublicMenu loads the available items of a branch grouped by active category.
+// Items of inactive categories are hidden; items without a category go under "Other".
+func buildPublicMenu(branchID uint) (*dto.PublicMenuResponse, error) {
+	var branch models.Branch
+	if err := models.DataBase.Preload("Restaurant").First(&branch, branchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+
+	var categories []models.MenuCategory
+	err := models.DataBase.
+		Where("branch_id = ? AND is_active = ?", branchID, true).
+		Order("sort_order ASC, id ASC").
+		Find(&categories).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching menu categories: %w", err)
+	}
+
+	var items []models.MenuItem
+	err = preloadChoices(models.DataBase).
+		Where("branch_id = ? AND available = ?", branchID, true).
+		Order("sort_order ASC, id ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching menu items: %w", err)
+	}
+
+	menu := make([]dto.PublicMenuCategory, 0, len(categories)+1)
+	index := make(map[uint]int, len(categories))
+	for _, category := range categories {
+		index[category.ID] = len(menu)
+		menu = append(menu, dto.PublicMenuCategory{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			Items:       []dto.PublicMenuItem{},
+		})
+	}
+
+	var uncategorised []dto.PublicMenuItem
+	for i := range items {
+		item := toPublicMenuItem(&items[i])
+		if items[i].CategoryID == nil {
+			uncategorised = append(uncategorised, item)
+			continue
+		}
+		if at, ok := index[*items[i].CategoryID]; ok {
+			menu[at].Items = append(menu[at].Items, item)
+		}
+	}
+	if len(uncategorised) > 0 {
+		menu = append(menu, dto.PublicMenuCategory{Name: "Other", Items: uncategorised})
+	}
+
+	return &dto.PublicMenuResponse{
+		BranchID:     branch.ID,
+		BranchName:   branch.Name,
+		Currency:     branch.Restaurant.Currency,
+		TimeZone:     schedule.Location(branch.Restaurant.TimeZone).String(),
+		OpeningHours: branch.OpeningHours,
+		Categories:   menu,
+	}, nil
+}
+
+// scheduleItem reports whether a cached item is served at the given time and
+// applies its scheduled discount to the item and variant prices. The variants
+// are copied so the cached menu is left untouched.
+func scheduleItem(item *dto.PublicMenuItem, categoryID uint, rules *schedule.RuleSet, at time.Time) bool {
+	key := &models.MenuItem{ID: item.ID}
+	if categoryID != 0 {
+		key.CategoryID = &categoryID
+	}
+	if !rules.Available(key, at) {
+		return false
+	}
+	if rules.Discount(key, at) <= 0 {
+		return true
+	}
+
+	regular := item.Price
+	item.Price = rules.Price(key, regular, at)
+	item.RegularPrice = &regular
+	variants := make([]dto.PublicVariant, len(item.Variants))
+	for i, v := range item.Variants {
+		regular := v.Price
+		v.Price = rules.Price(key, regular, at)
+		v.RegularPrice = &regular
+		variants[i] = v
+	}
+	if len(variants) > 0 {
+		item.Variants = variants
+	}
+	return true
+}
+
+// matchesDietary applies the same rules as the staff item filter to a cached item
+func matchesDietary(item *dto.PublicMenuItem, filter *dto.DietaryFilter) bool {
+	if filter.IsVegetarian != nil && item.IsVegetarian != *filter.IsVegetarian {
+		return false
+	}
+	if filter.IsVegan != nil && item.IsVegan != *filter.IsVegan {
+		return false
+	}
+	if filter.IsGlutenFree != nil && item.IsGlutenFree != *filter.IsGlutenFree {
+		return false
+	}
+	if filter.MaxSpiciness != nil && item.Spiciness > *filter.MaxSpiciness {
+		return false
+	}
+	for _, excluded := range SplitList(filter.ExcludeAllergens) {
+		for _, allergen := range item.Allergens {
+			if allergenKey(allergen) == allergenKey(excluded) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func menuETag(menu *dto.PublicMenuResponse) string {
+	body, _ := json.Marshal(menu)
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// toPublicMenuItem maps an item with only the variants and modifier options that can be ordered
+func toPublicMenuItem(item *models.MenuItem) dto.PublicMenuItem {
+	public := dto.PublicMenuItem{
+		ID:           item.ID,
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        item.Price,
+		IsVegetarian: item.IsVegetarian,
+		IsVegan:      item.IsVegan,
+		IsGlutenFree: item.IsGlutenFree,
+		Spiciness:    item.Spiciness,
+		PrepTime:     item.PrepTime,
+		ImageURL:     item.ImageURL,
+		Allergens:    SplitList(item.Allergens),
+	}
+	for _, v := range item.Variants {
+		if v.Available {
+			public.Variants = append(public.Variants, dto.PublicVariant{ID: v.ID, Name: v.Name, Price: v.Price, IsDefault: v.IsDefault})
+		}
+	}
+	for _, g := range item.ModifierGroups {
+		group := dto.PublicModifierGroup{
+			ID:            g.ID,
+			Name:          g.Name,
+			IsRequired:    g.IsRequired,
+			MinSelections: g.MinSelections,
+			MaxSelections: g.MaxSelections,
+			Options:       []dto.PublicModifierOption{},
+		}
+		for _, o := range g.Options {
+			if o.Available {
+				group.Options = append(group.Options, dto.PublicModifierOption{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta})
+			}
+		}
+		public.ModifierGroups = append(public.ModifierGroups, group)
+	}
+	return public
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/menu/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/schedule"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("menu schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid menu schedule")
+)
+
+// ListSchedules returns the menu schedules visible to the requester
+func ListSchedules(query *dto.ScheduleListQuery, claims *common_dto.Claims) ([]models.MenuSchedule, error) {
+	db := preloadTargets(claims.ScopeBranches(models.DataBase.Model(&models.MenuSchedule{}), "branch_id"))
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.CategoryID != nil {
+		db = db.Where("category_id = ?", *query.CategoryID)
+	}
+	if query.MenuItemID != nil {
+		db = db.Where("menu_item_id = ?", *query.MenuItemID)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", strings.ToUpper(query.Type))
+	}
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+
+	var schedules []models.MenuSchedule
+	if err := db.Order("branch_id ASC, start_time ASC, id ASC").Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("error fetching menu schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetSchedule returns a menu schedule if it is visible to the requester
+func GetSchedule(id uint, claims *common_dto.Claims) (*models.MenuSchedule, error) {
+	var s models.MenuSchedule
+	if err := preloadTargets(claims.ScopeBranches(models.DataBase, "branch_id")).First(&s, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu schedule: %w", err)
+	}
+	return &s, nil
+}
+
+// CreateSchedule attaches a schedule to a category or item of a branch menu
+func CreateSchedule(req *dto.CreateScheduleRequest, claims *common_dto.Claims) (*models.MenuSchedule, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &models.MenuSchedule{
+		BranchID:        branch.ID,
+		Name:            strings.TrimSpace(req.Name),
+		Type:            models.ScheduleType(req.Type),
+		CategoryID:      req.CategoryID,
+		MenuItemID:      req.MenuItemID,
+		StartTime:       req.StartTime,
+		EndTime:         req.EndTime,
+		DiscountPercent: req.DiscountPercent,
+		IsActive:        true,
+	}
+	if s.Days, err = joinDays(req.Days); err != nil {
+		return nil, err
+	}
+	if err := validateSchedule(s); err != nil {
+		return nil, err
+	}
+	if err := checkScheduleTarget(s); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Branch", "Category", "MenuItem").Create(s).Error; err != nil {
+			return fmt.Errorf("error creating menu schedule: %w", err)
+		}
+		// A false is_active would be replaced by the column default on insert
+		if req.IsActive != nil && !*req.IsActive {
+			if err := tx.Model(s).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("error creating menu schedule: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateMenu(branch.ID)
+	return GetSchedule(s.ID, claims)
+}
+
+// UpdateSchedule applies the non-nil fields of req
+func UpdateSchedule(id uint, req *dto.UpdateScheduleRequest, claims *common_dto.Claims) (*models.MenuSchedule, error) {
+	s, err := GetSchedule(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		s.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Days != nil {
+		if s.Days, err = joinDays(*req.Days); err != nil {
+			return nil, err
+		}
+	}
+	if req.StartTime != nil {
+		s.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		s.EndTime = *req.EndTime
+	}
+	if req.DiscountPercent != nil {
+		s.DiscountPercent = *req.DiscountPercent
+	}
+	if req.IsActive != nil {
+		s.IsActive = *req.IsActive
+	}
+	if err := validateSchedule(s); err != nil {
+		return nil, err
+	}
+
+	if err := models.DataBase.Omit("Branch", "Category", "MenuItem").Save(s).Error; err != nil {
+		return nil, fmt.Errorf("error updating menu schedule: %w", err)
+	}
+
+	InvalidateMenu(s.BranchID)
+	return GetSchedule(s.ID, claims)
+}
+
+// DeleteSchedule soft deletes a menu schedule
+func DeleteSchedule(id uint, claims *common_dto.Claims) error {
+	s, err := GetSchedule(id, claims)
+	if err != nil {
+		return err
+	}
+	if err := models.DataBase.Delete(s).Error; err != nil {
+		return fmt.Errorf("error deleting menu schedule: %w", err)
+	}
+	InvalidateMenu(s.BranchID)
+	return nil
+}
+
+// ScheduleActiveNow reports whether a schedule's window is open now in its
+// restaurant's time zone; the schedule must be loaded with its branch
+func ScheduleActiveNow(s *models.MenuSchedule) bool {
+	return s.ActiveAt(time.Now().In(schedule.Location(s.Branch.Restaurant.TimeZone)))
+}
+
+// validateSchedule checks the window times and the discount of a price schedule
+func validateSchedule(s *models.MenuSchedule) error {
+	start, err := models.ParseClock(s.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: start_time: %v", ErrInvalidSchedule, err)
+	}
+	end, err := models.ParseClock(s.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: end_time: %v", ErrInvalidSchedule, err)
+	}
+	if start == end {
+		return fmt.Errorf("%w: start_time and end_time must differ", ErrInvalidSchedule)
+	}
+	s.StartTime = fmt.Sprintf("%02d:%02d", start/60, start%60)
+	s.EndTime = fmt.Sprintf("%02d:%02d", end/60, end%60)
+
+	switch s.Type {
+	case models.SchedulePrice:
+		if s.DiscountPercent <= 0 || s.DiscountPercent > 100 {
+			return fmt.Errorf("%w: price schedules need a discount_percent above 0 and up to 100", ErrInvalidSchedule)
+		}
+	case models.ScheduleAvailability:
+		if s.DiscountPercent != 0 {
+			return fmt.Errorf("%w: availability schedules cannot have a discount", ErrInvalidSchedule)
+		}
+	}
+	return nil
+}
+
+// checkScheduleTarget checks that the scheduled category or item is on the schedule's branch menu
+func checkScheduleTarget(s *models.MenuSchedule) error {
+	var count int64
+	var err error
+	if s.CategoryID != nil {
+		err = models.DataBase.Model(&models.MenuCategory{}).Where("id = ? AND branch_id = ?", *s.CategoryID, s.BranchID).Count(&count).Error
+	} else {
+		err = models.DataBase.Model(&models.MenuItem{}).Where("id = ? AND branch_id = ?", *s.MenuItemID, s.BranchID).Count(&count).Error
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching scheduled menu entry: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: the category or item is not on the branch menu", ErrInvalidSchedule)
+	}
+	return nil
+}
+
+// joinDays stores day names as a comma list of full lower case names in week order
+func joinDays(names []string) (string, error) {
+	picked := map[time.Weekday]bool{}
+	for _, name := range names {
+		day, err := models.ParseWeekday(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+		picked[day] = true
+	}
+	days := make([]string, 0, len(picked))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if picked[day] {
+			days = append(days, strings.ToLower(day.String()))
+		}
+	}
+	return strings.Join(days, ","), nil
+}
+
+// preloadTargets loads the category or item a schedule applies to and its restaurant
+func preloadTargets(db *gorm.DB) *gorm.DB {
+	return db.Preload("Branch.Restaurant").Preload("Category").Preload("MenuItem")
+}
@@ -0,0 +1,478 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	customer_services "restaurant_os/internal/api/customer/services"
+	order_dto "restaurant_os/internal/api/order/dto"
+	order_services "restaurant_os/internal/api/order/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/schedule"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type orderController struct{}
+
+var validate = dto.NewValidator()
+
+func NewOrderController() *orderController {
+	return &orderController{}
+}
+
+// CreateOrder places a staff order; all prices are computed on the server
+func (oc *orderController) CreateOrder(c *fiber.Ctx) error {
+	var req order_dto.CreateOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, order_dto.CreateOrderValidationErrorMessages)
+	}
+
+	order, err := order_services.CreateOrder(&req, middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to create order")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Order created successfully",
+		Data:    ToOrderResponse(order),
+	})
+}
+
+func (oc *orderController) GetOrders(c *fiber.Ctx) error {
+	var query order_dto.OrderListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	orders, total, err := order_services.ListOrders(&query, middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to fetch orders")
+	}
+
+	data := make([]order_dto.OrderResponse, 0, len(orders))
+	for i := range orders {
+		data = append(data, ToOrderResponse(&orders[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Orders fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (oc *orderController) GetOrder(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	order, err := order_services.GetOrder(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to fetch order")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Order fetched successfully",
+		Data:    ToOrderResponse(order),
+	})
+}
+
+// GetReceipt returns the printable receipt of an order with its tax breakdown
+func (oc *orderController) GetReceipt(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	receipt, err := order_services.GetReceipt(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to fetch receipt")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Receipt fetched successfully",
+		Data:    toReceiptResponse(receipt),
+	})
+}
+
+// UpdateOrderStatus moves an order through the order state machine
+func (oc *orderController) UpdateOrderStatus(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req order_dto.UpdateOrderStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, order_dto.UpdateStatusValidationErrorMessages)
+	}
+
+	order, err := order_services.UpdateOrderStatus(uint(id), models.OrderStatus(req.Status), req.Reason, middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to update order status")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Order status updated successfully",
+		Data:    ToOrderResponse(order),
+	})
+}
+
+// UpdateOrderItemStatus moves a single order item through the item state machine
+func (oc *orderController) UpdateOrderItemStatus(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+	itemID, err := c.ParamsInt("itemId")
+	if err != nil || itemID <= 0 {
+		errMsg := "Invalid order item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req order_dto.UpdateOrderItemStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, order_dto.UpdateStatusValidationErrorMessages)
+	}
+
+	order, err := order_services.UpdateOrderItemStatus(uint(id), uint(itemID), models.OrderItemStatus(req.Status), req.Reason, middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to update order item status")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Order item status updated successfully",
+		Data:    ToOrderResponse(order),
+	})
+}
+
+// GetOrderStatusHistory lists every recorded status change of an order and its items
+func (oc *orderController) GetOrderStatusHistory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	history, err := order_services.GetOrderStatusHistory(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return orderErrorResponse(c, err, "Failed to fetch status history")
+	}
+
+	data := make([]order_dto.StatusHistoryResponse, 0, len(history))
+	for _, h := range history {
+		entry := order_dto.StatusHistoryResponse{
+			ID:          h.ID,
+			OrderItemID: h.OrderItemID,
+			FromStatus:  h.FromStatus,
+			ToStatus:    h.ToStatus,
+			ChangedBy:   h.ChangedBy,
+			Reason:      h.Reason,
+			CreatedAt:   h.CreatedAt,
+		}
+		if h.ChangedByUser != nil {
+			entry.ChangedByName = h.ChangedByUser.Name
+		}
+		data = append(data, entry)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Status history fetched successfully",
+		Data:    data,
+	})
+}
+
+// validationErrorResponse renders validator errors using the per-field messages
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// orderErrorResponse maps order service errors to HTTP status codes
+func orderErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, order_services.ErrOrderNotFound), errors.Is(err, order_services.ErrBranchNotFound),
+		errors.Is(err, order_services.ErrOrderItemNotFound), errors.Is(err, customer_services.ErrCustomerNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden), errors.Is(err, order_services.ErrTransitionForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, order_services.ErrIllegalTransition):
+		status = fiber.StatusConflict
+	case errors.Is(err, order_services.ErrMenuItemNotFound), errors.Is(err, order_services.ErrMenuItemUnavailable),
+		errors.Is(err, order_services.ErrInvalidDiscount), errors.Is(err, order_services.ErrTableNotFound),
+		errors.Is(err, order_services.ErrVariantRequired), errors.Is(err, order_services.ErrInvalidVariant),
+		errors.Is(err, order_services.ErrInvalidModifiers), errors.Is(err, order_services.ErrComboNotFound),
+		errors.Is(err, order_services.ErrComboUnavailable), errors.Is(err, order_services.ErrInvalidComboSelection),
+		errors.Is(err, schedule.ErrNotScheduled):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, dto.ErrBranchRequired), errors.Is(err, order_services.ErrEmptyOrder):
+		status = fiber.StatusBadRequest
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+// ToModifierResponses maps the modifiers chosen on an order line
+func ToModifierResponses(modifiers []models.OrderItemModifier) []order_dto.ModifierResponse {
+	responses := make([]order_dto.ModifierResponse, 0, len(modifiers))
+	for _, m := range modifiers {
+		responses = append(responses, order_dto.ModifierResponse{
+			OptionID:   m.ModifierOptionID,
+			Group:      m.GroupName,
+			Name:       m.Name,
+			PriceDelta: m.PriceDelta,
+		})
+	}
+	return responses
+}
+
+// comboNames maps the order combos of an order to their names
+func comboNames(order *models.Order) map[uint]string {
+	names := make(map[uint]string, len(order.Combos))
+	for _, combo := range order.Combos {
+		names[combo.ID] = combo.Name
+	}
+	return names
+}
+
+func comboName(names map[uint]string, item *models.OrderItem) string {
+	if item.OrderComboID == nil {
+		return ""
+	}
+	return names[*item.OrderComboID]
+}
+
+// ToOrderResponse maps an order with preloaded items and combos to its API representation
+func ToOrderResponse(order *models.Order) order_dto.OrderResponse {
+	names := comboNames(order)
+	items := make([]order_dto.OrderItemResponse, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		items = append(items, order_dto.OrderItemResponse{
+			ID:          item.ID,
+			MenuItemID:  item.MenuItemID,
+			Name:        item.MenuItem.Name,
+			VariantID:   item.VariantID,
+			VariantName: item.VariantName,
+			Modifiers:   ToModifierResponses(item.Modifiers),
+			ComboID:     item.OrderComboID,
+			ComboName:   comboName(names, &item),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			Status:      string(item.Status),
+			Notes:       item.Notes,
+			Taxes:       ToTaxLineResponses(item.TaxLines),
+			CreatedAt:   item.CreatedAt,
+		})
+	}
+
+	combos := make([]order_dto.OrderComboResponse, 0, len(order.Combos))
+	for _, combo := range order.Combos {
+		combos = append(combos, order_dto.OrderComboResponse{
+			ID:         combo.ID,
+			ComboID:    combo.ComboID,
+			Name:       combo.Name,
+			Quantity:   combo.Quantity,
+			UnitPrice:  combo.UnitPrice,
+			TotalPrice: combo.TotalPrice,
+			Notes:      combo.Notes,
+		})
+	}
+
+	return order_dto.OrderResponse{
+		ID:             order.ID,
+		OrderNumber:    order.OrderNumber,
+		BranchID:       order.BranchID,
+		TableID:        order.TableID,
+		UserID:         order.UserID,
+		CustomerID:     order.CustomerID,
+		CustomerName:   order.CustomerName,
+		CustomerPhone:  order.CustomerPhone,
+		CustomerEmail:  order.CustomerEmail,
+		OrderType:      string(order.OrderType),
+		OrderSource:    string(order.OrderSource),
+		Status:         string(order.Status),
+		PaymentStatus:  string(order.PaymentStatus),
+		IsQROrder:      order.IsQROrder,
+		Subtotal:       order.Subtotal,
+		DiscountAmount: order.DiscountAmount,
+		TaxAmount:      order.TaxAmount,
+		IncludedTax:    order.IncludedTaxAmount,
+		ServiceCharge:  order.ServiceCharge,
+		Total:          order.Total,
+		Notes:          order.Notes,
+		EstimatedTime:  order.EstimatedTime,
+		Items:          items,
+		Combos:         combos,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+}
+
+// ToTaxLineResponses maps stored tax lines to their API representation
+func ToTaxLineResponses(lines []models.OrderTaxLine) []order_dto.TaxLineResponse {
+	data := make([]order_dto.TaxLineResponse, 0, len(lines))
+	for _, l := range lines {
+		data = append(data, order_dto.TaxLineResponse{
+			Name:          l.Name,
+			Rate:          l.Rate,
+			TaxableAmount: l.TaxableAmount,
+			Amount:        l.Amount,
+			IsInclusive:   l.IsInclusive,
+		})
+	}
+	return data
+}
+
+func toReceiptResponse(r *order_services.Receipt) order_dto.ReceiptResponse {
+	restaurant := r.Branch.Restaurant
+	names := comboNames(r.Order)
+	lines := make([]order_dto.ReceiptLine, 0, len(r.Lines))
+	for _, item := range r.Lines {
+		lines = append(lines, order_dto.ReceiptLine{
+			Name:        item.MenuItem.Name,
+			ComboName:   comboName(names, &item),
+			VariantName: item.VariantName,
+			Modifiers:   ToModifierResponses(item.Modifiers),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			Taxes:       ToTaxLineResponses(item.TaxLines),
+		})
+	}
+
+	payments := make([]order_dto.ReceiptPayment, 0, len(r.Payments))
+	var paid, rounding money.Money
+	for _, p := range r.Payments {
+		payments = append(payments, order_dto.ReceiptPayment{
+			Method:    string(p.Method),
+			Amount:    p.Amount,
+			Status:    string(p.Status),
+			CreatedAt: p.CreatedAt,
+		})
+		if p.Status == models.PaymentPaid || p.Status == models.PaymentRefunded {
+			paid = paid.Add(p.Amount)
+		}
+		rounding = rounding.Add(p.CashRounding)
+	}
+
+	return order_dto.ReceiptResponse{
+		RestaurantName:     restaurant.Name,
+		RestaurantAddress:  restaurant.Address,
+		RestaurantPhone:    restaurant.Phone,
+		TaxNumber:          restaurant.TaxNumber,
+		Currency:           restaurant.Currency,
+		BranchName:         r.Branch.Name,
+		BranchAddress:      r.Branch.Location,
+		BranchPhone:        r.Branch.Phone,
+		OrderNumber:        r.Order.OrderNumber,
+		OrderType:          string(r.Order.OrderType),
+		TableNumber:        r.TableNumber,
+		CustomerName:       r.Order.CustomerName,
+		Lines:              lines,
+		Subtotal:           r.Order.Subtotal,
+		DiscountAmount:     r.Order.DiscountAmount,
+		ServiceCharge:      r.Order.ServiceCharge,
+		ServiceChargeTaxes: ToTaxLineResponses(r.ServiceChargeTaxes),
+		TaxSummary:         ToTaxLineResponses(r.TaxSummary),
+		TaxAmount:          r.Order.TaxAmount,
+		IncludedTax:        r.Order.IncludedTaxAmount,
+		Total:              r.Order.Total,
+		Payments:           payments,
+		AmountPaid:         paid,
+		CashRounding:       rounding,
+		CreatedAt:          r.Order.CreatedAt,
+	}
+}
@@ -0,0 +1,240 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// ORDER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// OrderItemInput represents a single line of an order request.
+// Prices are never accepted from clients; they are looked up on the server.
+type OrderItemInput struct {
+	MenuItemID        uint   `json:"menu_item_id" validate:"required"`
+	VariantID         *uint  `json:"variant_id,omitempty"` // Defaults to the item's default variant
+	ModifierOptionIDs []uint `json:"modifier_option_ids,omitempty" validate:"max=30,dive,required"`
+	Quantity          int    `json:"quantity" validate:"required,min=1,max=100"`
+	Notes             string `json:"notes,omitempty" validate:"max=500"`
+}
+
+// ComboInput represents a combo ordered as one line. Each slot is filled with one of
+// its options; slots with a single option may be left out.
+type ComboInput struct {
+	ComboID    uint                  `json:"combo_id" validate:"required"`
+	Selections []ComboSelectionInput `json:"selections,omitempty" validate:"max=20,dive"`
+	Quantity   int                   `json:"quantity" validate:"required,min=1,max=100"`
+	Notes      string                `json:"notes,omitempty" validate:"max=500"`
+}
+
+// ComboSelectionInput is the option chosen for one combo slot, with modifiers for its item
+type ComboSelectionInput struct {
+	SlotID            uint   `json:"slot_id" validate:"required"`
+	OptionID          uint   `json:"option_id" validate:"required"`
+	ModifierOptionIDs []uint `json:"modifier_option_ids,omitempty" validate:"max=30,dive,required"`
+}
+
+// CreateOrderRequest represents create order request
+type CreateOrderRequest struct {
+	BranchID        *uint            `json:"branch_id,omitempty"` // Defaults to the user's branch
+	TableID         *uint            `json:"table_id,omitempty"`
+	OrderType       string           `json:"order_type" validate:"required,oneof=DINE_IN TAKEAWAY DELIVERY ONLINE"`
+	CustomerID      *uint            `json:"customer_id,omitempty"` // Defaults to the customer with customer_phone, created if new
+	CustomerName    string           `json:"customer_name,omitempty" validate:"max=100"`
+	CustomerPhone   string           `json:"customer_phone,omitempty" validate:"max=20"`
+	CustomerEmail   string           `json:"customer_email,omitempty" validate:"omitempty,email"`
+	Notes           string           `json:"notes,omitempty"`
+	DiscountAmount  money.Money      `json:"discount_amount,omitempty" validate:"min=0"`
+	DiscountPercent float64          `json:"discount_percent,omitempty" validate:"min=0,max=100"`
+	Items           []OrderItemInput `json:"items" validate:"required_without=Combos,dive"`
+	Combos          []ComboInput     `json:"combos,omitempty" validate:"max=20,dive"`
+}
+
+// CreateOrderValidationErrorMessages maps CreateOrderRequest fields to custom messages
+var CreateOrderValidationErrorMessages = map[string]string{
+	"OrderType":         "Order type is required and must be one of: DINE_IN, TAKEAWAY, DELIVERY, ONLINE.",
+	"CustomerName":      "Customer name must be at most 100 characters.",
+	"CustomerPhone":     "Customer phone must be at most 20 characters.",
+	"CustomerEmail":     "Customer email must be a valid email address.",
+	"DiscountAmount":    "Discount amount cannot be negative.",
+	"DiscountPercent":   "Discount percent must be between 0 and 100.",
+	"Items":             "At least one item or combo is required.",
+	"Combos":            "At most 20 combos per order.",
+	"ComboID":           "Each combo needs a combo_id.",
+	"Selections":        "At most 20 selections per combo.",
+	"SlotID":            "Each combo selection needs a slot_id.",
+	"OptionID":          "Each combo selection needs an option_id.",
+	"MenuItemID":        "Each item needs a menu_item_id.",
+	"Quantity":          "Each item or combo quantity must be between 1 and 100.",
+	"ModifierOptionIDs": "At most 30 modifier options per item.",
+}
+
+// UpdateOrderStatusRequest represents update order status request
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=CONFIRMED PREPARING READY SERVED COMPLETED CANCELLED"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// UpdateOrderItemStatusRequest represents update order item status request
+type UpdateOrderItemStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=PREPARING READY SERVED CANCELLED"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// UpdateStatusValidationErrorMessages maps status update request fields to custom messages
+var UpdateStatusValidationErrorMessages = map[string]string{
+	"Status": "Status is required and must be a valid status.",
+	"Reason": "Reason must be at most 500 characters.",
+}
+
+// OrderListQuery represents filters for listing orders
+type OrderListQuery struct {
+	dto.PaginationQuery
+	BranchID      *uint  `query:"branch_id"`
+	Status        string `query:"status"`
+	PaymentStatus string `query:"payment_status"`
+	OrderType     string `query:"order_type"`
+	TableID       *uint  `query:"table_id"`
+	CustomerID    *uint  `query:"customer_id"` // A customer's order history
+}
+
+// TaxLineResponse represents one tax component applied to a line or to the service charge
+type TaxLineResponse struct {
+	Name          string      `json:"name"`
+	Rate          float64     `json:"rate"`
+	TaxableAmount money.Money `json:"taxable_amount"`
+	Amount        money.Money `json:"amount"`
+	IsInclusive   bool        `json:"is_inclusive"`
+}
+
+// OrderItemResponse represents order item response
+type OrderItemResponse struct {
+	ID          uint               `json:"id"`
+	MenuItemID  uint               `json:"menu_item_id"`
+	Name        string             `json:"name"`
+	VariantID   *uint              `json:"variant_id,omitempty"`
+	VariantName string             `json:"variant_name,omitempty"`
+	Modifiers   []ModifierResponse `json:"modifiers"`
+	ComboID     *uint              `json:"order_combo_id,omitempty"` // Order combo the item was sold in
+	ComboName   string             `json:"combo_name,omitempty"`
+	Quantity    int                `json:"quantity"`
+	UnitPrice   money.Money        `json:"unit_price"`
+	TotalPrice  money.Money        `json:"total_price"`
+	Status      string             `json:"status"`
+	Notes       string             `json:"notes,omitempty"`
+	Taxes       []TaxLineResponse  `json:"taxes"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// ModifierResponse represents a modifier option chosen on an order or cart line
+type ModifierResponse struct {
+	OptionID   uint        `json:"option_id"`
+	Group      string      `json:"group"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// OrderComboResponse represents a combo sold on an order; its price is included in
+// the totals of its component items
+type OrderComboResponse struct {
+	ID         uint        `json:"id"`
+	ComboID    uint        `json:"combo_id"`
+	Name       string      `json:"name"`
+	Quantity   int         `json:"quantity"`
+	UnitPrice  money.Money `json:"unit_price"`
+	TotalPrice money.Money `json:"total_price"`
+	Notes      string      `json:"notes,omitempty"`
+}
+
+// OrderResponse represents order response
+type OrderResponse struct {
+	ID             uint                 `json:"id"`
+	OrderNumber    string               `json:"order_number"`
+	BranchID       uint                 `json:"branch_id"`
+	TableID        *uint                `json:"table_id,omitempty"`
+	UserID         *uint                `json:"user_id,omitempty"`
+	CustomerID     *uint                `json:"customer_id,omitempty"`
+	CustomerName   string               `json:"customer_name,omitempty"`
+	CustomerPhone  string               `json:"customer_phone,omitempty"`
+	CustomerEmail  string               `json:"customer_email,omitempty"`
+	OrderType      string               `json:"order_type"`
+	OrderSource    string               `json:"order_source"`
+	Status         string               `json:"status"`
+	PaymentStatus  string               `json:"payment_status"`
+	IsQROrder      bool                 `json:"is_qr_order"`
+	Subtotal       money.Money          `json:"subtotal"`
+	DiscountAmount money.Money          `json:"discount_amount"`
+	TaxAmount      money.Money          `json:"tax_amount"`
+	IncludedTax    money.Money          `json:"included_tax_amount"` // Tax contained in tax-inclusive prices
+	ServiceCharge  money.Money          `json:"service_charge"`
+	Total          money.Money          `json:"total"`
+	Notes          string               `json:"notes,omitempty"`
+	EstimatedTime  int                  `json:"estimated_time"`
+	Items          []OrderItemResponse  `json:"items"`
+	Combos         []OrderComboResponse `json:"combos"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// StatusHistoryResponse represents a single recorded status change
+type StatusHistoryResponse struct {
+	ID            uint      `json:"id"`
+	OrderItemID   *uint     `json:"order_item_id,omitempty"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	ChangedBy     *uint     `json:"changed_by,omitempty"`
+	ChangedByName string    `json:"changed_by_name,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReceiptLine represents a line printed on a receipt
+type ReceiptLine struct {
+	Name        string             `json:"name"`
+	ComboName   string             `json:"combo_name,omitempty"`
+	VariantName string             `json:"variant_name,omitempty"`
+	Modifiers   []ModifierResponse `json:"modifiers"`
+	Quantity    int                `json:"quantity"`
+	UnitPrice   money.Money        `json:"unit_price"`
+	TotalPrice  money.Money        `json:"total_price"`
+	Taxes       []TaxLineResponse  `json:"taxes"`
+}
+
+// ReceiptPayment represents a payment or refund printed on a receipt
+type ReceiptPayment struct {
+	Method    string      `json:"method"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ReceiptResponse represents a printable receipt with the tax breakdown
+type ReceiptResponse struct {
+	RestaurantName     string            `json:"restaurant_name"`
+	RestaurantAddress  string            `json:"restaurant_address,omitempty"`
+	RestaurantPhone    string            `json:"restaurant_phone,omitempty"`
+	TaxNumber          string            `json:"tax_number,omitempty"`
+	Currency           string            `json:"currency"`
+	BranchName         string            `json:"branch_name"`
+	BranchAddress      string            `json:"branch_address,omitempty"`
+	BranchPhone        string            `json:"branch_phone,omitempty"`
+	OrderNumber        string            `json:"order_number"`
+	OrderType          string            `json:"order_type"`
+	TableNumber        string            `json:"table_number,omitempty"`
+	CustomerName       string            `json:"customer_name,omitempty"`
+	Lines              []ReceiptLine     `json:"lines"`
+	Subtotal           money.Money       `json:"subtotal"`
+	DiscountAmount     money.Money       `json:"discount_amount"`
+	ServiceCharge      money.Money       `json:"service_charge"`
+	ServiceChargeTaxes []TaxLineResponse `json:"service_charge_taxes"`
+	TaxSummary         []TaxLineResponse `json:"tax_summary"` // Components totalled across the order
+	TaxAmount          money.Money       `json:"tax_amount"`
+	IncludedTax        money.Money       `json:"included_tax_amount"`
+	Total              money.Money       `json:"total"`
+	Payments           []ReceiptPayment  `json:"payments"`
+	AmountPaid         money.Money       `json:"amount_paid"`
+	CashRounding       money.Money       `json:"cash_rounding"` // Rounding of cash settlements to the smallest coin
+	CreatedAt          time.Time         `json:"created_at"`
+}
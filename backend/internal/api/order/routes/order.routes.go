@@ -0,0 +1,25 @@
+package routes
+
+import (
+	order_controller "restaurant_os/internal/api/order/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterOrderRoutes(api fiber.Router) {
+
+	orders := api.Group("/orders", middleware.RequireAuth())
+
+	orderHandler := order_controller.NewOrderController()
+
+	orders.Get("/", orderHandler.GetOrders)
+	orders.Post("/", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "WAITER", "CASHIER", "HOST"), orderHandler.CreateOrder)
+	orders.Get("/:id", orderHandler.GetOrder)
+	orders.Get("/:id/status-history", orderHandler.GetOrderStatusHistory)
+	orders.Get("/:id/receipt", orderHandler.GetReceipt)
+
+	// Role checks per target status happen in the service (kitchen, waiter, cashier)
+	orders.Patch("/:id/status", orderHandler.UpdateOrderStatus)
+	orders.Patch("/:id/items/:itemId/status", orderHandler.UpdateOrderItemStatus)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/order/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/schedule"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrComboNotFound         = errors.New("combo not found in this branch")
+	ErrComboUnavailable      = errors.New("combo is currently unavailable")
+	ErrInvalidComboSelection = errors.New("invalid combo selection")
+)
+
+// PricedCombo is a combo line with its price and the lines of its component items
+type PricedCombo struct {
+	Combo      models.Combo
+	Quantity   int
+	UnitPrice  money.Money // Bundle price plus slot upcharges and modifier price deltas
+	TotalPrice money.Money
+	Notes      string
+	Lines      []int // Indexes of the component lines in PricedOrder.Lines
+}
+
+// comboChoice is the option picked for one slot of an ordered combo
+type comboChoice struct {
+	option    *models.ComboSlotOption
+	modifiers []uint
+}
+
+// loadCombos fetches the ordered combos of a branch with their slots and options
+func loadCombos(tx *gorm.DB, branchID uint, inputs []dto.ComboInput) (map[uint]models.Combo, error) {
+	byID := make(map[uint]models.Combo, len(inputs))
+	if len(inputs) == 0 {
+		return byID, nil
+	}
+	ids := make([]uint, 0, len(inputs))
+	for _, input := range inputs {
+		ids = append(ids, input.ComboID)
+	}
+	var combos []models.Combo
+	err := tx.Preload("Slots", bySortOrder).
+		Preload("Slots.Options", bySortOrder).
+		Where("branch_id = ? AND id IN ?", branchID, ids).
+		Find(&combos).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching combos: %w", err)
+	}
+	for _, c := range combos {
+		byID[c.ID] = c
+	}
+	return byID, nil
+}
+
+// fillSlots matches the selections of a combo to its slots. Every slot needs exactly
+// one option; a slot with a single option is filled with it when not selected.
+func fillSlots(combo *models.Combo, selections []dto.ComboSelectionInput) ([]comboChoice, error) {
+	bySlot := make(map[uint]dto.ComboSelectionInput, len(selections))
+	for _, s := range selections {
+		if _, ok := bySlot[s.SlotID]; ok {
+			return nil, fmt.Errorf("%w: slot %d is chosen twice", ErrInvalidComboSelection, s.SlotID)
+		}
+		bySlot[s.SlotID] = s
+	}
+
+	choices := make([]comboChoice, 0, len(combo.Slots))
+	for i := range combo.Slots {
+		slot := &combo.Slots[i]
+		selection, ok := bySlot[slot.ID]
+		delete(bySlot, slot.ID)
+		if !ok {
+			if len(slot.Options) != 1 {
+				return nil, fmt.Errorf("%w: choose an option for %s in %s", ErrInvalidComboSelection, slot.Name, combo.Name)
+			}
+			choices = append(choices, comboChoice{option: &slot.Options[0]})
+			continue
+		}
+
+		var option *models.ComboSlotOption
+		for j := range slot.Options {
+			if slot.Options[j].ID == selection.OptionID {
+				option = &slot.Options[j]
+			}
+		}
+		if option == nil {
+			return nil, fmt.Errorf("%w: option %d is not offered for %s in %s", ErrInvalidComboSelection, selection.OptionID, slot.Name, combo.Name)
+		}
+		choices = append(choices, comboChoice{option: option, modifiers: selection.ModifierOptionIDs})
+	}
+	if len(bySlot) > 0 {
+		return nil, fmt.Errorf("%w: some slots do not belong to %s", ErrInvalidComboSelection, combo.Name)
+	}
+	if len(choices) == 0 {
+		return nil, fmt.Errorf("%w: %s has no items", ErrInvalidComboSelection, combo.Name)
+	}
+	return choices, nil
+}
+
+// priceCombo adds a combo and its component lines to priced. The combo price is
+// allocated to the components in proportion to their standalone prices, so the
+// component totals always add up to the combo total. Components must be served at
+// this time; scheduled discounts do not apply to combos.
+func priceCombo(priced *PricedOrder, combo *models.Combo, input *dto.ComboInput, choices []comboChoice, menuItems map[uint]models.MenuItem, schedules *schedule.RuleSet, now time.Time) error {
+	pricedCombo := PricedCombo{
+		Combo:     *combo,
+		Quantity:  input.Quantity,
+		UnitPrice: combo.Price,
+		Notes:     input.Notes,
+	}
+
+	lines := make([]PricedLine, 0, len(choices))
+	weights := make([]money.Money, 0, len(choices))
+	for _, choice := range choices {
+		menuItem, ok := menuItems[choice.option.MenuItemID]
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrMenuItemNotFound, choice.option.MenuItemID)
+		}
+		if !menuItem.Available {
+			return fmt.Errorf("%w: %s", ErrMenuItemUnavailable, menuItem.Name)
+		}
+		if err := schedules.Check(&menuItem, now); err != nil {
+			return err
+		}
+		selection, err := ResolveSelection(&menuItem, choice.option.VariantID, choice.modifiers)
+		if err != nil {
+			return err
+		}
+
+		pricedCombo.UnitPrice = pricedCombo.UnitPrice.Add(choice.option.PriceDelta)
+		for _, m := range selection.Modifiers {
+			pricedCombo.UnitPrice = pricedCombo.UnitPrice.Add(m.PriceDelta)
+		}
+		weights = append(weights, selection.UnitPrice)
+		lines = append(lines, PricedLine{
+			MenuItem:  menuItem,
+			Quantity:  input.Quantity,
+			Notes:     input.Notes,
+			Selection: selection,
+		})
+		if menuItem.PrepTime > priced.EstimatedTime {
+			priced.EstimatedTime = menuItem.PrepTime
+		}
+	}
+	if pricedCombo.UnitPrice.IsNegative() {
+		return fmt.Errorf("%w: price of %s cannot be negative", ErrInvalidComboSelection, combo.Name)
+	}
+	pricedCombo.TotalPrice = pricedCombo.UnitPrice.Mul(input.Quantity)
+
+	for i, share := range pricedCombo.UnitPrice.Allocate(weights) {
+		lines[i].UnitPrice = share
+		lines[i].TotalPrice = share.Mul(input.Quantity)
+		pricedCombo.Lines = append(pricedCombo.Lines, len(priced.Lines))
+		priced.Lines = append(priced.Lines, lines[i])
+	}
+	priced.Combos = append(priced.Combos, pricedCombo)
+	priced.Subtotal = priced.Subtotal.Add(pricedCombo.TotalPrice)
+	return nil
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	customer_services "restaurant_os/internal/api/customer/services"
+	"restaurant_os/internal/api/order/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/numbering"
+	"restaurant_os/internal/realtime"
+	"restaurant_os/internal/tax"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrderNotFound  = errors.New("order not found")
+	ErrBranchNotFound = errors.New("branch not found")
+	ErrTableNotFound  = errors.New("table not found in this branch")
+)
+
+// NewOrder is everything needed to place an order once the caller has been authorised.
+// It is shared by staff order entry and QR checkout.
+type NewOrder struct {
+	BranchID      uint
+	TableID       *uint
+	UserID        *uint // Staff member placing the order, nil for QR orders
+	OrderType     models.OrderType
+	OrderSource   models.OrderSource
+	QRSessionID   *uint
+	CustomerID    *uint // Links the order to this customer instead of the one with CustomerPhone
+	CustomerName  string
+	CustomerPhone string
+	CustomerEmail string
+	Notes         string
+	Items         []dto.OrderItemInput
+	Combos        []dto.ComboInput
+	Discount      Discount
+}
+
+// CreateOrder authorises the requester for the branch and places a staff order
+func CreateOrder(req *dto.CreateOrderRequest, claims *common_dto.Claims) (*models.Order, error) {
+	branchID, err := claims.ResolveBranchID(req.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := getBranch(branchID)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+
+	userID := claims.UserID
+	input := &NewOrder{
+		BranchID:      branch.ID,
+		TableID:       req.TableID,
+		UserID:        &userID,
+		OrderType:     models.OrderType(req.OrderType),
+		OrderSource:   models.OrderSourceStaff,
+		CustomerID:    req.CustomerID,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		CustomerEmail: req.CustomerEmail,
+		Notes:         req.Notes,
+		Items:         req.Items,
+		Combos:        req.Combos,
+		Discount:      Discount{Amount: req.DiscountAmount, Percent: req.DiscountPercent},
+	}
+
+	var order *models.Order
+	err = RunOrderTransaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = PlaceOrder(tx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	realtime.Publish(realtime.Event{Type: realtime.EventOrderCreated, BranchID: order.BranchID, OrderID: order.ID})
+	return GetOrderByID(order.ID)
+}
+
+// RunOrderTransaction runs fn in a transaction, retrying a few times when two
+// concurrent orders were given the same order number
+func RunOrderTransaction(fn func(tx *gorm.DB) error) error {
+	const maxAttempts = 3
+	for attempt := 1; ; attempt++ {
+		err := models.DataBase.Transaction(fn)
+		if err == nil || attempt == maxAttempts || !isDuplicateKeyError(err) {
+			return err
+		}
+	}
+}
+
+// PlaceOrder prices and persists an order with its items inside tx.
+// The order number is generated here; the caller owns the transaction.
+func PlaceOrder(tx *gorm.DB, input *NewOrder) (*models.Order, error) {
+	if input.TableID != nil {
+		var table models.Table
+		if err := tx.Where("id = ? AND branch_id = ?", *input.TableID, input.BranchID).First(&table).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrTableNotFound
+			}
+			return nil, fmt.Errorf("error fetching table: %w", err)
+		}
+		if input.OrderType == models.OrderTypeDineIn && table.Status == models.TableAvailable {
+			if err := tx.Model(&table).Update("status", models.TableOccupied).Error; err != nil {
+				return nil, fmt.Errorf("error updating table status: %w", err)
+			}
+		}
+	}
+
+	priced, err := PriceOrder(tx, input.BranchID, input.OrderType, input.Items, input.Combos, input.Discount)
+	if err != nil {
+		return nil, err
+	}
+
+	orderNumber, err := nextOrderNumber(tx, input.BranchID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := customer_services.ForOrder(tx, input.BranchID, input.CustomerID, input.CustomerName, input.CustomerPhone, input.CustomerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.Order{
+		OrderNumber:       orderNumber,
+		TableID:           input.TableID,
+		BranchID:          input.BranchID,
+		UserID:            input.UserID,
+		CustomerName:      input.CustomerName,
+		CustomerPhone:     input.CustomerPhone,
+		CustomerEmail:     input.CustomerEmail,
+		OrderType:         input.OrderType,
+		OrderSource:       input.OrderSource,
+		Status:            models.OrderPending,
+		PaymentStatus:     models.PaymentPending,
+		QRSessionID:       input.QRSessionID,
+		IsQROrder:         input.OrderSource == models.OrderSourceQR,
+		Subtotal:          priced.Subtotal,
+		TaxAmount:         priced.TaxAmount,
+		DiscountAmount:    priced.DiscountAmount,
+		ServiceCharge:     priced.ServiceCharge,
+		Total:             priced.Total,
+		IncludedTaxAmount: priced.IncludedTaxAmount,
+		Notes:             input.Notes,
+		EstimatedTime:     priced.EstimatedTime,
+	}
+	if customer != nil {
+		order.CustomerID = &customer.ID
+		if order.CustomerName == "" {
+			order.CustomerName = customer.Name
+		}
+		if order.CustomerPhone == "" {
+			order.CustomerPhone = customer.Phone
+		}
+		if order.CustomerEmail == "" {
+			order.CustomerEmail = customer.Email
+		}
+	}
+	for _, line := range priced.Lines {
+		item := models.OrderItem{
+			MenuItemID: line.MenuItem.ID,
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			TotalPrice: line.TotalPrice,
+			Status:     models.OrderItemPending,
+			Notes:      line.Notes,
+		}
+		if variant := line.Selection.Variant; variant != nil {
+			item.VariantID = &variant.ID
+			item.VariantName = variant.Name
+		}
+		for _, m := range line.Selection.Modifiers {
+			item.Modifiers = append(item.Modifiers, models.OrderItemModifier{
+				ModifierOptionID: m.OptionID,
+				GroupName:        m.GroupName,
+				Name:             m.Name,
+				PriceDelta:       m.PriceDelta,
+			})
+		}
+		order.OrderItems = append(order.OrderItems, item)
+	}
+
+	for _, combo := range priced.Combos {
+		order.Combos = append(order.Combos, models.OrderCombo{
+			ComboID:    combo.Combo.ID,
+			Name:       combo.Combo.Name,
+			Quantity:   combo.Quantity,
+			UnitPrice:  combo.UnitPrice,
+			TotalPrice: combo.TotalPrice,
+			Notes:      combo.Notes,
+		})
+	}
+
+	if err := tx.Omit("OrderItems.MenuItem").Create(order).Error; err != nil {
+		return nil, fmt.Errorf("error creating order: %w", err)
+	}
+	for i, combo := range priced.Combos {
+		ids := make([]uint, 0, len(combo.Lines))
+		for _, line := range combo.Lines {
+			order.OrderItems[line].OrderComboID = &order.Combos[i].ID
+			ids = append(ids, order.OrderItems[line].ID)
+		}
+		if err := tx.Model(&models.OrderItem{}).Where("id IN ?", ids).Update("order_combo_id", order.Combos[i].ID).Error; err != nil {
+			return nil, fmt.Errorf("error linking combo items: %w", err)
+		}
+	}
+
+	var taxLines []models.OrderTaxLine
+	for i, line := range priced.Lines {
+		taxLines = append(taxLines, toOrderTaxLines(order.ID, &order.OrderItems[i].ID, line.Taxes)...)
+	}
+	taxLines = append(taxLines, toOrderTaxLines(order.ID, nil, priced.ServiceChargeTaxes)...)
+	if len(taxLines) > 0 {
+		if err := tx.Create(&taxLines).Error; err != nil {
+			return nil, fmt.Errorf("error storing order taxes: %w", err)
+		}
+	}
+	return order, nil
+}
+
+func toOrderTaxLines(orderID uint, orderItemID *uint, lines []tax.Line) []models.OrderTaxLine {
+	taxLines := make([]models.OrderTaxLine, 0, len(lines))
+	for _, l := range lines {
+		taxLines = append(taxLines, models.OrderTaxLine{
+			OrderID:       orderID,
+			OrderItemID:   orderItemID,
+			TaxRuleID:     l.RuleID,
+			Name:          l.Name,
+			Rate:          l.Rate,
+			TaxableAmount: l.Taxable,
+			Amount:        l.Amount,
+			IsInclusive:   l.IsInclusive,
+		})
+	}
+	return taxLines
+}
+
+// GetOrderByID loads an order with its items and menu items
+func GetOrderByID(id uint) (*models.Order, error) {
+	var order models.Order
+	err := models.DataBase.Preload("OrderItems.MenuItem").Preload("OrderItems.TaxLines").Preload("OrderItems.Modifiers").Preload("Combos").First(&order, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error fetching order: %w", err)
+	}
+	return &order, nil
+}
+
+// GetOrder returns an order if the requester has access to its branch
+func GetOrder(id uint, claims *common_dto.Claims) (*models.Order, error) {
+	order, err := GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := getBranch(order.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		// Do not reveal orders of other tenants
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// ListOrders returns a page of orders visible to the requester, newest first
+func ListOrders(query *dto.OrderListQuery, claims *common_dto.Claims) ([]models.Order, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.Order{}), "orders.branch_id")
+	if query.BranchID != nil {
+		db = db.Where("orders.branch_id = ?", *query.BranchID)
+	}
+	if query.Status != "" {
+		db = db.Where("orders.status = ?", strings.ToUpper(query.Status))
+	}
+	if query.PaymentStatus != "" {
+		db = db.Where("orders.payment_status = ?", strings.ToUpper(query.PaymentStatus))
+	}
+	if query.OrderType != "" {
+		db = db.Where("orders.order_type = ?", strings.ToUpper(query.OrderType))
+	}
+	if query.TableID != nil {
+		db = db.Where("orders.table_id = ?", *query.TableID)
+	}
+	if query.CustomerID != nil {
+		db = db.Where("orders.customer_id = ?", *query.CustomerID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting orders: %w", err)
+	}
+
+	var orders []models.Order
+	err := db.Preload("OrderItems.MenuItem").Preload("OrderItems.TaxLines").Preload("OrderItems.Modifiers").Preload("Combos").
+		Order("orders.created_at DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching orders: %w", err)
+	}
+	return orders, total, nil
+}
+
+func getBranch(id uint) (*models.Branch, error) {
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	return &branch, nil
+}
+
+// nextOrderNumber returns a human-readable order number unique per branch and day,
+// e.g. ORD-20250614-1-0007; concurrent orders given the same number are retried
+// by RunOrderTransaction
+func nextOrderNumber(tx *gorm.DB, branchID uint) (string, error) {
+	return numbering.Next(tx, &models.Order{}, "order_number", "ORD", branchID)
+}
+
+// isDuplicateKeyError detects unique constraint violations on SQLite and PostgreSQL
+func isDuplicateKeyError(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "duplicate key value")
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/order/dto"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/schedule"
+	"restaurant_os/internal/tax"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMenuItemNotFound    = errors.New("menu item not found in this branch")
+	ErrMenuItemUnavailable = errors.New("menu item is currently unavailable")
+	ErrInvalidDiscount     = errors.New("only one of discount_amount or discount_percent may be set")
+	ErrEmptyOrder          = errors.New("an order needs at least one item or combo")
+)
+
+// PricingSettings holds the rates (in percent) applied by the pricing engine.
+// Taxes come from the restaurant's tax rules, see package tax.
+type PricingSettings struct {
+	ServiceChargeRate float64
+}
+
+// PricedLine is an order line with its server-side prices
+type PricedLine struct {
+	MenuItem   models.MenuItem
+	Quantity   int
+	UnitPrice  money.Money
+	TotalPrice money.Money
+	Notes      string
+	Selection  *Selection // Chosen variant and modifiers
+	Taxes      []tax.Line // Computed on the line total after its share of the discount
+}
+
+// PricedOrder is the result of pricing a set of order lines
+type PricedOrder struct {
+	Lines          []PricedLine // Standalone items followed by the components of each combo
+	Combos         []PricedCombo
+	Subtotal       money.Money
+	DiscountAmount money.Money
+	TaxAmount      money.Money // Tax added on top of prices, including tax on the service charge
+	ServiceCharge  money.Money
+	Total          money.Money
+	EstimatedTime  int // minutes, longest prep time of the lines
+
+	IncludedTaxAmount  money.Money // Tax contained in tax-inclusive prices
+	ServiceChargeTaxes []tax.Line  // Tax on the service charge, when the rules make it taxable
+}
+
+// Discount is an optional discount requested by staff, as an amount or a percentage
+type Discount struct {
+	Amount  money.Money
+	Percent float64
+}
+
+// CurrentPricingSettings reads the service charge rate from configuration
+func CurrentPricingSettings() PricingSettings {
+	settings := PricingSettings{ServiceChargeRate: 5}
+	if config.EnvConfig == nil {
+		return settings
+	}
+	if rate, err := strconv.ParseFloat(config.EnvConfig.OrderServiceChargeRate, 64); err == nil && rate >= 0 {
+		settings.ServiceChargeRate = rate
+	}
+	return settings
+}
+
+// PriceOrder looks up the current price and availability of every menu item,
+// variant, modifier option and combo and computes line totals, discount, tax, service charge and grand total.
+// A combo becomes one line per component item, priced with its share of the combo price.
+// Items must be served at this time by the menu schedules, whose price discounts apply
+// to standalone items only.
+// The discount is spread over the lines in proportion to their totals and each
+// line is taxed by its most specific tax rule. Service charge is calculated on
+// the subtotal after discount and only applies to dine-in orders.
+// Amounts are exact; percentages are rounded to the restaurant currency's
+// precision, and tax per line or per invoice as configured by TAX_ROUNDING.
+func PriceOrder(tx *gorm.DB, branchID uint, orderType models.OrderType, items []dto.OrderItemInput, combos []dto.ComboInput, discount Discount) (*PricedOrder, error) {
+	if discount.Amount.IsPositive() && discount.Percent > 0 {
+		return nil, ErrInvalidDiscount
+	}
+	if len(items) == 0 && len(combos) == 0 {
+		return nil, ErrEmptyOrder
+	}
+
+	combosByID, err := loadCombos(tx, branchID, combos)
+	if err != nil {
+		return nil, err
+	}
+	comboChoices := make([][]comboChoice, 0, len(combos))
+	for _, input := range combos {
+		combo, ok := combosByID[input.ComboID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrComboNotFound, input.ComboID)
+		}
+		if !combo.Available {
+			return nil, fmt.Errorf("%w: %s", ErrComboUnavailable, combo.Name)
+		}
+		choices, err := fillSlots(&combo, input.Selections)
+		if err != nil {
+			return nil, err
+		}
+		comboChoices = append(comboChoices, choices)
+	}
+
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.MenuItemID)
+	}
+	for _, choices := range comboChoices {
+		for _, choice := range choices {
+			ids = append(ids, choice.option.MenuItemID)
+		}
+	}
+	var menuItems []models.MenuItem
+	if err := PreloadChoices(tx).Where("branch_id = ? AND id IN ?", branchID, ids).Find(&menuItems).Error; err != nil {
+		return nil, fmt.Errorf("error fetching menu items: %w", err)
+	}
+	byID := make(map[uint]models.MenuItem, len(menuItems))
+	for _, m := range menuItems {
+		byID[m.ID] = m
+	}
+
+	schedules, err := schedule.LoadRules(tx, branchID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	priced := &PricedOrder{Lines: make([]PricedLine, 0, len(items))}
+	for _, item := range items {
+		menuItem, ok := byID[item.MenuItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrMenuItemNotFound, item.MenuItemID)
+		}
+		if !menuItem.Available {
+			return nil, fmt.Errorf("%w: %s", ErrMenuItemUnavailable, menuItem.Name)
+		}
+		if err := schedules.Check(&menuItem, now); err != nil {
+			return nil, err
+		}
+		selection, err := ResolveSelection(&menuItem, item.VariantID, item.ModifierOptionIDs)
+		if err != nil {
+			return nil, err
+		}
+		selection.ApplySchedule(schedules, &menuItem, now)
+
+		line := PricedLine{
+			MenuItem:   menuItem,
+			Quantity:   item.Quantity,
+			UnitPrice:  selection.UnitPrice,
+			TotalPrice: selection.UnitPrice.Mul(item.Quantity),
+			Notes:      item.Notes,
+			Selection:  selection,
+		}
+		priced.Lines = append(priced.Lines, line)
+		priced.Subtotal = priced.Subtotal.Add(line.TotalPrice)
+		if menuItem.PrepTime > priced.EstimatedTime {
+			priced.EstimatedTime = menuItem.PrepTime
+		}
+	}
+	for i := range combos {
+		combo := combosByID[combos[i].ComboID]
+		if err := priceCombo(priced, &combo, &combos[i], comboChoices[i], byID, schedules, now); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := tax.LoadRules(tx, branchID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case discount.Percent > 0:
+		priced.DiscountAmount = rules.Currency.Round(priced.Subtotal.Percent(discount.Percent))
+	case discount.Amount.IsPositive():
+		priced.DiscountAmount = discount.Amount
+	}
+	priced.DiscountAmount = money.Min(priced.DiscountAmount, priced.Subtotal)
+
+	weights := make([]money.Money, len(priced.Lines))
+	for i, line := range priced.Lines {
+		weights[i] = line.TotalPrice
+	}
+	discounts := priced.DiscountAmount.Allocate(weights)
+	taxGroups := make([][]tax.Line, 0, len(priced.Lines)+1)
+	for i := range priced.Lines {
+		line := &priced.Lines[i]
+		line.Taxes = rules.Apply(rules.For(&line.MenuItem), line.TotalPrice.Sub(discounts[i]), orderType)
+		taxGroups = append(taxGroups, line.Taxes)
+	}
+
+	settings := CurrentPricingSettings()
+	net := priced.Subtotal.Sub(priced.DiscountAmount)
+	if orderType == models.OrderTypeDineIn {
+		priced.ServiceCharge = rules.Currency.Round(net.Percent(settings.ServiceChargeRate))
+		priced.ServiceChargeTaxes = rules.Apply(tax.Exclusive(rules.ServiceChargeRule()), priced.ServiceCharge, orderType)
+		taxGroups = append(taxGroups, priced.ServiceChargeTaxes)
+	}
+	rules.Finalize(taxGroups...)
+
+	var allTaxes []tax.Line
+	for _, group := range taxGroups {
+		allTaxes = append(allTaxes, group...)
+	}
+	priced.TaxAmount, priced.IncludedTaxAmount = tax.Sum(allTaxes)
+	priced.Total = net.Add(priced.TaxAmount).Add(priced.ServiceCharge)
+
+	return priced, nil
+}
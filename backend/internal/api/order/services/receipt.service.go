@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+)
+
+// Receipt gathers everything printed on an order receipt
+type Receipt struct {
+	Order              *models.Order
+	Branch             models.Branch // Restaurant preloaded for its name and tax number
+	TableNumber        string
+	Lines              []models.OrderItem    // Non-cancelled items with their taxes
+	ServiceChargeTaxes []models.OrderTaxLine // Tax lines not tied to an item
+	TaxSummary         []models.OrderTaxLine // Components totalled by name, rate and inclusiveness
+	Payments           []models.Payment
+}
+
+// GetReceipt builds the receipt of an order, with the tax breakdown per line and per component
+func GetReceipt(orderID uint, claims *common_dto.Claims) (*Receipt, error) {
+	order, err := GetOrder(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &Receipt{Order: order}
+	if err := models.DataBase.Preload("Restaurant").First(&receipt.Branch, order.BranchID).Error; err != nil {
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	if order.TableID != nil {
+		var table models.Table
+		if err := models.DataBase.Select("id", "number").First(&table, *order.TableID).Error; err == nil {
+			receipt.TableNumber = table.Number
+		}
+	}
+	if err := models.DataBase.Where("order_id = ? AND order_item_id IS NULL", order.ID).Order("id ASC").Find(&receipt.ServiceChargeTaxes).Error; err != nil {
+		return nil, fmt.Errorf("error fetching service charge taxes: %w", err)
+	}
+	if err := models.DataBase.Where("order_id = ?", order.ID).Order("created_at ASC, id ASC").Find(&receipt.Payments).Error; err != nil {
+		return nil, fmt.Errorf("error fetching payments: %w", err)
+	}
+
+	index := map[string]int{}
+	summarise := func(lines []models.OrderTaxLine) {
+		for _, l := range lines {
+			key := fmt.Sprintf("%s|%.3f|%t", l.Name, l.Rate, l.IsInclusive)
+			i, ok := index[key]
+			if !ok {
+				i = len(receipt.TaxSummary)
+				index[key] = i
+				receipt.TaxSummary = append(receipt.TaxSummary, models.OrderTaxLine{Name: l.Name, Rate: l.Rate, IsInclusive: l.IsInclusive})
+			}
+			receipt.TaxSummary[i].TaxableAmount = receipt.TaxSummary[i].TaxableAmount.Add(l.TaxableAmount)
+			receipt.TaxSummary[i].Amount = receipt.TaxSummary[i].Amount.Add(l.Amount)
+		}
+	}
+	for _, item := range order.OrderItems {
+		if item.Status == models.OrderItemCancelled {
+			continue
+		}
+		receipt.Lines = append(receipt.Lines, item)
+		summarise(item.TaxLines)
+	}
+	summarise(receipt.ServiceChargeTaxes)
+
+	return receipt, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/schedule"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVariantRequired  = errors.New("a variant must be chosen for this menu item")
+	ErrInvalidVariant   = errors.New("variant is not available for this menu item")
+	ErrInvalidModifiers = errors.New("invalid modifier selection")
+)
+
+// Selection is the variant and modifier options chosen for one line, priced from the menu
+type Selection struct {
+	Variant   *models.MenuItemVariant
+	Modifiers []SelectedModifier
+	BasePrice money.Money // Variant or item price
+	UnitPrice money.Money // Base price plus the modifier price deltas
+}
+
+// SelectedModifier is a chosen modifier option with the name of its group
+type SelectedModifier struct {
+	OptionID   uint
+	GroupName  string
+	Name       string
+	PriceDelta money.Money
+}
+
+// OptionIDs returns the IDs of the chosen modifier options
+func (s *Selection) OptionIDs() []uint {
+	ids := make([]uint, 0, len(s.Modifiers))
+	for _, m := range s.Modifiers {
+		ids = append(ids, m.OptionID)
+	}
+	return ids
+}
+
+// PreloadChoices loads the variants and modifier groups ResolveSelection needs, in display order
+func PreloadChoices(db *gorm.DB) *gorm.DB {
+	return db.Preload("Variants", bySortOrder).
+		Preload("ModifierGroups", bySortOrder).
+		Preload("ModifierGroups.Options", bySortOrder)
+}
+
+// ResolveSelection checks a variant and modifier choice against a menu item loaded
+// with PreloadChoices and computes the unit price. Items with variants fall back to
+// their default variant; every modifier group's selection limits must be met.
+func ResolveSelection(item *models.MenuItem, variantID *uint, optionIDs []uint) (*Selection, error) {
+	selection := &Selection{BasePrice: item.Price}
+
+	variant, err := pickVariant(item, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if variant != nil {
+		selection.Variant = variant
+		selection.BasePrice = variant.Price
+	}
+	selection.UnitPrice = selection.BasePrice
+
+	chosen := make(map[uint]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if chosen[id] {
+			return nil, fmt.Errorf("%w: option %d is chosen twice", ErrInvalidModifiers, id)
+		}
+		chosen[id] = true
+	}
+
+	matched := 0
+	for _, group := range item.ModifierGroups {
+		count := 0
+		for _, option := range group.Options {
+			if !chosen[option.ID] {
+				continue
+			}
+			if !option.Available {
+				return nil, fmt.Errorf("%w: %s is currently unavailable", ErrInvalidModifiers, option.Name)
+			}
+			count++
+			selection.Modifiers = append(selection.Modifiers, SelectedModifier{
+				OptionID:   option.ID,
+				GroupName:  group.Name,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+			selection.UnitPrice = selection.UnitPrice.Add(option.PriceDelta)
+		}
+		matched += count
+
+		minimum := group.MinSelections
+		if group.IsRequired && minimum < 1 {
+			minimum = 1
+		}
+		if count < minimum {
+			return nil, fmt.Errorf("%w: choose at least %d from %s for %s", ErrInvalidModifiers, minimum, group.Name, item.Name)
+		}
+		if group.MaxSelections > 0 && count > group.MaxSelections {
+			return nil, fmt.Errorf("%w: choose at most %d from %s for %s", ErrInvalidModifiers, group.MaxSelections, group.Name, item.Name)
+		}
+	}
+	if matched != len(chosen) {
+		return nil, fmt.Errorf("%w: some options do not belong to %s", ErrInvalidModifiers, item.Name)
+	}
+	if selection.UnitPrice.IsNegative() {
+		return nil, fmt.Errorf("%w: price of %s cannot be negative", ErrInvalidModifiers, item.Name)
+	}
+	return selection, nil
+}
+
+// ApplySchedule applies the item's scheduled discount, e.g. happy hour, to the
+// variant or item price; modifier price deltas are not discounted
+func (s *Selection) ApplySchedule(rules *schedule.RuleSet, item *models.MenuItem, at time.Time) {
+	scheduled := rules.Price(item, s.BasePrice, at)
+	s.UnitPrice = money.Max(s.UnitPrice.Sub(s.BasePrice).Add(scheduled), money.Zero)
+	s.BasePrice = scheduled
+}
+
+func pickVariant(item *models.MenuItem, variantID *uint) (*models.MenuItemVariant, error) {
+	if len(item.Variants) == 0 {
+		if variantID != nil {
+			return nil, fmt.Errorf("%w: %s has no variants", ErrInvalidVariant, item.Name)
+		}
+		return nil, nil
+	}
+	for i := range item.Variants {
+		variant := &item.Variants[i]
+		if (variantID == nil && variant.IsDefault) || (variantID != nil && variant.ID == *variantID) {
+			if !variant.Available {
+				return nil, fmt.Errorf("%w: %s %s is sold out", ErrInvalidVariant, variant.Name, item.Name)
+			}
+			return variant, nil
+		}
+	}
+	if variantID == nil {
+		return nil, fmt.Errorf("%w: %s", ErrVariantRequired, item.Name)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrInvalidVariant, item.Name)
+}
+
+func bySortOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	customer_services "restaurant_os/internal/api/customer/services"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/realtime"
+	"restaurant_os/internal/stock"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrIllegalTransition   = errors.New("illegal status transition")
+	ErrTransitionForbidden = errors.New("your role cannot set this status")
+	ErrOrderItemNotFound   = errors.New("order item not found")
+)
+
+// supervisorRoles may perform every transition
+var supervisorRoles = []string{"SUPER_ADMIN", "RESTAURANT", string(models.RoleManager)}
+
+// orderStatusRoles lists who, besides supervisors, may move an order into a status.
+// REFUNDED is missing on purpose: only the refund flow sets it, with its payments and audit trail.
+var orderStatusRoles = map[models.OrderStatus][]string{
+	models.OrderConfirmed: {string(models.RoleWaiter), string(models.RoleCashier), string(models.RoleHost), string(models.RoleChef), string(models.RoleKitchen)},
+	models.OrderPreparing: {string(models.RoleChef), string(models.RoleKitchen)},
+	models.OrderReady:     {string(models.RoleChef), string(models.RoleKitchen)},
+	models.OrderServed:    {string(models.RoleWaiter)},
+	models.OrderCompleted: {string(models.RoleCashier)},
+	models.OrderCancelled: {string(models.RoleCashier)},
+}
+
+// orderItemStatusRoles lists who, besides supervisors, may move an order item into a status
+var orderItemStatusRoles = map[models.OrderItemStatus][]string{
+	models.OrderItemPreparing: {string(models.RoleChef), string(models.RoleKitchen)},
+	models.OrderItemReady:     {string(models.RoleChef), string(models.RoleKitchen)},
+	models.OrderItemServed:    {string(models.RoleWaiter)},
+	models.OrderItemCancelled: {string(models.RoleCashier)},
+}
+
+// itemStatusFor is the status an order's items are brought up to when the order
+// itself moves forward; completing an order serves whatever was not served yet
+var itemStatusFor = map[models.OrderStatus]models.OrderItemStatus{
+	models.OrderPreparing: models.OrderItemPreparing,
+	models.OrderReady:     models.OrderItemReady,
+	models.OrderServed:    models.OrderItemServed,
+	models.OrderCompleted: models.OrderItemServed,
+}
+
+// itemFlow is the forward path of an order item after PENDING, one step at a time
+var itemFlow = []models.OrderItemStatus{models.OrderItemPreparing, models.OrderItemReady, models.OrderItemServed}
+
+// UpdateOrderStatus moves an order to a new status after checking the state machine and the requester's role.
+// Cancelling an order cancels all of its items that have not been served; moving it forward brings
+// its items along, so they are fired (and their recipes deducted) like items moved one by one.
+func UpdateOrderStatus(orderID uint, next models.OrderStatus, reason string, claims *common_dto.Claims) (*models.Order, error) {
+	roles, ok := orderStatusRoles[next]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s cannot be set directly", ErrIllegalTransition, next)
+	}
+	if !hasRole(claims, roles) {
+		return nil, ErrTransitionForbidden
+	}
+	current, err := GetOrder(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		order, err := LockOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if !order.Status.CanTransitionTo(next) {
+			return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, order.Status, next)
+		}
+		if err := SetOrderStatus(tx, order, next, &claims.UserID, reason); err != nil {
+			return err
+		}
+
+		if target, ok := itemStatusFor[next]; ok {
+			if err := advanceItems(tx, order, target, &claims.UserID, reason); err != nil {
+				return err
+			}
+		}
+		if next == models.OrderCancelled {
+			for i := range order.OrderItems {
+				item := &order.OrderItems[i]
+				if !item.Status.CanTransitionTo(models.OrderItemCancelled) {
+					continue
+				}
+				if err := SetOrderItemStatus(tx, item, models.OrderItemCancelled, &claims.UserID, "order cancelled"); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	realtime.Publish(realtime.Event{Type: realtime.EventOrderUpdated, BranchID: current.BranchID, OrderID: orderID})
+	return GetOrderByID(orderID)
+}
+
+// UpdateOrderItemStatus moves a single order item to a new status and then
+// re-derives the order status from all of its items
+func UpdateOrderItemStatus(orderID, itemID uint, next models.OrderItemStatus, reason string, claims *common_dto.Claims) (*models.Order, error) {
+	if !hasRole(claims, orderItemStatusRoles[next]) {
+		return nil, ErrTransitionForbidden
+	}
+	current, err := GetOrder(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		order, err := LockOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Status.IsFinal() {
+			return fmt.Errorf("%w: order is %s", ErrIllegalTransition, order.Status)
+		}
+
+		var item *models.OrderItem
+		for i := range order.OrderItems {
+			if order.OrderItems[i].ID == itemID {
+				item = &order.OrderItems[i]
+				break
+			}
+		}
+		if item == nil {
+			return ErrOrderItemNotFound
+		}
+		if !item.Status.CanTransitionTo(next) {
+			return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, item.Status, next)
+		}
+		if err := SetOrderItemStatus(tx, item, next, &claims.UserID, reason); err != nil {
+			return err
+		}
+
+		return SyncOrderStatus(tx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	realtime.Publish(realtime.Event{Type: realtime.EventOrderItemUpdated, BranchID: current.BranchID, OrderID: orderID, OrderItemID: itemID})
+	return GetOrderByID(orderID)
+}
+
+// advanceItems steps every item of an order that is behind target through each
+// status up to it; cancelled items and items already past target are left alone
+func advanceItems(tx *gorm.DB, order *models.Order, target models.OrderItemStatus, changedBy *uint, reason string) error {
+	if reason == "" {
+		reason = "order moved to " + string(order.Status)
+	}
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		for _, step := range itemFlow {
+			if item.Status.CanTransitionTo(step) {
+				if err := SetOrderItemStatus(tx, item, step, changedBy, reason); err != nil {
+					return err
+				}
+			}
+			if step == target {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// SyncOrderStatus updates the order status to the one derived from its items.
+// Derived changes only move an order forward (or to CANCELLED when every
+// item is cancelled) and are recorded without a user.
+func SyncOrderStatus(tx *gorm.DB, order *models.Order) error {
+	derived, ok := models.DeriveOrderStatus(order.OrderItems)
+	if !ok || derived == order.Status || order.Status.IsFinal() {
+		return nil
+	}
+	if derived != models.OrderCancelled && !derived.IsAheadOf(order.Status) {
+		return nil
+	}
+	return SetOrderStatus(tx, order, derived, nil, "derived from item statuses")
+}
+
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func GetOrderStatusHistory(orderID uint, claims *common_dto.Claims) ([]models.OrderStatusHistory, error) {
+	if _, err := GetOrder(orderID, claims); err != nil {
+		return nil, err
+	}
+	var history []models.OrderStatusHistory
+	err := models.DataBase.Preload("ChangedByUser").
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching status history: %w", err)
+	}
+	return history, nil
+}
+
+// LockOrder loads an order with its items, locking the order row for the rest of the transaction
+func LockOrder(tx *gorm.DB, orderID uint) (*models.Order, error) {
+	var order models.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error fetching order: %w", err)
+	}
+	if err := tx.Where("order_id = ?", orderID).Order("id ASC").Find(&order.OrderItems).Error; err != nil {
+		return nil, fmt.Errorf("error fetching order items: %w", err)
+	}
+	return &order, nil
+}
+
+// SetOrderStatus stores a new order status and records it in the status history.
+// Completing an order adds it to its customer's aggregates; refunding a
+// completed order in full takes it off again.
+func SetOrderStatus(tx *gorm.DB, order *models.Order, next models.OrderStatus, changedBy *uint, reason string) error {
+	// Update writes the new status into order, so keep the one it is leaving
+	previous := order.Status
+	history := &models.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: string(order.Status),
+		ToStatus:   string(next),
+		ChangedBy:  changedBy,
+		Reason:     reason,
+	}
+	if err := tx.Model(order).Update("status", next).Error; err != nil {
+		return fmt.Errorf("error updating order status: %w", err)
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return fmt.Errorf("error recording status history: %w", err)
+	}
+	order.Status = next
+
+	switch {
+	case next == models.OrderCompleted:
+		return customer_services.CreditOrder(tx, order)
+	case previous == models.OrderCompleted && next == models.OrderRefunded:
+		return customer_services.ReverseOrder(tx, order)
+	}
+	return nil
+}
+
+// SetOrderItemStatus stores a new order item status and records it in the status history.
+// Reaching the configured status (fired by default) deducts the item's recipe from stock.
+func SetOrderItemStatus(tx *gorm.DB, item *models.OrderItem, next models.OrderItemStatus, changedBy *uint, reason string) error {
+	history := &models.OrderStatusHistory{
+		OrderID:     item.OrderID,
+		OrderItemID: &item.ID,
+		FromStatus:  string(item.Status),
+		ToStatus:    string(next),
+		ChangedBy:   changedBy,
+		Reason:      reason,
+	}
+	if err := tx.Model(item).Update("status", next).Error; err != nil {
+		return fmt.Errorf("error updating order item status: %w", err)
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return fmt.Errorf("error recording status history: %w", err)
+	}
+	item.Status = next
+	if next == stock.DeductOn() {
+		return stock.DeductOrderItem(tx, item, changedBy)
+	}
+	return nil
+}
+
+// IsSupervisor reports whether the requester is a manager or above
+func IsSupervisor(claims *common_dto.Claims) bool {
+	return hasRole(claims, nil)
+}
+
+// hasRole reports whether the requester is a supervisor or holds one of the given roles
+func hasRole(claims *common_dto.Claims, roles []string) bool {
+	for _, group := range [][]string{supervisorRoles, roles} {
+		for _, role := range group {
+			if claims.Role == role || claims.UserType == role {
+				return true
+			}
+		}
+	}
+	return false
+}
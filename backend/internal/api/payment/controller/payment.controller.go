@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	order_services "restaurant_os/internal/api/order/services"
+	payment_dto "restaurant_os/internal/api/payment/dto"
+	payment_services "restaurant_os/internal/api/payment/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type paymentController struct{}
+
+var validate = dto.NewValidator()
+
+func NewPaymentController() *paymentController {
+	return &paymentController{}
+}
+
+// CreatePayment records one or more tenders (split tender) against an order
+func (pc *paymentController) CreatePayment(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req payment_dto.CreatePaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, payment_dto.CreatePaymentValidationErrorMessages)
+	}
+
+	result, err := payment_services.RecordPayment(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return paymentErrorResponse(c, err, "Failed to record payment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Payment recorded successfully",
+		Data:    toPaymentSummaryResponse(result),
+	})
+}
+
+func (pc *paymentController) GetPayments(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	result, err := payment_services.GetPayments(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return paymentErrorResponse(c, err, "Failed to fetch payments")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Payments fetched successfully",
+		Data:    toPaymentSummaryResponse(result),
+	})
+}
+
+// CreateRefund returns money on a paid order, in full or for selected lines
+func (pc *paymentController) CreateRefund(c *fiber.Ctx) error {
+	return pc.createRefund(c, models.RefundTypeRefund)
+}
+
+// CreateVoid removes selected lines, or the whole order, before payment
+func (pc *paymentController) CreateVoid(c *fiber.Ctx) error {
+	return pc.createRefund(c, models.RefundTypeVoid)
+}
+
+func (pc *paymentController) createRefund(c *fiber.Ctx, kind models.RefundType) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req payment_dto.RefundRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, payment_dto.RefundValidationErrorMessages)
+	}
+
+	refund, err := payment_services.CreateRefund(uint(id), kind, &req, middleware.GetClaims(c), c.IP())
+	if err != nil {
+		if kind == models.RefundTypeVoid {
+			return paymentErrorResponse(c, err, "Failed to void order")
+		}
+		return paymentErrorResponse(c, err, "Failed to refund order")
+	}
+
+	message := "Order refunded successfully"
+	if kind == models.RefundTypeVoid {
+		message = "Order voided successfully"
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    toRefundResponse(refund),
+	})
+}
+
+// GetRefunds lists the refunds and voids of an order
+func (pc *paymentController) GetRefunds(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	refunds, err := payment_services.GetRefunds(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return paymentErrorResponse(c, err, "Failed to fetch refunds")
+	}
+
+	data := make([]payment_dto.RefundResponse, 0, len(refunds))
+	for i := range refunds {
+		data = append(data, toRefundResponse(&refunds[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Refunds fetched successfully",
+		Data:    data,
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// paymentErrorResponse maps payment service errors to HTTP status codes
+func paymentErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, order_services.ErrOrderNotFound), errors.Is(err, order_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, payment_services.ErrApprovalRequired), errors.Is(err, payment_services.ErrInvalidManagerPIN):
+		status = fiber.StatusForbidden
+	case errors.Is(err, payment_services.ErrOrderNotPayable), errors.Is(err, payment_services.ErrOrderAlreadyPaid),
+		errors.Is(err, payment_services.ErrVoidAfterPayment), errors.Is(err, payment_services.ErrNothingToRefund),
+		errors.Is(err, order_services.ErrIllegalTransition):
+		status = fiber.StatusConflict
+	case errors.Is(err, payment_services.ErrOverpayment), errors.Is(err, payment_services.ErrRefundTooLarge),
+		errors.Is(err, payment_services.ErrInvalidRefundItem):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toPaymentSummaryResponse(result *payment_services.PaymentResult) payment_dto.PaymentSummaryResponse {
+	order, payments := result.Order, result.Payments
+	paid := payment_services.AmountPaid(payments)
+	balance := payment_services.BalanceDue(order.Total, paid)
+	return payment_dto.PaymentSummaryResponse{
+		OrderID:        order.ID,
+		OrderNumber:    order.OrderNumber,
+		OrderTotal:     order.Total,
+		AmountPaid:     paid,
+		AmountRefunded: payment_services.AmountRefunded(payments),
+		BalanceDue:     balance,
+		CashDue:        result.Currency.RoundCash(balance),
+		CashRounding:   payment_services.CashRounding(payments),
+		Currency:       result.Currency.Code,
+		PaymentStatus:  string(order.PaymentStatus),
+		ChangeDue:      result.ChangeDue,
+		Payments:       toPaymentResponses(payments),
+	}
+}
+
+func toPaymentResponses(payments []models.Payment) []payment_dto.PaymentResponse {
+	data := make([]payment_dto.PaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		data = append(data, payment_dto.PaymentResponse{
+			ID:             p.ID,
+			Method:         string(p.Method),
+			Amount:         p.Amount,
+			TenderedAmount: p.TenderedAmount,
+			ChangeDue:      p.ChangeDue,
+			CashRounding:   p.CashRounding,
+			Status:         string(p.Status),
+			TransactionID:  p.TransactionID,
+			Reference:      p.Reference,
+			ProcessedBy:    p.ProcessedBy,
+			OriginalID:     p.OriginalPaymentID,
+			RefundID:       p.RefundID,
+			CreatedAt:      p.CreatedAt,
+		})
+	}
+	return data
+}
+
+func toRefundResponse(refund *models.Refund) payment_dto.RefundResponse {
+	items := make([]payment_dto.RefundItemResponse, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		items = append(items, payment_dto.RefundItemResponse{
+			OrderItemID: item.OrderItemID,
+			Name:        item.OrderItem.MenuItem.Name,
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+			Restocked:   item.Restocked,
+		})
+	}
+
+	return payment_dto.RefundResponse{
+		ID:             refund.ID,
+		OrderID:        refund.OrderID,
+		Type:           string(refund.Type),
+		ReasonCode:     string(refund.ReasonCode),
+		Notes:          refund.Notes,
+		Amount:         refund.Amount,
+		IsFull:         refund.IsFull,
+		Restock:        refund.Restock,
+		RequestedBy:    refund.RequestedBy,
+		ApprovedBy:     refund.ApprovedBy,
+		ApprovedByName: refund.ApprovedUser.Name,
+		ApprovalMethod: string(refund.ApprovalMethod),
+		Items:          items,
+		Payments:       toPaymentResponses(refund.Payments),
+		CreatedAt:      refund.CreatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// PAYMENT REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// TenderInput is one way the customer pays, e.g. part cash and part card.
+// For CASH, amount is what the customer hands over; any excess over the
+// balance is returned as change. Other methods may not exceed the balance.
+type TenderInput struct {
+	Method        string      `json:"method" validate:"required,oneof=CASH CARD UPI WALLET NET_BANKING"`
+	Amount        money.Money `json:"amount" validate:"required,gt=0"`
+	TransactionID string      `json:"transaction_id,omitempty" validate:"max=100"`
+	Reference     string      `json:"reference,omitempty" validate:"max=100"`
+}
+
+// CreatePaymentRequest records one or more tenders against an order
+type CreatePaymentRequest struct {
+	Tenders []TenderInput `json:"tenders" validate:"required,min=1,max=10,dive"`
+}
+
+// CreatePaymentValidationErrorMessages maps CreatePaymentRequest fields to custom messages
+var CreatePaymentValidationErrorMessages = map[string]string{
+	"Tenders":       "Between 1 and 10 tenders are required.",
+	"Method":        "Each tender method must be one of: CASH, CARD, UPI, WALLET, NET_BANKING.",
+	"Amount":        "Each tender amount must be greater than 0.",
+	"TransactionID": "Transaction ID must be at most 100 characters.",
+	"Reference":     "Reference must be at most 100 characters.",
+}
+
+// PaymentResponse represents a recorded payment
+type PaymentResponse struct {
+	ID             uint        `json:"id"`
+	Method         string      `json:"method"`
+	Amount         money.Money `json:"amount"`
+	TenderedAmount money.Money `json:"tendered_amount"`
+	ChangeDue      money.Money `json:"change_due"`
+	CashRounding   money.Money `json:"cash_rounding"` // Collected minus applied when cash settled a rounded balance
+	Status         string      `json:"status"`
+	TransactionID  string      `json:"transaction_id,omitempty"`
+	Reference      string      `json:"reference,omitempty"`
+	ProcessedBy    *uint       `json:"processed_by,omitempty"`
+	OriginalID     *uint       `json:"original_payment_id,omitempty"` // Payment reversed by this refund
+	RefundID       *uint       `json:"refund_id,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+// PaymentSummaryResponse represents the payment state of an order
+type PaymentSummaryResponse struct {
+	OrderID        uint              `json:"order_id"`
+	OrderNumber    string            `json:"order_number"`
+	OrderTotal     money.Money       `json:"order_total"`
+	AmountPaid     money.Money       `json:"amount_paid"`
+	AmountRefunded money.Money       `json:"amount_refunded"`
+	BalanceDue     money.Money       `json:"balance_due"`
+	CashDue        money.Money       `json:"cash_due"` // Balance rounded to the smallest coin, for settling in cash
+	CashRounding   money.Money       `json:"cash_rounding"`
+	Currency       string            `json:"currency"`
+	PaymentStatus  string            `json:"payment_status"`
+	ChangeDue      money.Money       `json:"change_due"` // Cash to return for the tenders just recorded
+	Payments       []PaymentResponse `json:"payments"`
+}
+
+// ============================================================================
+// REFUND/VOID REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// RefundItemInput selects an order line (and for refunds, a quantity of it)
+type RefundItemInput struct {
+	OrderItemID uint `json:"order_item_id" validate:"required"`
+	Quantity    int  `json:"quantity,omitempty" validate:"min=0"` // Defaults to the whole remaining line
+}
+
+// RefundRequest represents a refund or void request. Without items the whole order is refunded or voided.
+// Cashiers need a manager to enter their PIN; managers are approved by their own token.
+type RefundRequest struct {
+	ReasonCode string            `json:"reason_code" validate:"required,oneof=CUSTOMER_COMPLAINT WRONG_ITEM QUALITY_ISSUE DUPLICATE_ORDER PRICING_ERROR SERVICE_DELAY OTHER"`
+	Notes      string            `json:"notes,omitempty" validate:"required_if=ReasonCode OTHER,max=1000"`
+	Items      []RefundItemInput `json:"items,omitempty" validate:"omitempty,max=100,dive"`
+	Restock    bool              `json:"restock,omitempty"`
+	ManagerPIN string            `json:"manager_pin,omitempty" validate:"omitempty,numeric,min=4,max=8"`
+}
+
+// RefundValidationErrorMessages maps RefundRequest fields to custom messages
+var RefundValidationErrorMessages = map[string]string{
+	"ReasonCode":  "Reason code is required and must be one of: CUSTOMER_COMPLAINT, WRONG_ITEM, QUALITY_ISSUE, DUPLICATE_ORDER, PRICING_ERROR, SERVICE_DELAY, OTHER.",
+	"Notes":       "Notes are required for reason OTHER and must be at most 1000 characters.",
+	"Items":       "At most 100 items can be refunded at once.",
+	"OrderItemID": "Each item needs an order_item_id.",
+	"Quantity":    "Item quantity cannot be negative.",
+	"ManagerPIN":  "Manager PIN must be 4 to 8 digits.",
+}
+
+// RefundItemResponse represents a refunded or voided order line
+type RefundItemResponse struct {
+	OrderItemID uint        `json:"order_item_id"`
+	Name        string      `json:"name"`
+	Quantity    int         `json:"quantity"`
+	Amount      money.Money `json:"amount"`
+	Restocked   bool        `json:"restocked"`
+}
+
+// RefundResponse represents a refund or void with its approval trail
+type RefundResponse struct {
+	ID             uint                 `json:"id"`
+	OrderID        uint                 `json:"order_id"`
+	Type           string               `json:"type"`
+	ReasonCode     string               `json:"reason_code"`
+	Notes          string               `json:"notes,omitempty"`
+	Amount         money.Money          `json:"amount"`
+	IsFull         bool                 `json:"is_full"`
+	Restock        bool                 `json:"restock"`
+	RequestedBy    uint                 `json:"requested_by"`
+	ApprovedBy     uint                 `json:"approved_by"`
+	ApprovedByName string               `json:"approved_by_name,omitempty"`
+	ApprovalMethod string               `json:"approval_method"`
+	Items          []RefundItemResponse `json:"items"`
+	Payments       []PaymentResponse    `json:"payments"` // Negative payments reversing the original tenders
+	CreatedAt      time.Time            `json:"created_at"`
+}
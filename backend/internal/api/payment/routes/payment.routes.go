@@ -0,0 +1,27 @@
+package routes
+
+import (
+	payment_controller "restaurant_os/internal/api/payment/controller"
+	"restaurant_os/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPaymentRoutes(api fiber.Router) {
+
+	paymentHandler := payment_controller.NewPaymentController()
+
+	// Payments hang off orders; auth is repeated per route so these stay protected independently of the /orders group
+	api.Get("/orders/:id/payments", middleware.RequireAuth(), paymentHandler.GetPayments)
+	api.Post("/orders/:id/payments", middleware.RequireAuth(),
+		middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CASHIER", "WAITER"), paymentHandler.CreatePayment)
+
+	// Cashiers may request refunds and voids; the service requires a manager token or manager PIN.
+	// Rate limited to slow down PIN guessing.
+	refundRoles := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CASHIER")
+	pinLimit := middleware.RateLimit(20, time.Minute)
+	api.Get("/orders/:id/refunds", middleware.RequireAuth(), paymentHandler.GetRefunds)
+	api.Post("/orders/:id/refunds", pinLimit, middleware.RequireAuth(), refundRoles, paymentHandler.CreateRefund)
+	api.Post("/orders/:id/voids", pinLimit, middleware.RequireAuth(), refundRoles, paymentHandler.CreateVoid)
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	order_services "restaurant_os/internal/api/order/services"
+	"restaurant_os/internal/api/payment/dto"
+	"restaurant_os/internal/config"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOrderNotPayable  = errors.New("order cannot take payments in its current status")
+	ErrOrderAlreadyPaid = errors.New("order is already fully paid")
+	ErrOverpayment      = errors.New("payment exceeds the balance due")
+)
+
+// PaymentResult is the outcome of recording tenders against an order
+type PaymentResult struct {
+	Order     *models.Order
+	Payments  []models.Payment // All payments of the order, oldest first
+	ChangeDue money.Money      // Cash to return for the tenders just recorded
+	Currency  money.Currency   // Currency of the restaurant, with its cash rounding step
+}
+
+// RecordPayment applies the tenders to the order balance in the given order.
+// The order row is locked so concurrent payments cannot overpay it.
+// A cash tender that settles the balance is compared with the balance rounded to
+// the smallest coin; the difference is recorded on the payment as cash rounding.
+// Cash is never applied beyond the balance, whatever is over is change.
+func RecordPayment(orderID uint, req *dto.CreatePaymentRequest, claims *common_dto.Claims) (*PaymentResult, error) {
+	if _, err := order_services.GetOrder(orderID, claims); err != nil {
+		return nil, err
+	}
+
+	var change money.Money
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return order_services.ErrOrderNotFound
+			}
+			return fmt.Errorf("error fetching order: %w", err)
+		}
+		if order.Status == models.OrderCancelled || order.Status == models.OrderRefunded {
+			return fmt.Errorf("%w: order is %s", ErrOrderNotPayable, order.Status)
+		}
+		currency, err := branchCurrency(tx, order.BranchID)
+		if err != nil {
+			return err
+		}
+
+		paid, err := paidAmount(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if !order.Total.GreaterThan(paid) {
+			return ErrOrderAlreadyPaid
+		}
+
+		for _, tender := range req.Tenders {
+			remaining := order.Total.Sub(paid)
+			if !remaining.IsPositive() {
+				return fmt.Errorf("%w: order is covered before the %s tender", ErrOverpayment, tender.Method)
+			}
+
+			method := models.PaymentMethod(tender.Method)
+			tendered := tender.Amount
+			applied, rounding := tendered, money.Zero
+			switch {
+			case method == models.PaymentCash:
+				if cashDue := currency.RoundCash(remaining); !tendered.LessThan(cashDue) {
+					applied, rounding = remaining, cashDue.Sub(remaining)
+				} else if tendered.GreaterThan(remaining) {
+					// Short of the rounded amount but over the exact balance: settle it exactly
+					applied = remaining
+				}
+			case tendered.GreaterThan(remaining):
+				return fmt.Errorf("%w: %s tender of %s exceeds balance of %s", ErrOverpayment, method, tendered, remaining)
+			}
+
+			payment := &models.Payment{
+				OrderID:       order.ID,
+				Amount:        applied,
+				Method:        method,
+				Status:        models.PaymentPaid,
+				TransactionID: tender.TransactionID,
+				Reference:     tender.Reference,
+				ProcessedBy:   &claims.UserID,
+			}
+			if method == models.PaymentCash {
+				payment.TenderedAmount = tendered
+				payment.ChangeDue = tendered.Sub(applied).Sub(rounding)
+				payment.CashRounding = rounding
+			}
+			if err := tx.Create(payment).Error; err != nil {
+				return fmt.Errorf("error recording payment: %w", err)
+			}
+			paid = paid.Add(applied)
+			change = change.Add(payment.ChangeDue)
+		}
+
+		status := models.PaymentPartial
+		if !order.Total.GreaterThan(paid) {
+			status = models.PaymentPaid
+		}
+		if err := tx.Model(&order).Update("payment_status", status).Error; err != nil {
+			return fmt.Errorf("error updating payment status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := GetPayments(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+	result.ChangeDue = change
+	return result, nil
+}
+
+// GetPayments returns an order and its payments, oldest first
+func GetPayments(orderID uint, claims *common_dto.Claims) (*PaymentResult, error) {
+	order, err := order_services.GetOrder(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+	var payments []models.Payment
+	if err := models.DataBase.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("error fetching payments: %w", err)
+	}
+	currency, err := branchCurrency(models.DataBase, order.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentResult{Order: order, Payments: payments, Currency: currency}, nil
+}
+
+// AmountPaid sums the settled payments of an order
+func AmountPaid(payments []models.Payment) money.Money {
+	var paid money.Money
+	for _, p := range payments {
+		if p.Status == models.PaymentPaid {
+			paid = paid.Add(p.Amount)
+		}
+	}
+	return paid
+}
+
+// AmountRefunded sums the refund payments of an order as a positive amount
+func AmountRefunded(payments []models.Payment) money.Money {
+	var refunded money.Money
+	for _, p := range payments {
+		if p.Status == models.PaymentRefunded {
+			refunded = refunded.Sub(p.Amount)
+		}
+	}
+	return refunded
+}
+
+// CashRounding sums the cash rounding of the payments of an order
+func CashRounding(payments []models.Payment) money.Money {
+	var rounding money.Money
+	for _, p := range payments {
+		rounding = rounding.Add(p.CashRounding)
+	}
+	return rounding
+}
+
+// BalanceDue returns what is still owed on an order, never negative
+func BalanceDue(total, paid money.Money) money.Money {
+	return money.Max(total.Sub(paid), money.Zero)
+}
+
+func paidAmount(tx *gorm.DB, orderID uint) (money.Money, error) {
+	var payments []models.Payment
+	if err := tx.Where("order_id = ? AND status = ?", orderID, models.PaymentPaid).Find(&payments).Error; err != nil {
+		return money.Zero, fmt.Errorf("error fetching payments: %w", err)
+	}
+	return AmountPaid(payments), nil
+}
+
+// branchCurrency returns the currency of the branch's restaurant, with the cash
+// rounding step overridden by CASH_ROUNDING when set
+func branchCurrency(tx *gorm.DB, branchID uint) (money.Currency, error) {
+	var branch models.Branch
+	if err := tx.Preload("Restaurant").First(&branch, branchID).Error; err != nil {
+		return money.Currency{}, fmt.Errorf("error fetching branch: %w", err)
+	}
+	currency := money.CurrencyOf(branch.Restaurant.Currency)
+	if config.EnvConfig != nil && config.EnvConfig.CashRounding != "" {
+		if step, err := money.Parse(config.EnvConfig.CashRounding); err == nil {
+			currency = currency.WithCashStep(step)
+		}
+	}
+	return currency, nil
+}
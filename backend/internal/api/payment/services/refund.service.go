@@ -0,0 +1,563 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	customer_services "restaurant_os/internal/api/customer/services"
+	order_services "restaurant_os/internal/api/order/services"
+	"restaurant_os/internal/api/payment/dto"
+	"restaurant_os/internal/audit"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/password"
+	"restaurant_os/internal/realtime"
+	"restaurant_os/internal/stock"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrApprovalRequired  = errors.New("manager approval is required, a manager must enter their PIN")
+	ErrInvalidManagerPIN = errors.New("manager PIN is not valid for this branch")
+	ErrVoidAfterPayment  = errors.New("order has payments, refund it instead of voiding")
+	ErrNothingToRefund   = errors.New("nothing left to refund on this order")
+	ErrRefundTooLarge    = errors.New("refund exceeds the amount paid")
+	ErrInvalidRefundItem = errors.New("invalid refund item")
+)
+
+// refundLine is an order line selected for a refund or void with its share of the bill
+type refundLine struct {
+	item     *models.OrderItem
+	quantity int
+	share    lineShare
+}
+
+// lineShare splits a line's part of the order total into the order's components.
+// included is the tax already inside gross and is not added to the total.
+type lineShare struct {
+	gross, discount, tax, included, service money.Money
+}
+
+func (s lineShare) total() money.Money {
+	return s.gross.Sub(s.discount).Add(s.tax).Add(s.service)
+}
+
+func (s lineShare) add(o lineShare) lineShare {
+	return lineShare{
+		gross:    s.gross.Add(o.gross),
+		discount: s.discount.Add(o.discount),
+		tax:      s.tax.Add(o.tax),
+		included: s.included.Add(o.included),
+		service:  s.service.Add(o.service),
+	}
+}
+
+// CreateRefund refunds (paid orders) or voids (unpaid orders) a whole order or selected lines.
+// The requester must be a manager, or a manager of the branch must approve with their PIN.
+// Refunds create negative payments against the original tenders; everything is audited.
+func CreateRefund(orderID uint, kind models.RefundType, req *dto.RefundRequest, claims *common_dto.Claims, ipAddress string) (*models.Refund, error) {
+	current, err := order_services.GetOrder(orderID, claims)
+	if err != nil {
+		return nil, err
+	}
+	approverID, method, err := approveRefund(current, claims, req.ManagerPIN, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var refund *models.Refund
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		order, err := order_services.LockOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		lines, err := selectRefundLines(tx, order, kind, req.Items)
+		if err != nil {
+			return err
+		}
+
+		refund = &models.Refund{
+			OrderID:        order.ID,
+			BranchID:       order.BranchID,
+			Type:           kind,
+			ReasonCode:     models.RefundReason(req.ReasonCode),
+			Notes:          req.Notes,
+			IsFull:         len(req.Items) == 0,
+			Restock:        req.Restock,
+			RequestedBy:    claims.UserID,
+			ApprovedBy:     approverID,
+			ApprovalMethod: method,
+		}
+		for _, line := range lines {
+			refund.Items = append(refund.Items, models.RefundItem{
+				OrderItemID: line.item.ID,
+				Quantity:    line.quantity,
+				Amount:      line.share.total(),
+				// Only items whose recipe was taken out of stock can go back
+				Restocked: req.Restock && line.item.StockDeductedAt != nil,
+			})
+		}
+
+		if kind == models.RefundTypeVoid {
+			err = applyVoid(tx, order, refund, lines, claims.UserID)
+		} else {
+			err = applyRefund(tx, order, refund, lines, claims.UserID)
+		}
+		if err != nil {
+			return err
+		}
+		if req.Restock {
+			for _, line := range lines {
+				if err := stock.RestockOrderItem(tx, line.item, line.quantity, refund.ID, &claims.UserID); err != nil {
+					return err
+				}
+			}
+		}
+
+		action := audit.ActionOrderRefund
+		if kind == models.RefundTypeVoid {
+			action = audit.ActionOrderVoid
+		}
+		return audit.Record(tx, audit.Entry{
+			BranchID:   &order.BranchID,
+			UserID:     &claims.UserID,
+			ApprovedBy: &approverID,
+			Action:     action,
+			EntityType: "order",
+			EntityID:   order.ID,
+			Reason:     req.ReasonCode,
+			Details: map[string]interface{}{
+				"refund_id":       refund.ID,
+				"amount":          refund.Amount,
+				"is_full":         refund.IsFull,
+				"items":           len(refund.Items),
+				"restock":         refund.Restock,
+				"approval_method": method,
+				"notes":           req.Notes,
+			},
+			IPAddress: ipAddress,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	realtime.Publish(realtime.Event{Type: realtime.EventOrderUpdated, BranchID: current.BranchID, OrderID: orderID})
+	return getRefund(refund.ID)
+}
+
+// GetRefunds returns the refunds and voids of an order, oldest first
+func GetRefunds(orderID uint, claims *common_dto.Claims) ([]models.Refund, error) {
+	if _, err := order_services.GetOrder(orderID, claims); err != nil {
+		return nil, err
+	}
+	var refunds []models.Refund
+	err := preloadRefund(models.DataBase).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&refunds).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching refunds: %w", err)
+	}
+	return refunds, nil
+}
+
+func getRefund(id uint) (*models.Refund, error) {
+	var refund models.Refund
+	if err := preloadRefund(models.DataBase).First(&refund, id).Error; err != nil {
+		return nil, fmt.Errorf("error fetching refund: %w", err)
+	}
+	return &refund, nil
+}
+
+func preloadRefund(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items.OrderItem.MenuItem").Preload("Payments").Preload("ApprovedUser")
+}
+
+// approveRefund returns who approved the request: the requester when they are a
+// manager, otherwise the branch manager (or restaurant owner) whose PIN was entered
+func approveRefund(order *models.Order, claims *common_dto.Claims, pin, ipAddress string) (uint, models.ApprovalMethod, error) {
+	if order_services.IsSupervisor(claims) {
+		return claims.UserID, models.ApprovalSelf, nil
+	}
+	if pin == "" {
+		return 0, "", ErrApprovalRequired
+	}
+
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, order.BranchID).Error; err != nil {
+		return 0, "", fmt.Errorf("error fetching branch: %w", err)
+	}
+	var managers []models.User
+	err := models.DataBase.
+		Where("is_active = ? AND pin_hash <> ''", true).
+		Where("(role = ? AND restaurant_id = ? AND (branch_id = ? OR branch_id IS NULL)) OR (user_type = ? AND restaurant_id = ?)",
+			models.RoleManager, branch.RestaurantID, branch.ID, models.UserTypeRestaurant, branch.RestaurantID).
+		Find(&managers).Error
+	if err != nil {
+		return 0, "", fmt.Errorf("error fetching managers: %w", err)
+	}
+	for _, manager := range managers {
+		if password.Check(pin, manager.PinHash) {
+			return manager.ID, models.ApprovalPIN, nil
+		}
+	}
+
+	// Failed overrides are recorded outside any transaction so they survive the rejection
+	if err := audit.Record(models.DataBase, audit.Entry{
+		BranchID:   &order.BranchID,
+		UserID:     &claims.UserID,
+		Action:     audit.ActionManagerPINFailed,
+		EntityType: "order",
+		EntityID:   order.ID,
+		IPAddress:  ipAddress,
+	}); err != nil {
+		return 0, "", err
+	}
+	return 0, "", ErrInvalidManagerPIN
+}
+
+// selectRefundLines resolves the requested lines, or every eligible line when none are given.
+// Voids always remove whole lines; refunds may return part of a line.
+func selectRefundLines(tx *gorm.DB, order *models.Order, kind models.RefundType, inputs []dto.RefundItemInput) ([]refundLine, error) {
+	refunded, err := refundedQuantities(tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := func(item *models.OrderItem) int {
+		if kind == models.RefundTypeVoid {
+			return item.Quantity
+		}
+		return item.Quantity - refunded[item.ID]
+	}
+
+	var lines []refundLine
+	if len(inputs) == 0 {
+		for i := range order.OrderItems {
+			item := &order.OrderItems[i]
+			if item.Status == models.OrderItemCancelled || remaining(item) <= 0 {
+				continue
+			}
+			lines = append(lines, refundLine{item: item, quantity: remaining(item)})
+		}
+	} else {
+		seen := make(map[uint]bool, len(inputs))
+		for _, input := range inputs {
+			var item *models.OrderItem
+			for i := range order.OrderItems {
+				if order.OrderItems[i].ID == input.OrderItemID {
+					item = &order.OrderItems[i]
+					break
+				}
+			}
+			if item == nil || seen[item.ID] {
+				return nil, fmt.Errorf("%w: order item %d is not on this order or is listed twice", ErrInvalidRefundItem, input.OrderItemID)
+			}
+			seen[item.ID] = true
+
+			if item.Status == models.OrderItemCancelled {
+				return nil, fmt.Errorf("%w: order item %d is cancelled", ErrInvalidRefundItem, item.ID)
+			}
+			if kind == models.RefundTypeVoid {
+				if !item.Status.CanTransitionTo(models.OrderItemCancelled) {
+					return nil, fmt.Errorf("%w: order item %d is %s", order_services.ErrIllegalTransition, item.ID, item.Status)
+				}
+				if input.Quantity != 0 && input.Quantity != item.Quantity {
+					return nil, fmt.Errorf("%w: voids remove whole lines", ErrInvalidRefundItem)
+				}
+			}
+
+			quantity := input.Quantity
+			if quantity == 0 {
+				quantity = remaining(item)
+			}
+			if quantity <= 0 || quantity > remaining(item) {
+				return nil, fmt.Errorf("%w: only %d of order item %d can be refunded", ErrInvalidRefundItem, remaining(item), item.ID)
+			}
+			lines = append(lines, refundLine{item: item, quantity: quantity})
+		}
+	}
+
+	var taxLines []models.OrderTaxLine
+	if err := tx.Where("order_id = ?", order.ID).Order("id ASC").Find(&taxLines).Error; err != nil {
+		return nil, fmt.Errorf("error fetching order tax lines: %w", err)
+	}
+	for i := range lines {
+		lines[i].share = shareOf(order, taxLines, lines[i].item, lines[i].quantity)
+	}
+	return lines, nil
+}
+
+// applyVoid cancels the selected lines (or the whole order) of an unpaid order and
+// takes their share off the bill
+func applyVoid(tx *gorm.DB, order *models.Order, refund *models.Refund, lines []refundLine, userID uint) error {
+	paid, err := paidAmount(tx, order.ID)
+	if err != nil {
+		return err
+	}
+	if paid.IsPositive() {
+		return ErrVoidAfterPayment
+	}
+	reason := "voided: " + string(refund.ReasonCode)
+
+	if refund.IsFull {
+		if !order.Status.CanTransitionTo(models.OrderCancelled) {
+			return fmt.Errorf("%w: %s -> %s", order_services.ErrIllegalTransition, order.Status, models.OrderCancelled)
+		}
+		refund.Amount = order.Total
+		if err := createRefund(tx, refund); err != nil {
+			return err
+		}
+		return cancelOrder(tx, order, userID, reason)
+	}
+
+	var removed lineShare
+	for _, line := range lines {
+		if err := order_services.SetOrderItemStatus(tx, line.item, models.OrderItemCancelled, &userID, reason); err != nil {
+			return err
+		}
+		removed = removed.add(line.share)
+	}
+	totals, err := remainingTotals(tx, order, removed)
+	if err != nil {
+		return err
+	}
+	refund.Amount = order.Total.Sub(totals.Total)
+	if err := createRefund(tx, refund); err != nil {
+		return err
+	}
+
+	err = tx.Model(order).Updates(map[string]interface{}{
+		"subtotal":            totals.Subtotal,
+		"discount_amount":     totals.DiscountAmount,
+		"tax_amount":          totals.TaxAmount,
+		"included_tax_amount": totals.IncludedTaxAmount,
+		"service_charge":      totals.ServiceCharge,
+		"total":               totals.Total,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("error updating order totals: %w", err)
+	}
+	return order_services.SyncOrderStatus(tx, order)
+}
+
+// remainingTotals recomputes an order's totals once lines are voided. The service charge
+// and its tax lines shrink with the remaining net amount; the taxes are then summed again
+// from the tax lines of the items still on the order.
+func remainingTotals(tx *gorm.DB, order *models.Order, removed lineShare) (*models.Order, error) {
+	totals := &models.Order{
+		Subtotal:       order.Subtotal.Sub(removed.gross),
+		DiscountAmount: order.DiscountAmount.Sub(removed.discount),
+		ServiceCharge:  order.ServiceCharge.Sub(removed.service),
+	}
+
+	var taxLines []models.OrderTaxLine
+	if err := tx.Where("order_id = ?", order.ID).Order("id ASC").Find(&taxLines).Error; err != nil {
+		return nil, fmt.Errorf("error fetching order tax lines: %w", err)
+	}
+	cancelled := make(map[uint]bool, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		if item.Status == models.OrderItemCancelled {
+			cancelled[item.ID] = true
+		}
+	}
+	for i := range taxLines {
+		line := &taxLines[i]
+		if line.OrderItemID != nil && cancelled[*line.OrderItemID] {
+			continue
+		}
+		if line.OrderItemID == nil && !order.ServiceCharge.IsZero() {
+			line.TaxableAmount = line.TaxableAmount.MulFrac(totals.ServiceCharge.Minor(), order.ServiceCharge.Minor()).Round()
+			line.Amount = line.Amount.MulFrac(totals.ServiceCharge.Minor(), order.ServiceCharge.Minor()).Round()
+			err := tx.Model(line).Updates(map[string]interface{}{
+				"taxable_amount": line.TaxableAmount,
+				"amount":         line.Amount,
+			}).Error
+			if err != nil {
+				return nil, fmt.Errorf("error updating service charge tax: %w", err)
+			}
+		}
+		if line.IsInclusive {
+			totals.IncludedTaxAmount = totals.IncludedTaxAmount.Add(line.Amount)
+		} else {
+			totals.TaxAmount = totals.TaxAmount.Add(line.Amount)
+		}
+	}
+	totals.Total = totals.Subtotal.Sub(totals.DiscountAmount).Add(totals.TaxAmount).Add(totals.ServiceCharge)
+	return totals, nil
+}
+
+// applyRefund returns money for the selected lines (or everything paid) by creating
+// negative payments against the original tenders, newest first
+func applyRefund(tx *gorm.DB, order *models.Order, refund *models.Refund, lines []refundLine, userID uint) error {
+	var payments []models.Payment
+	if err := tx.Where("order_id = ?", order.ID).Order("created_at DESC, id DESC").Find(&payments).Error; err != nil {
+		return fmt.Errorf("error fetching payments: %w", err)
+	}
+	refundable := AmountPaid(payments).Sub(AmountRefunded(payments))
+	if !refundable.IsPositive() {
+		return ErrNothingToRefund
+	}
+
+	amount := refundable
+	if !refund.IsFull {
+		amount = money.Zero
+		for _, line := range lines {
+			amount = amount.Add(line.share.total())
+		}
+		if amount.GreaterThan(refundable) {
+			return fmt.Errorf("%w: %s requested, %s refundable", ErrRefundTooLarge, amount, refundable)
+		}
+		if !amount.IsPositive() {
+			return ErrNothingToRefund
+		}
+	}
+	refund.Amount = amount
+	if err := createRefund(tx, refund); err != nil {
+		return err
+	}
+	// A full refund reverses the customer's whole order once it is marked refunded
+	if !refund.IsFull {
+		if err := customer_services.DebitSpend(tx, order, amount); err != nil {
+			return err
+		}
+	}
+
+	reversed := make(map[uint]money.Money)
+	for _, p := range payments {
+		if p.Status == models.PaymentRefunded && p.OriginalPaymentID != nil {
+			reversed[*p.OriginalPaymentID] = reversed[*p.OriginalPaymentID].Sub(p.Amount)
+		}
+	}
+	left := amount
+	for _, original := range payments {
+		if left.IsZero() {
+			break
+		}
+		if original.Status != models.PaymentPaid {
+			continue
+		}
+		available := original.Amount.Sub(reversed[original.ID])
+		if !available.IsPositive() {
+			continue
+		}
+		take := money.Min(available, left)
+		originalID := original.ID
+		reversal := &models.Payment{
+			OrderID:           order.ID,
+			Amount:            take.Neg(),
+			Method:            original.Method,
+			Status:            models.PaymentRefunded,
+			Reference:         fmt.Sprintf("REFUND-%d", refund.ID),
+			ProcessedBy:       &userID,
+			OriginalPaymentID: &originalID,
+			RefundID:          &refund.ID,
+		}
+		if err := tx.Create(reversal).Error; err != nil {
+			return fmt.Errorf("error recording refund payment: %w", err)
+		}
+		left = left.Sub(take)
+	}
+
+	if refundable.GreaterThan(amount) {
+		return nil
+	}
+	if err := tx.Model(order).Update("payment_status", models.PaymentRefunded).Error; err != nil {
+		return fmt.Errorf("error updating payment status: %w", err)
+	}
+	reason := "refunded: " + string(refund.ReasonCode)
+	// A fully refunded order that is still in the kitchen is cancelled first
+	if refund.IsFull && order.Status.CanTransitionTo(models.OrderCancelled) {
+		if err := cancelOrder(tx, order, userID, reason); err != nil {
+			return err
+		}
+	}
+	if order.Status.CanTransitionTo(models.OrderRefunded) {
+		return order_services.SetOrderStatus(tx, order, models.OrderRefunded, &userID, reason)
+	}
+	return nil
+}
+
+// cancelOrder cancels an order and every item that can still be cancelled
+func cancelOrder(tx *gorm.DB, order *models.Order, userID uint, reason string) error {
+	if err := order_services.SetOrderStatus(tx, order, models.OrderCancelled, &userID, reason); err != nil {
+		return err
+	}
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		if !item.Status.CanTransitionTo(models.OrderItemCancelled) {
+			continue
+		}
+		if err := order_services.SetOrderItemStatus(tx, item, models.OrderItemCancelled, &userID, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createRefund(tx *gorm.DB, refund *models.Refund) error {
+	if err := tx.Omit("Order", "RequestedUser", "ApprovedUser", "Payments", "Items.OrderItem").Create(refund).Error; err != nil {
+		return fmt.Errorf("error recording refund: %w", err)
+	}
+	return nil
+}
+
+// refundedQuantities sums the refunded quantity per order item of an order
+func refundedQuantities(tx *gorm.DB, orderID uint) (map[uint]int, error) {
+	var rows []struct {
+		OrderItemID uint
+		Quantity    int
+	}
+	err := tx.Model(&models.RefundItem{}).
+		Select("refund_items.order_item_id, SUM(refund_items.quantity) AS quantity").
+		Joins("JOIN refunds ON refunds.id = refund_items.refund_id").
+		Where("refunds.order_id = ? AND refunds.type = ?", orderID, models.RefundTypeRefund).
+		Group("refund_items.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching refunded quantities: %w", err)
+	}
+	refunded := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		refunded[row.OrderItemID] = row.Quantity
+	}
+	return refunded, nil
+}
+
+// shareOf splits the part of the order behind quantity units of an item: its own tax
+// lines pro rata to the quantity, and the discount, service charge and service charge
+// tax in proportion to its part of the subtotal
+func shareOf(order *models.Order, taxLines []models.OrderTaxLine, item *models.OrderItem, quantity int) lineShare {
+	share := lineShare{gross: item.UnitPrice.Mul(quantity)}
+	for _, l := range taxLines {
+		if l.OrderItemID == nil || *l.OrderItemID != item.ID || item.Quantity <= 0 {
+			continue
+		}
+		amount := l.Amount.MulFrac(int64(quantity), int64(item.Quantity)).Round()
+		if l.IsInclusive {
+			share.included = share.included.Add(amount)
+		} else {
+			share.tax = share.tax.Add(amount)
+		}
+	}
+	if !order.Subtotal.IsPositive() {
+		return share
+	}
+	part := func(v money.Money) money.Money {
+		return v.MulFrac(share.gross.Minor(), order.Subtotal.Minor()).Round()
+	}
+	share.discount = part(order.DiscountAmount)
+	share.service = part(order.ServiceCharge)
+	for _, l := range taxLines {
+		if l.OrderItemID != nil {
+			continue
+		}
+		if l.IsInclusive {
+			share.included = share.included.Add(part(l.Amount))
+		} else {
+			share.tax = share.tax.Add(part(l.Amount))
+		}
+	}
+	return share
+}
@@ -0,0 +1,546 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	purchase_dto "restaurant_os/internal/api/purchase/dto"
+	purchase_services "restaurant_os/internal/api/purchase/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"strings"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type purchaseController struct{}
+
+var validate = validator.New()
+
+func NewPurchaseController() *purchaseController {
+	return &purchaseController{}
+}
+
+// ============================================================================
+// SUPPLIERS
+// ============================================================================
+
+func (pc *purchaseController) GetSuppliers(c *fiber.Ctx) error {
+	var query purchase_dto.SupplierListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	suppliers, err := purchase_services.ListSuppliers(&query, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to fetch suppliers")
+	}
+
+	data := make([]purchase_dto.SupplierResponse, 0, len(suppliers))
+	for i := range suppliers {
+		data = append(data, toSupplierResponse(&suppliers[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Suppliers fetched successfully",
+		Data:    data,
+	})
+}
+
+func (pc *purchaseController) GetSupplier(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid supplier ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	supplier, err := purchase_services.GetSupplier(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to fetch supplier")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Supplier fetched successfully",
+		Data:    toSupplierResponse(supplier),
+	})
+}
+
+func (pc *purchaseController) CreateSupplier(c *fiber.Ctx) error {
+	var req purchase_dto.CreateSupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.SupplierValidationErrorMessages)
+	}
+
+	supplier, err := purchase_services.CreateSupplier(&req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to create supplier")
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Supplier created successfully",
+		Data:    toSupplierResponse(supplier),
+	})
+}
+
+func (pc *purchaseController) UpdateSupplier(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid supplier ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req purchase_dto.UpdateSupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.SupplierValidationErrorMessages)
+	}
+
+	supplier, err := purchase_services.UpdateSupplier(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to update supplier")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Supplier updated successfully",
+		Data:    toSupplierResponse(supplier),
+	})
+}
+
+func (pc *purchaseController) GetSupplierSpend(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid supplier ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var query purchase_dto.SupplierSpendQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	spend, err := purchase_services.SupplierSpend(uint(id), &query, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to fetch supplier spend")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Supplier spend fetched successfully",
+		Data:    spend,
+	})
+}
+
+// ============================================================================
+// PURCHASE ORDERS
+// ============================================================================
+
+func (pc *purchaseController) GetPurchaseOrders(c *fiber.Ctx) error {
+	var query purchase_dto.PurchaseOrderListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	orders, total, err := purchase_services.ListPurchaseOrders(&query, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to fetch purchase orders")
+	}
+
+	data := make([]purchase_dto.PurchaseOrderResponse, 0, len(orders))
+	for i := range orders {
+		data = append(data, toPurchaseOrderResponse(&orders[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Purchase orders fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (pc *purchaseController) GetPurchaseOrder(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid purchase order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	order, err := purchase_services.GetPurchaseOrder(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to fetch purchase order")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Purchase order fetched successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) CreatePurchaseOrder(c *fiber.Ctx) error {
+	var req purchase_dto.CreatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.PurchaseValidationErrorMessages)
+	}
+
+	order, err := purchase_services.CreatePurchaseOrder(&req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to create purchase order")
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Purchase order created successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) UpdatePurchaseOrder(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid purchase order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req purchase_dto.UpdatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.PurchaseValidationErrorMessages)
+	}
+
+	order, err := purchase_services.UpdatePurchaseOrder(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to update purchase order")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Purchase order updated successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) SendPurchaseOrder(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid purchase order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	order, err := purchase_services.SendPurchaseOrder(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to send purchase order")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Purchase order sent successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) CancelPurchaseOrder(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid purchase order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req purchase_dto.CancelPurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.PurchaseValidationErrorMessages)
+	}
+
+	order, err := purchase_services.CancelPurchaseOrder(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to cancel purchase order")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Purchase order cancelled successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) ReceiveGoods(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid purchase order ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req purchase_dto.CreateGoodsReceiptRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, purchase_dto.PurchaseValidationErrorMessages)
+	}
+
+	order, err := purchase_services.ReceiveGoods(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to receive goods")
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Goods received successfully",
+		Data:    toPurchaseOrderResponse(order),
+	})
+}
+
+func (pc *purchaseController) SuggestPurchaseOrders(c *fiber.Ctx) error {
+	var req purchase_dto.ReorderCheckRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+
+	result, orders, err := purchase_services.RunReorderCheckForBranch(&req, middleware.GetClaims(c))
+	if err != nil {
+		return purchaseErrorResponse(c, err, "Failed to run reorder check")
+	}
+
+	resp := purchase_dto.ReorderCheckResponse{
+		LowStockItems:   result.LowStock,
+		Notified:        result.Notified,
+		SuggestedOrders: make([]purchase_dto.PurchaseOrderResponse, 0, len(orders)),
+	}
+	for i := range orders {
+		resp.SuggestedOrders = append(resp.SuggestedOrders, toPurchaseOrderResponse(&orders[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Reorder check completed",
+		Data:    resp,
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// purchaseErrorResponse maps supplier and purchase order service errors to HTTP status codes
+func purchaseErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, purchase_services.ErrSupplierNotFound), errors.Is(err, purchase_services.ErrPurchaseOrderNotFound),
+		errors.Is(err, purchase_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden), errors.Is(err, purchase_services.ErrSupplierForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, purchase_services.ErrPurchaseOrderStatus):
+		status = fiber.StatusConflict
+	case errors.Is(err, purchase_services.ErrInvalidPurchaseOrder), errors.Is(err, purchase_services.ErrInvalidReceipt):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, dto.ErrBranchRequired), errors.Is(err, purchase_services.ErrInvalidDateRange):
+		status = fiber.StatusBadRequest
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toSupplierResponse(s *models.Supplier) purchase_dto.SupplierResponse {
+	return purchase_dto.SupplierResponse{
+		ID:           s.ID,
+		RestaurantID: s.RestaurantID,
+		Name:         s.Name,
+		Contact:      s.Contact,
+		Phone:        s.Phone,
+		Email:        s.Email,
+		Address:      s.Address,
+		IsActive:     s.IsActive,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+func toPurchaseOrderResponse(o *models.PurchaseOrder) purchase_dto.PurchaseOrderResponse {
+	resp := purchase_dto.PurchaseOrderResponse{
+		ID:           o.ID,
+		PONumber:     o.PONumber,
+		BranchID:     o.BranchID,
+		SupplierID:   o.SupplierID,
+		SupplierName: o.Supplier.Name,
+		Status:       string(o.Status),
+		Notes:        o.Notes,
+		Total:        o.Total,
+		RaisedBy:     o.RaisedBy,
+		IsSuggested:  o.IsSuggested,
+		SentAt:       o.SentAt,
+		ReceivedAt:   o.ReceivedAt,
+		CancelledAt:  o.CancelledAt,
+		CancelReason: o.CancelReason,
+		Lines:        make([]purchase_dto.PurchaseOrderLineResponse, 0, len(o.Lines)),
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+	}
+	if o.RaisedByUser != nil {
+		resp.RaisedByName = o.RaisedByUser.Name
+	}
+	if o.ExpectedDate != nil {
+		expected := o.ExpectedDate.In(time.Local).Format(time.DateOnly)
+		resp.ExpectedDate = &expected
+	}
+	for _, line := range o.Lines {
+		resp.Lines = append(resp.Lines, purchase_dto.PurchaseOrderLineResponse{
+			ID:               line.ID,
+			InventoryID:      line.InventoryID,
+			InventoryName:    line.Inventory.ItemName,
+			Unit:             string(line.Inventory.Unit),
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			Outstanding:      line.Outstanding(),
+			UnitCost:         line.UnitCost,
+			LineTotal:        line.LineTotal,
+		})
+	}
+	for i := range o.Receipts {
+		resp.Receipts = append(resp.Receipts, toGoodsReceiptResponse(&o.Receipts[i]))
+	}
+	return resp
+}
+
+func toGoodsReceiptResponse(r *models.GoodsReceipt) purchase_dto.GoodsReceiptResponse {
+	resp := purchase_dto.GoodsReceiptResponse{
+		ID:                r.ID,
+		GRNNumber:         r.GRNNumber,
+		PurchaseOrderID:   r.PurchaseOrderID,
+		SupplierInvoiceNo: r.SupplierInvoiceNo,
+		Notes:             r.Notes,
+		Total:             r.Total,
+		ReceivedBy:        r.ReceivedBy,
+		ReceivedByName:    r.ReceivedByUser.Name,
+		ReceivedAt:        r.ReceivedAt,
+		Lines:             make([]purchase_dto.GoodsReceiptLineResponse, 0, len(r.Lines)),
+	}
+	for _, line := range r.Lines {
+		resp.Lines = append(resp.Lines, purchase_dto.GoodsReceiptLineResponse{
+			ID:                  line.ID,
+			PurchaseOrderLineID: line.PurchaseOrderLineID,
+			InventoryID:         line.InventoryID,
+			InventoryName:       line.Inventory.ItemName,
+			Quantity:            line.Quantity,
+			UnitCost:            line.UnitCost,
+			LineTotal:           line.LineTotal,
+			BatchID:             line.BatchID,
+			BatchNumber:         line.BatchNumber,
+			ExpiryDate:          line.ExpiryDate,
+			StockMovementID:     line.StockMovementID,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,249 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// SUPPLIER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// SupplierListQuery represents filters for listing suppliers
+type SupplierListQuery struct {
+	Search   string `query:"search"`
+	IsActive *bool  `query:"is_active"`
+}
+
+// CreateSupplierRequest represents the request to add a supplier
+type CreateSupplierRequest struct {
+	RestaurantID *uint  `json:"restaurant_id,omitempty"` // SUPER_ADMIN only; defaults to the user's restaurant, nil shares the supplier
+	Name         string `json:"name" validate:"required,min=2,max=100"`
+	Contact      string `json:"contact,omitempty" validate:"max=100"`
+	Phone        string `json:"phone,omitempty" validate:"max=20"`
+	Email        string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Address      string `json:"address,omitempty"`
+	IsActive     *bool  `json:"is_active,omitempty"`
+}
+
+// UpdateSupplierRequest represents the request to update a supplier; nil fields are left unchanged
+type UpdateSupplierRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Contact  *string `json:"contact,omitempty" validate:"omitempty,max=100"`
+	Phone    *string `json:"phone,omitempty" validate:"omitempty,max=20"`
+	Email    *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Address  *string `json:"address,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// SupplierValidationErrorMessages maps supplier request fields to custom messages
+var SupplierValidationErrorMessages = map[string]string{
+	"Name":    "Name is required and must be between 2 and 100 characters.",
+	"Contact": "Contact must be at most 100 characters.",
+	"Phone":   "Phone must be at most 20 characters.",
+	"Email":   "Email must be a valid email address.",
+}
+
+// SupplierResponse represents a supplier
+type SupplierResponse struct {
+	ID           uint      `json:"id"`
+	RestaurantID *uint     `json:"restaurant_id,omitempty"`
+	Name         string    `json:"name"`
+	Contact      string    `json:"contact,omitempty"`
+	Phone        string    `json:"phone,omitempty"`
+	Email        string    `json:"email,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SupplierSpendQuery represents filters for a supplier's spend history; from and
+// to are dates (YYYY-MM-DD) in the server time zone, both inclusive
+type SupplierSpendQuery struct {
+	BranchID *uint  `query:"branch_id"`
+	From     string `query:"from"`
+	To       string `query:"to"`
+}
+
+// MonthlySpend represents the goods received from a supplier in one month
+type MonthlySpend struct {
+	Month    string      `json:"month"` // YYYY-MM
+	Spend    money.Money `json:"spend"`
+	Receipts int         `json:"receipts"`
+}
+
+// ItemSpend represents the goods of one inventory item received from a supplier
+type ItemSpend struct {
+	InventoryID   uint        `json:"inventory_id"`
+	InventoryName string      `json:"inventory_name"`
+	Unit          string      `json:"unit"`
+	Quantity      float64     `json:"quantity"`
+	Spend         money.Money `json:"spend"`
+}
+
+// SupplierSpendResponse represents what was bought from a supplier, valued at
+// the invoiced cost of the goods receipts
+type SupplierSpendResponse struct {
+	SupplierID     uint           `json:"supplier_id"`
+	SupplierName   string         `json:"supplier_name"`
+	TotalSpend     money.Money    `json:"total_spend"`
+	ReceiptCount   int            `json:"receipt_count"`
+	OpenOrderValue money.Money    `json:"open_order_value"` // Outstanding quantities of sent purchase orders
+	Months         []MonthlySpend `json:"months"`
+	Items          []ItemSpend    `json:"items"`
+}
+
+// ============================================================================
+// PURCHASE ORDER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// PurchaseOrderListQuery represents filters for listing purchase orders
+type PurchaseOrderListQuery struct {
+	dto.PaginationQuery
+	BranchID   *uint  `query:"branch_id"`
+	SupplierID *uint  `query:"supplier_id"`
+	Status     string `query:"status"`
+}
+
+// PurchaseOrderLineInput is an inventory item to order, in its stock unit
+type PurchaseOrderLineInput struct {
+	InventoryID uint     `json:"inventory_id" validate:"required"`
+	Quantity    float64  `json:"quantity" validate:"required,gt=0"`
+	Unit        string   `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Unit of quantity and unit_cost, defaults to the inventory unit
+	UnitCost    *float64 `json:"unit_cost,omitempty" validate:"omitempty,min=0"`                               // Defaults to the item's current unit cost
+}
+
+// CreatePurchaseOrderRequest represents the request to raise a draft purchase order
+type CreatePurchaseOrderRequest struct {
+	BranchID     *uint                    `json:"branch_id,omitempty"` // Defaults to the user's branch
+	SupplierID   uint                     `json:"supplier_id" validate:"required"`
+	ExpectedDate string                   `json:"expected_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes        string                   `json:"notes,omitempty" validate:"max=1000"`
+	Lines        []PurchaseOrderLineInput `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+// UpdatePurchaseOrderRequest edits a draft purchase order; lines, when given, replace all lines
+type UpdatePurchaseOrderRequest struct {
+	SupplierID   *uint                     `json:"supplier_id,omitempty"`
+	ExpectedDate *string                   `json:"expected_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes        *string                   `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Lines        *[]PurchaseOrderLineInput `json:"lines,omitempty" validate:"omitempty,min=1,max=100,dive"`
+}
+
+// CancelPurchaseOrderRequest represents the request to cancel a purchase order
+type CancelPurchaseOrderRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// GoodsReceiptLineInput is a quantity delivered against a purchase order line
+type GoodsReceiptLineInput struct {
+	LineID      uint     `json:"line_id" validate:"required"`
+	Quantity    float64  `json:"quantity" validate:"required,gt=0"`
+	Unit        string   `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Unit of quantity and unit_cost, defaults to the inventory unit
+	UnitCost    *float64 `json:"unit_cost,omitempty" validate:"omitempty,min=0"`                               // Invoiced cost, defaults to the ordered cost
+	BatchNumber string   `json:"batch_number,omitempty" validate:"max=50"`
+	ExpiryDate  string   `json:"expiry_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// CreateGoodsReceiptRequest records a delivery against a purchase order
+type CreateGoodsReceiptRequest struct {
+	SupplierInvoiceNo string                  `json:"supplier_invoice_no,omitempty" validate:"max=50"`
+	Notes             string                  `json:"notes,omitempty" validate:"max=1000"`
+	Lines             []GoodsReceiptLineInput `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+// PurchaseValidationErrorMessages maps purchase order and goods receipt request fields to custom messages
+var PurchaseValidationErrorMessages = map[string]string{
+	"SupplierID":        "Supplier ID is required.",
+	"ExpectedDate":      "Expected date must be a date like 2006-01-02.",
+	"Notes":             "Notes must be at most 1000 characters.",
+	"Lines":             "Between 1 and 100 lines are required.",
+	"InventoryID":       "Each line needs an inventory_id.",
+	"LineID":            "Each line needs the line_id of a purchase order line.",
+	"Quantity":          "Each line needs a quantity greater than 0.",
+	"UnitCost":          "Unit cost cannot be negative.",
+	"Unit":              "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+	"Reason":            "Reason is required and must be between 3 and 500 characters.",
+	"SupplierInvoiceNo": "Supplier invoice number must be at most 50 characters.",
+	"BatchNumber":       "Batch number must be at most 50 characters.",
+	"ExpiryDate":        "Expiry date must be a date like 2006-01-02.",
+}
+
+// ReorderCheckRequest runs the reorder check on a branch
+type ReorderCheckRequest struct {
+	BranchID *uint `json:"branch_id,omitempty"` // Defaults to the user's branch
+}
+
+// ReorderCheckResponse reports the low stock found and the draft purchase orders suggested
+type ReorderCheckResponse struct {
+	LowStockItems   int                     `json:"low_stock_items"`
+	Notified        int                     `json:"notified"` // Items newly flagged with an INVENTORY_LOW notification
+	SuggestedOrders []PurchaseOrderResponse `json:"suggested_orders"`
+}
+
+// PurchaseOrderLineResponse represents one line of a purchase order
+type PurchaseOrderLineResponse struct {
+	ID               uint        `json:"id"`
+	InventoryID      uint        `json:"inventory_id"`
+	InventoryName    string      `json:"inventory_name"`
+	Unit             string      `json:"unit"`
+	Quantity         float64     `json:"quantity"`
+	ReceivedQuantity float64     `json:"received_quantity"`
+	Outstanding      float64     `json:"outstanding"`
+	UnitCost         money.Money `json:"unit_cost"`
+	LineTotal        money.Money `json:"line_total"`
+}
+
+// GoodsReceiptLineResponse represents one line of a goods receipt
+type GoodsReceiptLineResponse struct {
+	ID                  uint        `json:"id"`
+	PurchaseOrderLineID uint        `json:"purchase_order_line_id"`
+	InventoryID         uint        `json:"inventory_id"`
+	InventoryName       string      `json:"inventory_name"`
+	Quantity            float64     `json:"quantity"`
+	UnitCost            money.Money `json:"unit_cost"`
+	LineTotal           money.Money `json:"line_total"`
+	BatchID             *uint       `json:"batch_id,omitempty"`
+	BatchNumber         string      `json:"batch_number,omitempty"`
+	ExpiryDate          *time.Time  `json:"expiry_date,omitempty"`
+	StockMovementID     *uint       `json:"stock_movement_id,omitempty"`
+}
+
+// GoodsReceiptResponse represents a goods receipt note
+type GoodsReceiptResponse struct {
+	ID                uint                       `json:"id"`
+	GRNNumber         string                     `json:"grn_number"`
+	PurchaseOrderID   uint                       `json:"purchase_order_id"`
+	SupplierInvoiceNo string                     `json:"supplier_invoice_no,omitempty"`
+	Notes             string                     `json:"notes,omitempty"`
+	Total             money.Money                `json:"total"`
+	ReceivedBy        uint                       `json:"received_by"`
+	ReceivedByName    string                     `json:"received_by_name,omitempty"`
+	ReceivedAt        time.Time                  `json:"received_at"`
+	Lines             []GoodsReceiptLineResponse `json:"lines"`
+}
+
+// PurchaseOrderResponse represents a purchase order with its lines and receipts
+type PurchaseOrderResponse struct {
+	ID           uint                        `json:"id"`
+	PONumber     string                      `json:"po_number"`
+	BranchID     uint                        `json:"branch_id"`
+	SupplierID   uint                        `json:"supplier_id"`
+	SupplierName string                      `json:"supplier_name"`
+	Status       string                      `json:"status"`
+	ExpectedDate *string                     `json:"expected_date,omitempty"`
+	Notes        string                      `json:"notes,omitempty"`
+	Total        money.Money                 `json:"total"`
+	RaisedBy     *uint                       `json:"raised_by,omitempty"`
+	RaisedByName string                      `json:"raised_by_name,omitempty"`
+	IsSuggested  bool                        `json:"is_suggested"`
+	SentAt       *time.Time                  `json:"sent_at,omitempty"`
+	ReceivedAt   *time.Time                  `json:"received_at,omitempty"`
+	CancelledAt  *time.Time                  `json:"cancelled_at,omitempty"`
+	CancelReason string                      `json:"cancel_reason,omitempty"`
+	Lines        []PurchaseOrderLineResponse `json:"lines"`
+	Receipts     []GoodsReceiptResponse      `json:"receipts,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
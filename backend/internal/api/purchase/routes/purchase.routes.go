@@ -0,0 +1,35 @@
+package routes
+
+import (
+	purchase_controller "restaurant_os/internal/api/purchase/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPurchaseRoutes(api fiber.Router) {
+
+	purchaseHandler := purchase_controller.NewPurchaseController()
+	supervisors := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER")
+
+	suppliers := api.Group("/suppliers", middleware.RequireAuth(), supervisors)
+
+	suppliers.Get("/", purchaseHandler.GetSuppliers)
+	suppliers.Post("/", purchaseHandler.CreateSupplier)
+	suppliers.Get("/:id", purchaseHandler.GetSupplier)
+	suppliers.Put("/:id", purchaseHandler.UpdateSupplier)
+	suppliers.Get("/:id/spend", purchaseHandler.GetSupplierSpend)
+
+	// The kitchen checks deliveries in, supervisors raise and manage orders
+	purchaseOrders := api.Group("/purchase-orders", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"))
+
+	purchaseOrders.Get("/", purchaseHandler.GetPurchaseOrders)
+	purchaseOrders.Post("/", supervisors, purchaseHandler.CreatePurchaseOrder)
+	// Runs the scheduled reorder check on demand
+	purchaseOrders.Post("/suggestions", supervisors, purchaseHandler.SuggestPurchaseOrders)
+	purchaseOrders.Get("/:id", purchaseHandler.GetPurchaseOrder)
+	purchaseOrders.Put("/:id", supervisors, purchaseHandler.UpdatePurchaseOrder)
+	purchaseOrders.Post("/:id/send", supervisors, purchaseHandler.SendPurchaseOrder)
+	purchaseOrders.Post("/:id/cancel", supervisors, purchaseHandler.CancelPurchaseOrder)
+	purchaseOrders.Post("/:id/receipts", purchaseHandler.ReceiveGoods)
+}
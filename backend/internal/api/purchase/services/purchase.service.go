@@ -0,0 +1,381 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/purchase/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/numbering"
+	"restaurant_os/internal/stock"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrBranchNotFound        = errors.New("branch not found")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrPurchaseOrderStatus   = errors.New("purchase order status does not allow this")
+)
+
+// ListPurchaseOrders returns a page of purchase orders visible to the requester, newest first
+func ListPurchaseOrders(query *dto.PurchaseOrderListQuery, claims *common_dto.Claims) ([]models.PurchaseOrder, int64, error) {
+	query.Normalize()
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.PurchaseOrder{}), "branch_id")
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.SupplierID != nil {
+		db = db.Where("supplier_id = ?", *query.SupplierID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", strings.ToUpper(query.Status))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting purchase orders: %w", err)
+	}
+
+	var orders []models.PurchaseOrder
+	err := preloadPurchaseOrder(db).
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching purchase orders: %w", err)
+	}
+	return orders, total, nil
+}
+
+// GetPurchaseOrder returns a purchase order with its lines and receipts if its
+// branch is visible to the requester
+func GetPurchaseOrder(id uint, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := preloadPurchaseOrder(claims.ScopeBranches(models.DataBase, "branch_id")).First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("error fetching purchase order: %w", err)
+	}
+	return &order, nil
+}
+
+// CreatePurchaseOrder raises a draft purchase order for a branch
+func CreatePurchaseOrder(req *dto.CreatePurchaseOrderRequest, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := orderableSupplier(req.SupplierID, branch, claims)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := buildLines(branch.ID, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.PurchaseOrder{
+		BranchID:   branch.ID,
+		SupplierID: supplier.ID,
+		Status:     models.PurchaseOrderDraft,
+		Notes:      req.Notes,
+		RaisedBy:   &claims.UserID,
+		Lines:      lines,
+		Total:      linesTotal(lines),
+	}
+	if order.ExpectedDate, err = parseDate(req.ExpectedDate); err != nil {
+		return nil, err
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if order.PONumber, err = nextNumber(tx, &models.PurchaseOrder{}, "po_number", "PO", branch.ID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Branch", "Supplier", "RaisedByUser", "Lines.Inventory").Create(order).Error; err != nil {
+			return fmt.Errorf("error creating purchase order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPurchaseOrder(order.ID, claims)
+}
+
+// UpdatePurchaseOrder edits a draft purchase order
+func UpdatePurchaseOrder(id uint, req *dto.UpdatePurchaseOrderRequest, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	order, err := GetPurchaseOrder(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderDraft {
+		return nil, fmt.Errorf("%w: only draft purchase orders can be edited", ErrPurchaseOrderStatus)
+	}
+
+	updates := map[string]interface{}{}
+	if req.SupplierID != nil {
+		supplier, err := orderableSupplier(*req.SupplierID, &order.Branch, claims)
+		if err != nil {
+			return nil, err
+		}
+		updates["supplier_id"] = supplier.ID
+	}
+	if req.ExpectedDate != nil {
+		expected, err := parseDate(*req.ExpectedDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["expected_date"] = expected
+	}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+	var lines []models.PurchaseOrderLine
+	if req.Lines != nil {
+		if lines, err = buildLines(order.BranchID, *req.Lines); err != nil {
+			return nil, err
+		}
+		updates["total"] = linesTotal(lines)
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if lines != nil {
+			if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&models.PurchaseOrderLine{}).Error; err != nil {
+				return fmt.Errorf("error replacing purchase order lines: %w", err)
+			}
+			for i := range lines {
+				lines[i].PurchaseOrderID = order.ID
+			}
+			if err := tx.Omit("Inventory").Create(&lines).Error; err != nil {
+				return fmt.Errorf("error replacing purchase order lines: %w", err)
+			}
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&models.PurchaseOrder{}).Where("id = ?", order.ID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("error updating purchase order: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPurchaseOrder(order.ID, claims)
+}
+
+// SendPurchaseOrder marks a draft as sent to the supplier. The ordered items
+// record the order date, and items without a supplier are linked to this one.
+func SendPurchaseOrder(id uint, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	order, err := GetPurchaseOrder(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderDraft {
+		return nil, fmt.Errorf("%w: only draft purchase orders can be sent", ErrPurchaseOrderStatus)
+	}
+	if !order.Supplier.IsActive {
+		return nil, fmt.Errorf("%w: supplier %s is inactive", ErrInvalidPurchaseOrder, order.Supplier.Name)
+	}
+
+	now := time.Now()
+	inventoryIDs := make([]uint, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		inventoryIDs = append(inventoryIDs, line.InventoryID)
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.PurchaseOrder{}).
+			Where("id = ? AND status = ?", order.ID, models.PurchaseOrderDraft).
+			Updates(map[string]interface{}{"status": models.PurchaseOrderSent, "sent_at": now})
+		if res.Error != nil {
+			return fmt.Errorf("error sending purchase order: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: only draft purchase orders can be sent", ErrPurchaseOrderStatus)
+		}
+		if err := tx.Model(&models.Inventory{}).Where("id IN ?", inventoryIDs).Update("last_ordered", now).Error; err != nil {
+			return fmt.Errorf("error updating inventory: %w", err)
+		}
+		err := tx.Model(&models.Inventory{}).Where("id IN ? AND supplier_id IS NULL", inventoryIDs).
+			Updates(map[string]interface{}{"supplier_id": order.SupplierID, "supplier_name": order.Supplier.Name}).Error
+		if err != nil {
+			return fmt.Errorf("error linking inventory to supplier: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPurchaseOrder(order.ID, claims)
+}
+
+// CancelPurchaseOrder cancels a purchase order that is not fully received;
+// stock already received stays in stock
+func CancelPurchaseOrder(id uint, req *dto.CancelPurchaseOrderRequest, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	order, err := GetPurchaseOrder(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == models.PurchaseOrderReceived || order.Status == models.PurchaseOrderCancelled {
+		return nil, fmt.Errorf("%w: purchase order is already %s", ErrPurchaseOrderStatus, strings.ToLower(string(order.Status)))
+	}
+
+	res := models.DataBase.Model(&models.PurchaseOrder{}).
+		Where("id = ? AND status = ?", order.ID, order.Status).
+		Updates(map[string]interface{}{
+			"status":        models.PurchaseOrderCancelled,
+			"cancelled_at":  time.Now(),
+			"cancel_reason": strings.TrimSpace(req.Reason),
+		})
+	if res.Error != nil {
+		return nil, fmt.Errorf("error cancelling purchase order: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: purchase order changed, try again", ErrPurchaseOrderStatus)
+	}
+	return GetPurchaseOrder(order.ID, claims)
+}
+
+// buildLines checks that every item is stocked by the branch and appears once
+func buildLines(branchID uint, inputs []dto.PurchaseOrderLineInput) ([]models.PurchaseOrderLine, error) {
+	ids := make([]uint, 0, len(inputs))
+	seen := map[uint]bool{}
+	for _, in := range inputs {
+		if seen[in.InventoryID] {
+			return nil, fmt.Errorf("%w: inventory item %d is listed twice", ErrInvalidPurchaseOrder, in.InventoryID)
+		}
+		seen[in.InventoryID] = true
+		ids = append(ids, in.InventoryID)
+	}
+
+	var items []models.Inventory
+	if err := models.DataBase.Preload("Conversions").Where("id IN ? AND branch_id = ?", ids, branchID).Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("error fetching inventory: %w", err)
+	}
+	byID := make(map[uint]*models.Inventory, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+
+	lines := make([]models.PurchaseOrderLine, 0, len(inputs))
+	for _, in := range inputs {
+		item, ok := byID[in.InventoryID]
+		if !ok {
+			return nil, fmt.Errorf("%w: inventory item %d is not stocked by this branch", ErrInvalidPurchaseOrder, in.InventoryID)
+		}
+		quantity, unitCost, err := stockQuantity(item, in.Quantity, in.Unit, in.UnitCost)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPurchaseOrder, item.ItemName, err)
+		}
+		if quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of %s rounds to 0", ErrInvalidPurchaseOrder, item.ItemName)
+		}
+		if in.UnitCost == nil {
+			unitCost = item.UnitCost
+		}
+		lines = append(lines, models.PurchaseOrderLine{
+			InventoryID: item.ID,
+			Quantity:    quantity,
+			UnitCost:    unitCost,
+			LineTotal:   stock.Value(unitCost, quantity),
+		})
+	}
+	return lines, nil
+}
+
+// stockQuantity converts a quantity, and the cost of one unit of it when given,
+// from unit to the unit the item is stocked in
+func stockQuantity(item *models.Inventory, quantity float64, unit string, unitCost *float64) (float64, money.Money, error) {
+	cost := money.Zero
+	if unit == "" || models.InventoryUnit(unit) == item.Unit {
+		if unitCost != nil {
+			cost = money.FromFloat(*unitCost)
+		}
+		return stock.RoundQuantity(quantity), cost, nil
+	}
+	converted, err := item.ToStockUnit(quantity, models.InventoryUnit(unit))
+	if err != nil {
+		return 0, money.Zero, err
+	}
+	converted = stock.RoundQuantity(converted)
+	if unitCost != nil && converted > 0 {
+		cost = stock.PerUnit(stock.Value(money.FromFloat(*unitCost), quantity), converted)
+	}
+	return converted, cost, nil
+}
+
+// orderableSupplier returns an active supplier the branch's restaurant can order from
+func orderableSupplier(id uint, branch *models.Branch, claims *common_dto.Claims) (*models.Supplier, error) {
+	supplier, err := GetSupplier(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if supplier.RestaurantID != nil && *supplier.RestaurantID != branch.RestaurantID {
+		return nil, ErrSupplierNotFound
+	}
+	if !supplier.IsActive {
+		return nil, fmt.Errorf("%w: supplier %s is inactive", ErrInvalidPurchaseOrder, supplier.Name)
+	}
+	return supplier, nil
+}
+
+func resolveBranch(requested *uint, claims *common_dto.Claims) (*models.Branch, error) {
+	branchID, err := claims.ResolveBranchID(requested)
+	if err != nil {
+		return nil, err
+	}
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, branchID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	return &branch, nil
+}
+
+// nextNumber returns a document number unique per branch and day, e.g.
+// PO-20250614-1-0007; the row lock on the branch serializes concurrent numbering
+func nextNumber(tx *gorm.DB, model interface{}, column, kind string, branchID uint) (string, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Branch{}, branchID).Error; err != nil {
+		return "", fmt.Errorf("error generating %s number: %w", kind, err)
+	}
+	return numbering.Next(tx, model, column, kind, branchID)
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: dates must look like 2006-01-02", ErrInvalidPurchaseOrder)
+	}
+	return &date, nil
+}
+
+func linesTotal(lines []models.PurchaseOrderLine) money.Money {
+	total := money.Zero
+	for _, line := range lines {
+		total = total.Add(line.LineTotal)
+	}
+	return total
+}
+
+func preloadPurchaseOrder(db *gorm.DB) *gorm.DB {
+	return db.Preload("Branch").Preload("Supplier").Preload("RaisedByUser").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).Preload("Lines.Inventory").
+		Preload("Receipts", func(db *gorm.DB) *gorm.DB { return db.Order("received_at ASC, id ASC") }).
+		Preload("Receipts.ReceivedByUser").Preload("Receipts.Lines.Inventory")
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/purchase/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidReceipt = errors.New("invalid goods receipt")
+
+// ReceiveGoods records a goods receipt note against a sent purchase order. Each
+// line posts a PURCHASE_RECEIPT stock movement at the invoiced cost, which also
+// moves the item's unit cost to the weighted average, and opens a stock batch
+// with the delivered lot number and expiry date.
+func ReceiveGoods(id uint, req *dto.CreateGoodsReceiptRequest, claims *common_dto.Claims) (*models.PurchaseOrder, error) {
+	if _, err := GetPurchaseOrder(id, claims); err != nil {
+		return nil, err
+	}
+
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		// Concurrent receipts against the same order queue on its row
+		var order models.PurchaseOrder
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines.Inventory.Conversions").First(&order, id).Error
+		if err != nil {
+			return fmt.Errorf("error fetching purchase order: %w", err)
+		}
+		if !order.Status.CanReceive() {
+			return fmt.Errorf("%w: goods can only be received against sent purchase orders", ErrPurchaseOrderStatus)
+		}
+
+		lines := make(map[uint]*models.PurchaseOrderLine, len(order.Lines))
+		for i := range order.Lines {
+			lines[order.Lines[i].ID] = &order.Lines[i]
+		}
+		receipt := &models.GoodsReceipt{
+			PurchaseOrderID:   order.ID,
+			BranchID:          order.BranchID,
+			SupplierID:        order.SupplierID,
+			SupplierInvoiceNo: strings.TrimSpace(req.SupplierInvoiceNo),
+			Notes:             req.Notes,
+			ReceivedBy:        claims.UserID,
+			ReceivedAt:        time.Now(),
+		}
+		seen := map[uint]bool{}
+		for _, in := range req.Lines {
+			line, ok := lines[in.LineID]
+			if !ok {
+				return fmt.Errorf("%w: line %d is not on this purchase order", ErrInvalidReceipt, in.LineID)
+			}
+			if seen[in.LineID] {
+				return fmt.Errorf("%w: line %d is listed twice", ErrInvalidReceipt, in.LineID)
+			}
+			seen[in.LineID] = true
+
+			quantity, unitCost, err := stockQuantity(&line.Inventory, in.Quantity, in.Unit, in.UnitCost)
+			if err != nil {
+				return fmt.Errorf("%w: line %d: %v", ErrInvalidReceipt, line.ID, err)
+			}
+			if quantity <= 0 || quantity > stock.RoundQuantity(line.Outstanding()) {
+				return fmt.Errorf("%w: line %d has %g %s outstanding", ErrInvalidReceipt, line.ID, stock.RoundQuantity(line.Outstanding()), line.Inventory.Unit)
+			}
+			if in.UnitCost == nil {
+				unitCost = line.UnitCost
+			}
+			expiry, err := parseDate(in.ExpiryDate)
+			if err != nil {
+				return fmt.Errorf("%w: line %d: expiry dates must look like 2006-01-02", ErrInvalidReceipt, line.ID)
+			}
+			receiptLine := models.GoodsReceiptLine{
+				PurchaseOrderLineID: line.ID,
+				InventoryID:         line.InventoryID,
+				Quantity:            quantity,
+				UnitCost:            unitCost,
+				LineTotal:           stock.Value(unitCost, quantity),
+				BatchNumber:         strings.TrimSpace(in.BatchNumber),
+				ExpiryDate:          expiry,
+			}
+			receipt.Lines = append(receipt.Lines, receiptLine)
+			receipt.Total = receipt.Total.Add(receiptLine.LineTotal)
+			line.ReceivedQuantity = stock.RoundQuantity(line.ReceivedQuantity + quantity)
+		}
+
+		if receipt.GRNNumber, err = nextNumber(tx, &models.GoodsReceipt{}, "grn_number", "GRN", order.BranchID); err != nil {
+			return err
+		}
+		if err := tx.Omit("PurchaseOrder", "ReceivedByUser", "Lines.Inventory").Create(receipt).Error; err != nil {
+			return fmt.Errorf("error creating goods receipt: %w", err)
+		}
+
+		for i := range receipt.Lines {
+			receiptLine := &receipt.Lines[i]
+			movement, err := stock.Receive(tx, stock.Movement{
+				InventoryID:   receiptLine.InventoryID,
+				Quantity:      receiptLine.Quantity,
+				Reason:        fmt.Sprintf("%s for %s", receipt.GRNNumber, order.PONumber),
+				ReferenceType: "goods_receipt",
+				ReferenceID:   &receipt.ID,
+				PostedBy:      &claims.UserID,
+				BatchNumber:   receiptLine.BatchNumber,
+				ExpiryDate:    receiptLine.ExpiryDate,
+			}, receiptLine.UnitCost)
+			if err != nil {
+				return err
+			}
+			err = tx.Model(receiptLine).Updates(map[string]interface{}{
+				"stock_movement_id": movement.ID,
+				"batch_id":          movement.BatchID,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("error linking stock movement: %w", err)
+			}
+			err = tx.Model(&models.PurchaseOrderLine{}).Where("id = ?", receiptLine.PurchaseOrderLineID).
+				Update("received_quantity", lines[receiptLine.PurchaseOrderLineID].ReceivedQuantity).Error
+			if err != nil {
+				return fmt.Errorf("error updating purchase order line: %w", err)
+			}
+		}
+
+		updates := map[string]interface{}{"status": models.PurchaseOrderReceived, "received_at": receipt.ReceivedAt}
+		for _, line := range order.Lines {
+			if stock.RoundQuantity(line.Outstanding()) > 0 {
+				updates = map[string]interface{}{"status": models.PurchaseOrderPartiallyReceived}
+				break
+			}
+		}
+		if err := tx.Model(&models.PurchaseOrder{}).Where("id = ?", order.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("error updating purchase order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPurchaseOrder(id, claims)
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"restaurant_os/internal/api/purchase/dto"
+	"restaurant_os/internal/config"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/notify"
+	"restaurant_os/internal/stock"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReorderResult reports what a reorder check found and raised
+type ReorderResult struct {
+	LowStock      int    // Items at or below their reorder level
+	Notified      int    // Items newly flagged with an INVENTORY_LOW notification
+	Notifications int    // Notification rows created
+	Suggested     []uint // Draft purchase orders created
+}
+
+// ReorderCheckInterval returns how often the reorder check runs (default 15m)
+func ReorderCheckInterval() time.Duration {
+	if config.EnvConfig == nil {
+		return 15 * time.Minute
+	}
+	if d, err := time.ParseDuration(config.EnvConfig.ReorderCheckInterval); err == nil && d > 0 {
+		return d
+	}
+	return 15 * time.Minute
+}
+
+// CheckReorders looks for items at or below their reorder level, on one branch
+// or on all of them when branchID is nil:
+//   - an item is flagged once per shortage: its branch managers get an
+//     INVENTORY_LOW notification, and the flag clears when stock is back above
+//     the reorder level
+//   - items linked to an active supplier get draft purchase orders, one per
+//     supplier and branch, that top stock up to MaxLevel; quantities already on
+//     open purchase orders are counted as coming in
+func CheckReorders(now time.Time, branchID *uint) (*ReorderResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if branchID != nil {
+			return db.Where("branch_id = ?", *branchID)
+		}
+		return db
+	}
+
+	err := scope(models.DataBase.Model(&models.Inventory{})).
+		Where("low_stock_at IS NOT NULL AND current_stock > reorder_level").
+		Update("low_stock_at", nil).Error
+	if err != nil {
+		return nil, fmt.Errorf("error clearing restocked items: %w", err)
+	}
+
+	var items []models.Inventory
+	err = scope(models.DataBase.Preload("Supplier")).
+		Where("reorder_level > 0 AND current_stock <= reorder_level").
+		Order("branch_id ASC, id ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching low stock items: %w", err)
+	}
+
+	result := &ReorderResult{LowStock: len(items)}
+	for i := range items {
+		if items[i].LowStockAt != nil {
+			continue
+		}
+		created, err := notifyLowStock(&items[i], now)
+		if err != nil {
+			return result, err
+		}
+		if created > 0 {
+			result.Notified++
+			result.Notifications += created
+		}
+	}
+
+	if err := suggestPurchaseOrders(items, result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// RunReorderCheck is the scheduled job entry point
+func RunReorderCheck() error {
+	result, err := CheckReorders(time.Now(), nil)
+	if err != nil {
+		return err
+	}
+	if result.Notified > 0 || len(result.Suggested) > 0 {
+		log.Printf("reorder check: %d items low, %d newly flagged, %d purchase orders suggested",
+			result.LowStock, result.Notified, len(result.Suggested))
+	}
+	return nil
+}
+
+// notifyLowStock flags an item that fell to its reorder level and tells the
+// branch managers, returning how many notifications were sent. Only the check
+// that sets the flag notifies.
+func notifyLowStock(item *models.Inventory, now time.Time) (int, error) {
+	created := 0
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Inventory{}).Where("id = ? AND low_stock_at IS NULL", item.ID).Update("low_stock_at", now)
+		if res.Error != nil {
+			return fmt.Errorf("error flagging low stock: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		message := fmt.Sprintf("%s is down to %g %s (reorder level %g %s)",
+			item.ItemName, item.CurrentStock, item.Unit, item.ReorderLevel, item.Unit)
+		if item.SupplierID == nil {
+			message += "; no supplier is linked, so no purchase order was suggested"
+		}
+		inventoryID := item.ID
+		base := models.Notification{
+			Type:        models.NotificationInventoryLow,
+			Title:       "Low stock: " + item.ItemName,
+			Message:     message,
+			Data:        fmt.Sprintf(`{"current_stock":%g,"reorder_level":%g,"max_level":%g,"unit":%q}`, item.CurrentStock, item.ReorderLevel, item.MaxLevel, item.Unit),
+			InventoryID: &inventoryID,
+		}
+
+		n, err := notify.BranchManagers(tx, item.BranchID, base)
+		if err != nil {
+			return err
+		}
+		created = n
+		return nil
+	})
+	return created, err
+}
+
+// suggestPurchaseOrders raises draft purchase orders for the low items that
+// still need stock once open purchase orders and transfers are delivered
+func suggestPurchaseOrders(items []models.Inventory, result *ReorderResult) error {
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		if item.SupplierID != nil && item.Supplier != nil && item.Supplier.IsActive {
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var openLines []models.PurchaseOrderLine
+	err := models.DataBase.
+		Joins("JOIN purchase_orders ON purchase_orders.id = purchase_order_lines.purchase_order_id").
+		Where("purchase_order_lines.inventory_id IN ?", ids).
+		Where("purchase_orders.deleted_at IS NULL AND purchase_orders.status IN ?", []models.PurchaseOrderStatus{
+			models.PurchaseOrderDraft, models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived,
+		}).
+		Find(&openLines).Error
+	if err != nil {
+		return fmt.Errorf("error fetching open purchase orders: %w", err)
+	}
+	onOrder := map[uint]float64{}
+	for i := range openLines {
+		onOrder[openLines[i].InventoryID] += openLines[i].Outstanding()
+	}
+
+	// Stock another branch is sending counts as on order too, in the sending item's unit
+	var incoming []struct {
+		ToInventoryID uint
+		Quantity      float64
+		Unit          models.InventoryUnit
+	}
+	err = models.DataBase.Model(&models.StockTransferLine{}).
+		Select("stock_transfer_lines.to_inventory_id, inventories.unit, "+
+			"CASE WHEN stock_transfers.status = ? THEN stock_transfer_lines.requested_quantity "+
+			"ELSE stock_transfer_lines.dispatched_quantity END AS quantity", models.TransferRequested).
+		Joins("JOIN stock_transfers ON stock_transfers.id = stock_transfer_lines.stock_transfer_id").
+		Joins("JOIN inventories ON inventories.id = stock_transfer_lines.from_inventory_id").
+		Where("stock_transfer_lines.to_inventory_id IN ?", ids).
+		Where("stock_transfers.status IN ?", []models.StockTransferStatus{models.TransferRequested, models.TransferDispatched}).
+		Scan(&incoming).Error
+	if err != nil {
+		return fmt.Errorf("error fetching open stock transfers: %w", err)
+	}
+	byID := make(map[uint]*models.Inventory, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+	for _, line := range incoming {
+		if quantity, err := byID[line.ToInventoryID].ToStockUnit(line.Quantity, line.Unit); err == nil {
+			onOrder[line.ToInventoryID] += quantity
+		}
+	}
+
+	type group struct{ branchID, supplierID uint }
+	groups := map[group][]models.PurchaseOrderLine{}
+	var order []group
+	for _, item := range items {
+		if item.SupplierID == nil || item.Supplier == nil || !item.Supplier.IsActive {
+			continue
+		}
+		quantity := stock.RoundQuantity(item.MaxLevel - item.CurrentStock - onOrder[item.ID])
+		if quantity <= 0 {
+			continue
+		}
+		key := group{item.BranchID, *item.SupplierID}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], models.PurchaseOrderLine{
+			InventoryID: item.ID,
+			Quantity:    quantity,
+			UnitCost:    item.UnitCost,
+			LineTotal:   stock.Value(item.UnitCost, quantity),
+		})
+	}
+
+	for _, key := range order {
+		po := &models.PurchaseOrder{
+			BranchID:    key.branchID,
+			SupplierID:  key.supplierID,
+			Status:      models.PurchaseOrderDraft,
+			Notes:       "Suggested by the reorder check to top low stock up to its max level",
+			IsSuggested: true,
+			Lines:       groups[key],
+			Total:       linesTotal(groups[key]),
+		}
+		err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+			var err error
+			if po.PONumber, err = nextNumber(tx, &models.PurchaseOrder{}, "po_number", "PO", key.branchID); err != nil {
+				return err
+			}
+			if err := tx.Omit("Branch", "Supplier", "RaisedByUser", "Lines.Inventory").Create(po).Error; err != nil {
+				return fmt.Errorf("error creating suggested purchase order: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		result.Suggested = append(result.Suggested, po.ID)
+	}
+	return nil
+}
+
+// RunReorderCheckForBranch runs the reorder check on one branch the requester
+// can access and returns the purchase orders it suggested
+func RunReorderCheckForBranch(req *dto.ReorderCheckRequest, claims *common_dto.Claims) (*ReorderResult, []models.PurchaseOrder, error) {
+	branch, err := resolveBranch(req.BranchID, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := CheckReorders(time.Now(), &branch.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders := make([]models.PurchaseOrder, 0, len(result.Suggested))
+	for _, id := range result.Suggested {
+		order, err := GetPurchaseOrder(id, claims)
+		if err != nil {
+			return nil, nil, err
+		}
+		orders = append(orders, *order)
+	}
+	return result, orders, nil
+}
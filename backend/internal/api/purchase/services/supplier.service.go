@@ -0,0 +1,253 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/purchase/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/stock"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSupplierNotFound  = errors.New("supplier not found")
+	ErrSupplierForbidden = errors.New("only SUPER_ADMIN can change suppliers shared by every restaurant")
+	ErrInvalidDateRange  = errors.New("invalid date range")
+)
+
+// ListSuppliers returns the suppliers visible to the requester, by name
+func ListSuppliers(query *dto.SupplierListQuery, claims *common_dto.Claims) ([]models.Supplier, error) {
+	db := scopeSuppliers(models.DataBase.Model(&models.Supplier{}), claims)
+	if query.Search != "" {
+		like := "%" + strings.ToLower(strings.TrimSpace(query.Search)) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(contact) LIKE ?", like, like)
+	}
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+
+	var suppliers []models.Supplier
+	if err := db.Order("name ASC").Find(&suppliers).Error; err != nil {
+		return nil, fmt.Errorf("error fetching suppliers: %w", err)
+	}
+	return suppliers, nil
+}
+
+// GetSupplier returns a supplier if it is visible to the requester
+func GetSupplier(id uint, claims *common_dto.Claims) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := scopeSuppliers(models.DataBase, claims).First(&supplier, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("error fetching supplier: %w", err)
+	}
+	return &supplier, nil
+}
+
+// CreateSupplier adds a supplier to the requester's restaurant
+func CreateSupplier(req *dto.CreateSupplierRequest, claims *common_dto.Claims) (*models.Supplier, error) {
+	supplier := &models.Supplier{
+		RestaurantID: claims.RestaurantID,
+		Name:         strings.TrimSpace(req.Name),
+		Contact:      req.Contact,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		Address:      req.Address,
+		IsActive:     true,
+	}
+	if claims.IsSuperAdmin() {
+		supplier.RestaurantID = req.RestaurantID
+	}
+
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(supplier).Error; err != nil {
+			return fmt.Errorf("error creating supplier: %w", err)
+		}
+		// A false is_active would be replaced by the column default on insert
+		if req.IsActive != nil && !*req.IsActive {
+			if err := tx.Model(supplier).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("error creating supplier: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetSupplier(supplier.ID, claims)
+}
+
+// UpdateSupplier applies the non-nil fields of req; a new name is copied to the
+// inventory items linked to the supplier
+func UpdateSupplier(id uint, req *dto.UpdateSupplierRequest, claims *common_dto.Claims) (*models.Supplier, error) {
+	supplier, err := GetSupplier(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if supplier.RestaurantID == nil && !claims.IsSuperAdmin() {
+		return nil, ErrSupplierForbidden
+	}
+
+	if req.Name != nil {
+		supplier.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Contact != nil {
+		supplier.Contact = *req.Contact
+	}
+	if req.Phone != nil {
+		supplier.Phone = *req.Phone
+	}
+	if req.Email != nil {
+		supplier.Email = *req.Email
+	}
+	if req.Address != nil {
+		supplier.Address = *req.Address
+	}
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(supplier).Error; err != nil {
+			return fmt.Errorf("error updating supplier: %w", err)
+		}
+		err := tx.Model(&models.Inventory{}).Where("supplier_id = ?", supplier.ID).Update("supplier_name", supplier.Name).Error
+		if err != nil {
+			return fmt.Errorf("error updating inventory supplier: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// SupplierSpend sums the goods received from a supplier by month and by item,
+// restricted to the branches the requester can access
+func SupplierSpend(id uint, query *dto.SupplierSpendQuery, claims *common_dto.Claims) (*dto.SupplierSpendResponse, error) {
+	supplier, err := GetSupplier(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	db := claims.ScopeBranches(models.DataBase.Model(&models.GoodsReceipt{}), "branch_id").Where("supplier_id = ?", supplier.ID)
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if db, err = filterDates(db, "received_at", query.From, query.To); err != nil {
+		return nil, err
+	}
+
+	var receipts []models.GoodsReceipt
+	if err := db.Preload("Lines.Inventory").Order("received_at ASC").Find(&receipts).Error; err != nil {
+		return nil, fmt.Errorf("error fetching goods receipts: %w", err)
+	}
+
+	resp := &dto.SupplierSpendResponse{
+		SupplierID:   supplier.ID,
+		SupplierName: supplier.Name,
+		ReceiptCount: len(receipts),
+		Months:       []dto.MonthlySpend{},
+		Items:        []dto.ItemSpend{},
+	}
+	items := map[uint]*dto.ItemSpend{}
+	for _, receipt := range receipts {
+		resp.TotalSpend = resp.TotalSpend.Add(receipt.Total)
+		month := receipt.ReceivedAt.In(time.Local).Format("2006-01")
+		if n := len(resp.Months); n == 0 || resp.Months[n-1].Month != month {
+			resp.Months = append(resp.Months, dto.MonthlySpend{Month: month})
+		}
+		current := &resp.Months[len(resp.Months)-1]
+		current.Spend = current.Spend.Add(receipt.Total)
+		current.Receipts++
+
+		for _, line := range receipt.Lines {
+			item, ok := items[line.InventoryID]
+			if !ok {
+				item = &dto.ItemSpend{
+					InventoryID:   line.InventoryID,
+					InventoryName: line.Inventory.ItemName,
+					Unit:          string(line.Inventory.Unit),
+				}
+				items[line.InventoryID] = item
+			}
+			item.Quantity = stock.RoundQuantity(item.Quantity + line.Quantity)
+			item.Spend = item.Spend.Add(line.LineTotal)
+		}
+	}
+	for _, item := range items {
+		resp.Items = append(resp.Items, *item)
+	}
+	sort.Slice(resp.Items, func(i, j int) bool {
+		if c := resp.Items[i].Spend.Cmp(resp.Items[j].Spend); c != 0 {
+			return c > 0
+		}
+		return resp.Items[i].InventoryID < resp.Items[j].InventoryID
+	})
+
+	if resp.OpenOrderValue, err = openOrderValue(supplier.ID, query.BranchID, claims); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// openOrderValue values the quantities still expected on sent purchase orders
+func openOrderValue(supplierID uint, branchID *uint, claims *common_dto.Claims) (money.Money, error) {
+	db := claims.ScopeBranches(models.DataBase.Model(&models.PurchaseOrder{}), "branch_id").
+		Where("supplier_id = ? AND status IN ?", supplierID,
+			[]models.PurchaseOrderStatus{models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived})
+	if branchID != nil {
+		db = db.Where("branch_id = ?", *branchID)
+	}
+
+	var orders []models.PurchaseOrder
+	if err := db.Preload("Lines").Find(&orders).Error; err != nil {
+		return money.Zero, fmt.Errorf("error fetching open purchase orders: %w", err)
+	}
+	total := money.Zero
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			total = total.Add(stock.Value(line.UnitCost, line.Outstanding()))
+		}
+	}
+	return total, nil
+}
+
+// scopeSuppliers restricts a supplier query to shared suppliers and those of the requester's restaurant
+func scopeSuppliers(db *gorm.DB, claims *common_dto.Claims) *gorm.DB {
+	switch {
+	case claims.IsSuperAdmin():
+		return db
+	case claims.RestaurantID != nil:
+		return db.Where("restaurant_id IS NULL OR restaurant_id = ?", *claims.RestaurantID)
+	default:
+		return db.Where("restaurant_id IS NULL")
+	}
+}
+
+// filterDates applies inclusive from and to dates (YYYY-MM-DD) to a time column
+func filterDates(db *gorm.DB, column, from, to string) (*gorm.DB, error) {
+	if from != "" {
+		start, err := time.ParseInLocation(time.DateOnly, from, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: from must be a date like 2006-01-02", ErrInvalidDateRange)
+		}
+		db = db.Where(column+" >= ?", start)
+	}
+	if to != "" {
+		end, err := time.ParseInLocation(time.DateOnly, to, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: to must be a date like 2006-01-02", ErrInvalidDateRange)
+		}
+		db = db.Where(column+" < ?", end.AddDate(0, 0, 1))
+	}
+	return db, nil
+}
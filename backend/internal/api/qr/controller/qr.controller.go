@@ -0,0 +1,362 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	menu_dto "restaurant_os/internal/api/menu/dto"
+	order_controller "restaurant_os/internal/api/order/controller"
+	order_dto "restaurant_os/internal/api/order/dto"
+	order_services "restaurant_os/internal/api/order/services"
+	qr_dto "restaurant_os/internal/api/qr/dto"
+	qr_services "restaurant_os/internal/api/qr/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/schedule"
+	"strconv"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type qrController struct{}
+
+var validate = validator.New()
+
+func NewQRController() *qrController {
+	return &qrController{}
+}
+
+// Scan records a table QR scan and tells the client whether a session is already running
+func (qc *qrController) Scan(c *fiber.Ctx) error {
+	scan, table, session, err := qr_services.RecordScan(c.Params("qrToken"), scanInfo(c))
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to scan table")
+	}
+
+	response := qr_dto.ScanResponse{
+		ScanID:      scan.ID,
+		TableID:     table.ID,
+		TableNumber: table.Number,
+		BranchID:    table.BranchID,
+		BranchName:  table.Branch.Name,
+	}
+	if session != nil {
+		active := toSessionResponse(session)
+		response.ActiveSession = &active
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Table scanned successfully",
+		Data:    response,
+	})
+}
+
+// StartSession starts a session at the table, or joins the one already running
+func (qc *qrController) StartSession(c *fiber.Ctx) error {
+	var req qr_dto.StartSessionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, qr_dto.StartSessionValidationErrorMessages)
+	}
+
+	session, err := qr_services.StartSession(c.Params("qrToken"), &req, scanInfo(c))
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to start session")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Session started successfully",
+		Data:    toSessionResponse(session),
+	})
+}
+
+func (qc *qrController) GetSession(c *fiber.Ctx) error {
+	session, err := qr_services.GetSession(c.Params("sessionToken"))
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to fetch session")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Session fetched successfully",
+		Data:    toSessionResponse(session),
+	})
+}
+
+func (qc *qrController) GetMenu(c *fiber.Ctx) error {
+	var filter menu_dto.DietaryFilter
+	if err := c.QueryParser(&filter); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	menu, etag, err := qr_services.GetMenu(c.Params("sessionToken"), &filter)
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to fetch menu")
+	}
+	return menuResponse(c, menu, etag)
+}
+
+// GetTableMenu serves the read-only menu for a table QR code; it is cacheable by
+// clients and proxies and answers conditional requests with 304 Not Modified
+func (qc *qrController) GetTableMenu(c *fiber.Ctx) error {
+	var filter menu_dto.DietaryFilter
+	if err := c.QueryParser(&filter); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	menu, etag, err := qr_services.TableMenu(c.Params("qrToken"), &filter)
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to fetch menu")
+	}
+	c.Set(fiber.HeaderCacheControl, "public, max-age=60")
+	return menuResponse(c, menu, etag)
+}
+
+func (qc *qrController) AddCartItem(c *fiber.Ctx) error {
+	var req qr_dto.AddCartItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, qr_dto.CartValidationErrorMessages)
+	}
+
+	session, err := qr_services.AddCartItem(c.Params("sessionToken"), &req)
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to add item to cart")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Item added to cart",
+		Data:    toSessionResponse(session),
+	})
+}
+
+func (qc *qrController) UpdateCartItem(c *fiber.Ctx) error {
+	itemID, err := strconv.ParseUint(c.Params("itemId"), 10, 32)
+	if err != nil {
+		errMsg := "Invalid cart item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid cart item ID",
+			Error:   &errMsg,
+		})
+	}
+
+	var req qr_dto.UpdateCartItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, qr_dto.CartValidationErrorMessages)
+	}
+
+	session, err := qr_services.UpdateCartItem(c.Params("sessionToken"), uint(itemID), &req)
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to update cart item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Cart item updated",
+		Data:    toSessionResponse(session),
+	})
+}
+
+func (qc *qrController) RemoveCartItem(c *fiber.Ctx) error {
+	itemID, err := strconv.ParseUint(c.Params("itemId"), 10, 32)
+	if err != nil {
+		errMsg := "Invalid cart item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid cart item ID",
+			Error:   &errMsg,
+		})
+	}
+
+	session, err := qr_services.RemoveCartItem(c.Params("sessionToken"), uint(itemID))
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to remove cart item")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Cart item removed",
+		Data:    toSessionResponse(session),
+	})
+}
+
+// Checkout places an order from the session cart
+func (qc *qrController) Checkout(c *fiber.Ctx) error {
+	var req qr_dto.CheckoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, qr_dto.CheckoutValidationErrorMessages)
+	}
+
+	order, err := qr_services.Checkout(c.Params("sessionToken"), &req)
+	if err != nil {
+		return qrErrorResponse(c, err, "Failed to place order")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Order placed successfully",
+		Data:    order_controller.ToOrderResponse(order),
+	})
+}
+
+func scanInfo(c *fiber.Ctx) qr_services.ScanInfo {
+	return qr_services.ScanInfo{
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// qrErrorResponse maps QR service errors to HTTP status codes
+func qrErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, qr_services.ErrTableNotFound), errors.Is(err, qr_services.ErrSessionNotFound),
+		errors.Is(err, qr_services.ErrCartItemNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, qr_services.ErrSessionExpired):
+		status = fiber.StatusGone
+	case errors.Is(err, qr_services.ErrCartEmpty), errors.Is(err, qr_services.ErrMenuItemNotOnMenu),
+		errors.Is(err, qr_services.ErrCartQuantity),
+		errors.Is(err, order_services.ErrMenuItemNotFound), errors.Is(err, order_services.ErrMenuItemUnavailable),
+		errors.Is(err, order_services.ErrTableNotFound), errors.Is(err, order_services.ErrVariantRequired),
+		errors.Is(err, order_services.ErrInvalidVariant), errors.Is(err, order_services.ErrInvalidModifiers),
+		errors.Is(err, schedule.ErrNotScheduled), errors.Is(err, schedule.ErrBranchClosed):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+// menuResponse sends a menu with its ETag, or 304 when the client already has it
+func menuResponse(c *fiber.Ctx, menu *menu_dto.PublicMenuResponse, etag string) error {
+	c.Set(fiber.HeaderETag, etag)
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" && strings.Contains(match, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Menu fetched successfully",
+		Data:    menu,
+	})
+}
+
+func toSessionResponse(session *models.QRSession) qr_dto.SessionResponse {
+	items := make([]qr_dto.CartItemResponse, 0, len(session.CartItems))
+	var total money.Money
+	for _, item := range session.CartItems {
+		modifiers := make([]order_dto.ModifierResponse, 0, len(item.Modifiers))
+		for _, m := range item.Modifiers {
+			modifiers = append(modifiers, order_dto.ModifierResponse{
+				OptionID:   m.ModifierOptionID,
+				Group:      m.GroupName,
+				Name:       m.Name,
+				PriceDelta: m.PriceDelta,
+			})
+		}
+		items = append(items, qr_dto.CartItemResponse{
+			ID:          item.ID,
+			MenuItemID:  item.MenuItemID,
+			Name:        item.MenuItem.Name,
+			VariantID:   item.VariantID,
+			VariantName: item.VariantName,
+			Modifiers:   modifiers,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			Notes:       item.Notes,
+			AddedAt:     item.AddedAt,
+		})
+		total = total.Add(item.TotalPrice)
+	}
+
+	return qr_dto.SessionResponse{
+		SessionToken:   session.SessionToken,
+		TableID:        session.TableID,
+		BranchID:       session.BranchID,
+		CustomerName:   session.CustomerName,
+		GuestCount:     session.GuestCount,
+		Status:         string(session.Status),
+		StartedAt:      session.StartedAt,
+		LastActivityAt: session.LastActivityAt,
+		ExpiresAt:      session.ExpiresAt,
+		CartItems:      items,
+		CartTotal:      total,
+	}
+}
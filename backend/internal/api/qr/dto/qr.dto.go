@@ -0,0 +1,105 @@
+package dto
+
+import (
+	order_dto "restaurant_os/internal/api/order/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// QR ORDERING REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// StartSessionRequest represents a request to start or join a table session
+type StartSessionRequest struct {
+	ScanID        *uint  `json:"scan_id,omitempty"` // Scan that led to this session, for conversion analytics
+	CustomerName  string `json:"customer_name,omitempty" validate:"max=100"`
+	CustomerPhone string `json:"customer_phone,omitempty" validate:"max=20"`
+	CustomerEmail string `json:"customer_email,omitempty" validate:"omitempty,email"`
+	GuestCount    int    `json:"guest_count,omitempty" validate:"min=0,max=50"`
+}
+
+// StartSessionValidationErrorMessages maps StartSessionRequest fields to custom messages
+var StartSessionValidationErrorMessages = map[string]string{
+	"CustomerName":  "Customer name must be at most 100 characters.",
+	"CustomerPhone": "Customer phone must be at most 20 characters.",
+	"CustomerEmail": "Customer email must be a valid email address.",
+	"GuestCount":    "Guest count must be between 0 and 50.",
+}
+
+// AddCartItemRequest represents add to cart request. Prices are looked up on the server.
+type AddCartItemRequest struct {
+	MenuItemID        uint   `json:"menu_item_id" validate:"required"`
+	VariantID         *uint  `json:"variant_id,omitempty"` // Defaults to the item's default variant
+	ModifierOptionIDs []uint `json:"modifier_option_ids,omitempty" validate:"max=30,dive,required"`
+	Quantity          int    `json:"quantity" validate:"required,min=1,max=50"`
+	Notes             string `json:"notes,omitempty" validate:"max=500"`
+}
+
+// UpdateCartItemRequest represents update cart item request
+type UpdateCartItemRequest struct {
+	Quantity *int    `json:"quantity,omitempty" validate:"omitempty,min=1,max=50"`
+	Notes    *string `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+// CartValidationErrorMessages maps cart request fields to custom messages
+var CartValidationErrorMessages = map[string]string{
+	"MenuItemID":        "menu_item_id is required.",
+	"ModifierOptionIDs": "At most 30 modifier options per item.",
+	"Quantity":          "Quantity must be between 1 and 50.",
+	"Notes":             "Notes must be at most 500 characters.",
+}
+
+// CheckoutRequest represents QR checkout request
+type CheckoutRequest struct {
+	CustomerName  string `json:"customer_name,omitempty" validate:"max=100"`
+	CustomerPhone string `json:"customer_phone,omitempty" validate:"max=20"`
+	Notes         string `json:"notes,omitempty" validate:"max=1000"`
+}
+
+// CheckoutValidationErrorMessages maps CheckoutRequest fields to custom messages
+var CheckoutValidationErrorMessages = map[string]string{
+	"CustomerName":  "Customer name must be at most 100 characters.",
+	"CustomerPhone": "Customer phone must be at most 20 characters.",
+	"Notes":         "Notes must be at most 1000 characters.",
+}
+
+// ScanResponse represents the result of scanning a table QR code
+type ScanResponse struct {
+	ScanID        uint             `json:"scan_id"`
+	TableID       uint             `json:"table_id"`
+	TableNumber   string           `json:"table_number"`
+	BranchID      uint             `json:"branch_id"`
+	BranchName    string           `json:"branch_name"`
+	ActiveSession *SessionResponse `json:"active_session,omitempty"` // Session already running at the table
+}
+
+// CartItemResponse represents a cart line
+type CartItemResponse struct {
+	ID          uint                         `json:"id"`
+	MenuItemID  uint                         `json:"menu_item_id"`
+	Name        string                       `json:"name"`
+	VariantID   *uint                        `json:"variant_id,omitempty"`
+	VariantName string                       `json:"variant_name,omitempty"`
+	Modifiers   []order_dto.ModifierResponse `json:"modifiers"`
+	Quantity    int                          `json:"quantity"`
+	UnitPrice   money.Money                  `json:"unit_price"`
+	TotalPrice  money.Money                  `json:"total_price"`
+	Notes       string                       `json:"notes,omitempty"`
+	AddedAt     time.Time                    `json:"added_at"`
+}
+
+// SessionResponse represents a QR ordering session with its cart
+type SessionResponse struct {
+	SessionToken   string             `json:"session_token"`
+	TableID        uint               `json:"table_id"`
+	BranchID       uint               `json:"branch_id"`
+	CustomerName   string             `json:"customer_name,omitempty"`
+	GuestCount     int                `json:"guest_count"`
+	Status         string             `json:"status"`
+	StartedAt      time.Time          `json:"started_at"`
+	LastActivityAt time.Time          `json:"last_activity_at"`
+	ExpiresAt      time.Time          `json:"expires_at"`
+	CartItems      []CartItemResponse `json:"cart_items"`
+	CartTotal      money.Money        `json:"cart_total"` // Indicative; final prices are computed at checkout
+}
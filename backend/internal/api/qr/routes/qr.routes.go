@@ -0,0 +1,30 @@
+package routes
+
+import (
+	qr_controller "restaurant_os/internal/api/qr/controller"
+	"restaurant_os/internal/middleware"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterQRRoutes(api fiber.Router) {
+
+	// Public customer endpoints; the table QR token and session token act as credentials,
+	// so requests are rate limited per client IP
+	qr := api.Group("/qr", middleware.RateLimit(60, time.Minute))
+
+	qrHandler := qr_controller.NewQRController()
+
+	qr.Post("/tables/:qrToken/scan", qrHandler.Scan)
+	qr.Post("/tables/:qrToken/sessions", qrHandler.StartSession)
+	qr.Get("/tables/:qrToken/menu", qrHandler.GetTableMenu)
+
+	qr.Get("/sessions/:sessionToken", qrHandler.GetSession)
+	qr.Get("/sessions/:sessionToken/menu", qrHandler.GetMenu)
+	qr.Get("/sessions/:sessionToken/cart", qrHandler.GetSession)
+	qr.Post("/sessions/:sessionToken/cart", qrHandler.AddCartItem)
+	qr.Put("/sessions/:sessionToken/cart/:itemId", qrHandler.UpdateCartItem)
+	qr.Delete("/sessions/:sessionToken/cart/:itemId", qrHandler.RemoveCartItem)
+	qr.Post("/sessions/:sessionToken/checkout", qrHandler.Checkout)
+}
@@ -0,0 +1,617 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	menu_dto "restaurant_os/internal/api/menu/dto"
+	menu_services "restaurant_os/internal/api/menu/services"
+	order_dto "restaurant_os/internal/api/order/dto"
+	order_services "restaurant_os/internal/api/order/services"
+	"restaurant_os/internal/api/qr/dto"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/realtime"
+	"restaurant_os/internal/schedule"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTableNotFound     = errors.New("table not found or QR ordering is disabled")
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrSessionExpired    = errors.New("session has expired, please scan the table QR code again")
+	ErrCartItemNotFound  = errors.New("cart item not found")
+	ErrCartEmpty         = errors.New("cart is empty")
+	ErrMenuItemNotOnMenu = errors.New("menu item is not available at this table")
+	ErrCartQuantity      = fmt.Errorf("a cart line holds at most %d of an item", MaxCartQuantity)
+)
+
+// MaxCartQuantity is the most of one item a cart line can hold, matching the
+// quantity limit of the cart requests
+const MaxCartQuantity = 50
+
+// ScanInfo describes the device that scanned a QR code
+type ScanInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// RecordScan looks up the table for a QR token and logs the scan for analytics
+func RecordScan(qrToken string, info ScanInfo) (*models.QRCodeScan, *models.Table, *models.QRSession, error) {
+	table, err := getQRTable(qrToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := models.DataBase.Select("id", "name").First(&table.Branch, table.BranchID).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+
+	scan := &models.QRCodeScan{
+		TableID:    table.ID,
+		BranchID:   table.BranchID,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+		DeviceType: detectDeviceType(info.UserAgent),
+		ScanTime:   time.Now(),
+	}
+	if err := models.DataBase.Create(scan).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("error recording scan: %w", err)
+	}
+
+	session, err := findActiveSession(models.DataBase, table.ID)
+	if err != nil || session == nil {
+		return scan, table, nil, err
+	}
+	session, err = loadSessionWithCart(session.SessionToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return scan, table, session, nil
+}
+
+// StartSession joins the active session at the table or starts a new one
+func StartSession(qrToken string, req *dto.StartSessionRequest, info ScanInfo) (*models.QRSession, error) {
+	table, err := getQRTable(qrToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var session *models.QRSession
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		session, err = findActiveSession(tx, table.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if session == nil {
+			token, err := generateSessionToken()
+			if err != nil {
+				return err
+			}
+			guests := req.GuestCount
+			if guests < 1 {
+				guests = 1
+			}
+			session = &models.QRSession{
+				SessionToken:   token,
+				TableID:        table.ID,
+				BranchID:       table.BranchID,
+				CustomerName:   req.CustomerName,
+				CustomerPhone:  req.CustomerPhone,
+				CustomerEmail:  req.CustomerEmail,
+				GuestCount:     guests,
+				Status:         models.QRSessionActive,
+				StartedAt:      now,
+				LastActivityAt: now,
+				ExpiresAt:      now.Add(SessionTTL()),
+				IPAddress:      info.IPAddress,
+				DeviceInfo:     info.UserAgent,
+			}
+			if err := tx.Create(session).Error; err != nil {
+				return fmt.Errorf("error creating session: %w", err)
+			}
+			if table.Status == models.TableAvailable {
+				if err := tx.Model(table).Update("status", models.TableOccupied).Error; err != nil {
+					return fmt.Errorf("error updating table status: %w", err)
+				}
+			}
+		} else if err := touchSession(tx, session); err != nil {
+			return err
+		}
+
+		if req.ScanID != nil {
+			err := tx.Model(&models.QRCodeScan{}).
+				Where("id = ? AND table_id = ?", *req.ScanID, table.ID).
+				Updates(map[string]interface{}{"qr_session_id": session.ID, "converted_to_session": true}).Error
+			if err != nil {
+				return fmt.Errorf("error updating scan: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadSessionWithCart(session.SessionToken)
+}
+
+// GetSession loads an active session with its cart; viewing the session counts as activity
+func GetSession(sessionToken string) (*models.QRSession, error) {
+	session, err := loadActiveSession(models.DataBase, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := touchSession(models.DataBase, session); err != nil {
+		return nil, err
+	}
+	return session, loadCart(session)
+}
+
+func loadSessionWithCart(sessionToken string) (*models.QRSession, error) {
+	session, err := loadActiveSession(models.DataBase, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return session, loadCart(session)
+}
+
+func loadCart(session *models.QRSession) error {
+	err := models.DataBase.Preload("MenuItem").Preload("Modifiers").
+		Where("qr_session_id = ?", session.ID).
+		Order("id ASC").
+		Find(&session.CartItems).Error
+	if err != nil {
+		return fmt.Errorf("error fetching cart: %w", err)
+	}
+	return nil
+}
+
+// GetMenu returns the cached customer menu of the session's branch and its ETag
+func GetMenu(sessionToken string, filter *menu_dto.DietaryFilter) (*menu_dto.PublicMenuResponse, string, error) {
+	session, err := loadActiveSession(models.DataBase, sessionToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := touchSession(models.DataBase, session); err != nil {
+		return nil, "", err
+	}
+	return menu_services.PublicMenu(session.BranchID, filter)
+}
+
+// TableMenu returns the cached customer menu of the branch a table QR code belongs to,
+// so customers can browse before starting a session
+func TableMenu(qrToken string, filter *menu_dto.DietaryFilter) (*menu_dto.PublicMenuResponse, string, error) {
+	table, err := getQRTable(qrToken)
+	if err != nil {
+		return nil, "", err
+	}
+	return menu_services.PublicMenu(table.BranchID, filter)
+}
+
+// AddCartItem adds a menu item to the session cart; the same item with the
+// same variant, modifiers and notes is merged into one line
+func AddCartItem(sessionToken string, req *dto.AddCartItemRequest) (*models.QRSession, error) {
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		session, err := loadActiveSession(tx, sessionToken)
+		if err != nil {
+			return err
+		}
+
+		var menuItem models.MenuItem
+		err = order_services.PreloadChoices(tx).
+			Where("id = ? AND branch_id = ? AND available = ?", req.MenuItemID, session.BranchID, true).
+			First(&menuItem).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMenuItemNotOnMenu
+			}
+			return fmt.Errorf("error fetching menu item: %w", err)
+		}
+		selection, err := scheduledSelection(tx, session.BranchID, &menuItem, req.VariantID, req.ModifierOptionIDs)
+		if err != nil {
+			return err
+		}
+
+		var lines []models.QRCartItem
+		err = tx.Preload("Modifiers").
+			Where("qr_session_id = ? AND menu_item_id = ? AND notes = ?", session.ID, menuItem.ID, req.Notes).
+			Find(&lines).Error
+		if err != nil {
+			return fmt.Errorf("error fetching cart item: %w", err)
+		}
+
+		var line *models.QRCartItem
+		for i := range lines {
+			if sameSelection(&lines[i], selection) {
+				line = &lines[i]
+				break
+			}
+		}
+		if line != nil {
+			// Adding to an existing line must not get past the limit a single request has
+			if line.Quantity+req.Quantity > MaxCartQuantity {
+				return fmt.Errorf("%w: %d already in the cart", ErrCartQuantity, line.Quantity)
+			}
+			line.Quantity += req.Quantity
+		} else {
+			line = &models.QRCartItem{
+				QRSessionID: session.ID,
+				MenuItemID:  menuItem.ID,
+				Quantity:    req.Quantity,
+				Notes:       req.Notes,
+				Modifiers:   toCartModifiers(selection),
+				AddedAt:     time.Now(),
+			}
+			if selection.Variant != nil {
+				line.VariantID = &selection.Variant.ID
+				line.VariantName = selection.Variant.Name
+			}
+		}
+		line.UnitPrice = selection.UnitPrice
+		line.TotalPrice = selection.UnitPrice.Mul(line.Quantity)
+		if err := tx.Omit("QRSession", "MenuItem").Save(line).Error; err != nil {
+			return fmt.Errorf("error saving cart item: %w", err)
+		}
+		return touchSession(tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadSessionWithCart(sessionToken)
+}
+
+// UpdateCartItem changes the quantity or notes of a cart line and re-prices it
+// with the current menu
+func UpdateCartItem(sessionToken string, cartItemID uint, req *dto.UpdateCartItemRequest) (*models.QRSession, error) {
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		session, line, err := loadCartItem(tx, sessionToken, cartItemID)
+		if err != nil {
+			return err
+		}
+
+		var menuItem models.MenuItem
+		if err := order_services.PreloadChoices(tx).First(&menuItem, line.MenuItemID).Error; err != nil {
+			return fmt.Errorf("error fetching menu item: %w", err)
+		}
+		optionIDs := make([]uint, 0, len(line.Modifiers))
+		for _, m := range line.Modifiers {
+			optionIDs = append(optionIDs, m.ModifierOptionID)
+		}
+		selection, err := scheduledSelection(tx, session.BranchID, &menuItem, line.VariantID, optionIDs)
+		if err != nil {
+			return err
+		}
+
+		if req.Quantity != nil {
+			line.Quantity = *req.Quantity
+		}
+		if req.Notes != nil {
+			line.Notes = *req.Notes
+		}
+		line.UnitPrice = selection.UnitPrice
+		line.TotalPrice = selection.UnitPrice.Mul(line.Quantity)
+		if err := tx.Omit("QRSession", "MenuItem", "Modifiers").Save(line).Error; err != nil {
+			return fmt.Errorf("error saving cart item: %w", err)
+		}
+		return touchSession(tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadSessionWithCart(sessionToken)
+}
+
+// scheduledSelection resolves a cart selection priced by the branch's menu schedules.
+// QR customers can only order while the branch is open and the item is served.
+func scheduledSelection(tx *gorm.DB, branchID uint, menuItem *models.MenuItem, variantID *uint, optionIDs []uint) (*order_services.Selection, error) {
+	rules, err := schedule.LoadRules(tx, branchID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !rules.IsOpen(now) {
+		return nil, schedule.ErrBranchClosed
+	}
+	if err := rules.Check(menuItem, now); err != nil {
+		return nil, err
+	}
+	selection, err := order_services.ResolveSelection(menuItem, variantID, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+	selection.ApplySchedule(rules, menuItem, now)
+	return selection, nil
+}
+
+// RemoveCartItem deletes a cart line
+func RemoveCartItem(sessionToken string, cartItemID uint) (*models.QRSession, error) {
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		session, line, err := loadCartItem(tx, sessionToken, cartItemID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("qr_cart_item_id = ?", line.ID).Delete(&models.QRCartItemModifier{}).Error; err != nil {
+			return fmt.Errorf("error removing cart item: %w", err)
+		}
+		if err := tx.Delete(line).Error; err != nil {
+			return fmt.Errorf("error removing cart item: %w", err)
+		}
+		return touchSession(tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadSessionWithCart(sessionToken)
+}
+
+// Checkout turns the session cart into a QR order priced by the order pricing engine,
+// empties the cart and marks the session's scans as converted to an order.
+// The session stays active so the table can order another round.
+func Checkout(sessionToken string, req *dto.CheckoutRequest) (*models.Order, error) {
+	var order *models.Order
+	err := order_services.RunOrderTransaction(func(tx *gorm.DB) error {
+		session, err := loadActiveSession(tx, sessionToken)
+		if err != nil {
+			return err
+		}
+
+		var cart []models.QRCartItem
+		if err := tx.Preload("Modifiers").Where("qr_session_id = ?", session.ID).Order("id ASC").Find(&cart).Error; err != nil {
+			return fmt.Errorf("error fetching cart: %w", err)
+		}
+		if len(cart) == 0 {
+			return ErrCartEmpty
+		}
+		rules, err := schedule.LoadRules(tx, session.BranchID)
+		if err != nil {
+			return err
+		}
+		if !rules.IsOpen(time.Now()) {
+			return schedule.ErrBranchClosed
+		}
+
+		items := make([]order_dto.OrderItemInput, 0, len(cart))
+		for _, line := range cart {
+			item := order_dto.OrderItemInput{
+				MenuItemID: line.MenuItemID,
+				VariantID:  line.VariantID,
+				Quantity:   line.Quantity,
+				Notes:      line.Notes,
+			}
+			for _, m := range line.Modifiers {
+				item.ModifierOptionIDs = append(item.ModifierOptionIDs, m.ModifierOptionID)
+			}
+			items = append(items, item)
+		}
+
+		customerName := firstNonEmpty(req.CustomerName, session.CustomerName)
+		customerPhone := firstNonEmpty(req.CustomerPhone, session.CustomerPhone)
+		tableID := session.TableID
+		sessionID := session.ID
+		order, err = order_services.PlaceOrder(tx, &order_services.NewOrder{
+			BranchID:      session.BranchID,
+			TableID:       &tableID,
+			OrderType:     models.OrderTypeDineIn,
+			OrderSource:   models.OrderSourceQR,
+			QRSessionID:   &sessionID,
+			CustomerName:  customerName,
+			CustomerPhone: customerPhone,
+			CustomerEmail: session.CustomerEmail,
+			Notes:         req.Notes,
+			Items:         items,
+		})
+		if err != nil {
+			return err
+		}
+
+		cartItemIDs := tx.Model(&models.QRCartItem{}).Select("id").Where("qr_session_id = ?", session.ID)
+		if err := tx.Where("qr_cart_item_id IN (?)", cartItemIDs).Delete(&models.QRCartItemModifier{}).Error; err != nil {
+			return fmt.Errorf("error clearing cart: %w", err)
+		}
+		if err := tx.Where("qr_session_id = ?", session.ID).Delete(&models.QRCartItem{}).Error; err != nil {
+			return fmt.Errorf("error clearing cart: %w", err)
+		}
+		if err := tx.Model(&models.QRCodeScan{}).
+			Where("qr_session_id = ?", session.ID).
+			Update("converted_to_order", true).Error; err != nil {
+			return fmt.Errorf("error updating scans: %w", err)
+		}
+
+		orderID := order.ID
+		notification := &models.Notification{
+			BranchID:    session.BranchID,
+			Type:        models.NotificationNewOrder,
+			Title:       "New QR order",
+			Message:     fmt.Sprintf("Order %s placed from table QR", order.OrderNumber),
+			Data:        "{}",
+			OrderID:     &orderID,
+			TableID:     &tableID,
+			QRSessionID: &sessionID,
+		}
+		if err := tx.Create(notification).Error; err != nil {
+			return fmt.Errorf("error creating notification: %w", err)
+		}
+		return touchSession(tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	realtime.Publish(realtime.Event{Type: realtime.EventOrderCreated, BranchID: order.BranchID, OrderID: order.ID})
+	return order_services.GetOrderByID(order.ID)
+}
+
+// SessionTTL returns how long a QR session stays valid after the last customer activity (default 2h)
+func SessionTTL() time.Duration {
+	if config.EnvConfig == nil {
+		return 2 * time.Hour
+	}
+	return parseDuration(config.EnvConfig.QRSessionTTL, 2*time.Hour)
+}
+
+// SessionMaxDuration caps how far sliding expiry may extend a session past its start (default 8h)
+func SessionMaxDuration() time.Duration {
+	if config.EnvConfig == nil {
+		return 8 * time.Hour
+	}
+	return parseDuration(config.EnvConfig.QRSessionMaxDuration, 8*time.Hour)
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+func getQRTable(qrToken string) (*models.Table, error) {
+	var table models.Table
+	err := models.DataBase.Where("qr_token = ? AND is_qr_active = ?", qrToken, true).First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTableNotFound
+		}
+		return nil, fmt.Errorf("error fetching table: %w", err)
+	}
+	return &table, nil
+}
+
+// findActiveSession returns the running session at a table, or nil
+func findActiveSession(tx *gorm.DB, tableID uint) (*models.QRSession, error) {
+	var session models.QRSession
+	err := tx.Where("table_id = ? AND status = ? AND expires_at > ?", tableID, models.QRSessionActive, time.Now()).
+		Order("started_at DESC").
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error fetching session: %w", err)
+	}
+	return &session, nil
+}
+
+func loadActiveSession(tx *gorm.DB, sessionToken string) (*models.QRSession, error) {
+	var session models.QRSession
+	if err := tx.Where("session_token = ?", sessionToken).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("error fetching session: %w", err)
+	}
+	if session.Status != models.QRSessionActive || time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+	return &session, nil
+}
+
+func loadCartItem(tx *gorm.DB, sessionToken string, cartItemID uint) (*models.QRSession, *models.QRCartItem, error) {
+	session, err := loadActiveSession(tx, sessionToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	var line models.QRCartItem
+	if err := tx.Preload("Modifiers").Where("id = ? AND qr_session_id = ?", cartItemID, session.ID).First(&line).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCartItemNotFound
+		}
+		return nil, nil, fmt.Errorf("error fetching cart item: %w", err)
+	}
+	return session, &line, nil
+}
+
+// touchSession records customer activity and slides the session expiry forward,
+// never past the maximum session duration
+func touchSession(tx *gorm.DB, session *models.QRSession) error {
+	now := time.Now()
+	expiresAt := now.Add(SessionTTL())
+	if limit := session.StartedAt.Add(SessionMaxDuration()); expiresAt.After(limit) {
+		expiresAt = limit
+	}
+	if expiresAt.Before(session.ExpiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+
+	err := tx.Model(session).Updates(map[string]interface{}{
+		"last_activity_at": now,
+		"expires_at":       expiresAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("error updating session activity: %w", err)
+	}
+	session.LastActivityAt = now
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func generateSessionToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating session token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// detectDeviceType makes a coarse guess from the user agent: mobile, tablet or desktop
+func detectDeviceType(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet"):
+		return "tablet"
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "android") || strings.Contains(ua, "iphone"):
+		return "mobile"
+	default:
+		return "desktop"
+	}
+}
+
+// sameSelection reports whether a cart line has the chosen variant and modifier options
+func sameSelection(line *models.QRCartItem, selection *order_services.Selection) bool {
+	variantID := uint(0)
+	if selection.Variant != nil {
+		variantID = selection.Variant.ID
+	}
+	if (line.VariantID == nil && variantID != 0) || (line.VariantID != nil && *line.VariantID != variantID) {
+		return false
+	}
+	if len(line.Modifiers) != len(selection.Modifiers) {
+		return false
+	}
+	chosen := make(map[uint]bool, len(selection.Modifiers))
+	for _, id := range selection.OptionIDs() {
+		chosen[id] = true
+	}
+	for _, m := range line.Modifiers {
+		if !chosen[m.ModifierOptionID] {
+			return false
+		}
+	}
+	return true
+}
+
+func toCartModifiers(selection *order_services.Selection) []models.QRCartItemModifier {
+	modifiers := make([]models.QRCartItemModifier, 0, len(selection.Modifiers))
+	for _, m := range selection.Modifiers {
+		modifiers = append(modifiers, models.QRCartItemModifier{
+			ModifierOptionID: m.OptionID,
+			GroupName:        m.GroupName,
+			Name:             m.Name,
+			PriceDelta:       m.PriceDelta,
+		})
+	}
+	return modifiers
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
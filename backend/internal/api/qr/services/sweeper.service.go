@@ -0,0 +1,201 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SweepResult counts the sessions closed by a sweep
+type SweepResult struct {
+	Abandoned   int
+	Expired     int
+	TablesFreed int
+}
+
+// SessionIdleTimeout returns how long a session with items in its cart may sit idle
+// before it is marked abandoned (default 30m)
+func SessionIdleTimeout() time.Duration {
+	if config.EnvConfig == nil {
+		return 30 * time.Minute
+	}
+	return parseDuration(config.EnvConfig.QRSessionIdleTimeout, 30*time.Minute)
+}
+
+// SessionSweepInterval returns how often the session sweeper runs (default 1m)
+func SessionSweepInterval() time.Duration {
+	if config.EnvConfig == nil {
+		return time.Minute
+	}
+	return parseDuration(config.EnvConfig.QRSessionSweepInterval, time.Minute)
+}
+
+// SweepSessions closes stale QR sessions:
+//   - idle sessions with a non-empty cart become ABANDONED
+//   - sessions past ExpiresAt become EXPIRED
+//
+// A closed session's table is set back to AVAILABLE when nothing else keeps it occupied,
+// and a QR_SESSION notification is raised for the branch. Sessions that fail to close
+// are logged and left for the next sweep.
+func SweepSessions(now time.Time) (*SweepResult, error) {
+	result := &SweepResult{}
+
+	var abandoned []models.QRSession
+	err := models.DataBase.
+		Where("status = ? AND last_activity_at < ?", models.QRSessionActive, now.Add(-SessionIdleTimeout())).
+		Where("EXISTS (SELECT 1 FROM qr_cart_items WHERE qr_cart_items.qr_session_id = qr_sessions.id)").
+		Find(&abandoned).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching idle sessions: %w", err)
+	}
+	for i := range abandoned {
+		closed, freed, err := closeSession(&abandoned[i], models.QRSessionAbandoned, now)
+		if err != nil {
+			// One failing session must not hold back the rest of the sweep
+			log.Printf("qr sessions: session %d: %v", abandoned[i].ID, err)
+			continue
+		}
+		if closed {
+			result.Abandoned++
+		}
+		if freed {
+			result.TablesFreed++
+		}
+	}
+
+	var expired []models.QRSession
+	err = models.DataBase.
+		Where("status = ? AND expires_at <= ?", models.QRSessionActive, now).
+		Find(&expired).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching expired sessions: %w", err)
+	}
+	for i := range expired {
+		closed, freed, err := closeSession(&expired[i], models.QRSessionExpired, now)
+		if err != nil {
+			// One failing session must not hold back the rest of the sweep
+			log.Printf("qr sessions: session %d: %v", expired[i].ID, err)
+			continue
+		}
+		if closed {
+			result.Expired++
+		}
+		if freed {
+			result.TablesFreed++
+		}
+	}
+
+	return result, nil
+}
+
+// RunSessionSweep is the scheduled job entry point
+func RunSessionSweep() error {
+	result, err := SweepSessions(time.Now())
+	if err != nil {
+		return err
+	}
+	if result.Abandoned > 0 || result.Expired > 0 {
+		log.Printf("qr sessions: %d abandoned, %d expired, %d tables freed", result.Abandoned, result.Expired, result.TablesFreed)
+	}
+	return nil
+}
+
+// closeSession moves an active session to a closed status. The update is conditional on
+// the session still qualifying, so customer activity that raced the sweep wins.
+func closeSession(session *models.QRSession, status models.QRSessionStatus, now time.Time) (closed bool, freed bool, err error) {
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		update := tx.Model(&models.QRSession{}).Where("id = ? AND status = ?", session.ID, models.QRSessionActive)
+		if status == models.QRSessionAbandoned {
+			update = update.Where("last_activity_at < ?", now.Add(-SessionIdleTimeout()))
+		} else {
+			update = update.Where("expires_at <= ?", now)
+		}
+		res := update.Update("status", status)
+		if res.Error != nil {
+			return fmt.Errorf("error closing session: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		closed = true
+
+		var cartItems int64
+		if err := tx.Model(&models.QRCartItem{}).Where("qr_session_id = ?", session.ID).Count(&cartItems).Error; err != nil {
+			return fmt.Errorf("error counting cart items: %w", err)
+		}
+
+		freed, err = releaseTable(tx, session.TableID)
+		if err != nil {
+			return err
+		}
+
+		tableLabel := fmt.Sprint(session.TableID)
+		var table models.Table
+		if err := tx.Select("id", "number").First(&table, session.TableID).Error; err == nil {
+			tableLabel = table.Number
+		}
+
+		title := "QR session expired"
+		message := fmt.Sprintf("QR session at table %s expired", tableLabel)
+		if status == models.QRSessionAbandoned {
+			title = "QR cart abandoned"
+			message = fmt.Sprintf("QR session at table %s was abandoned with %d item(s) in the cart", tableLabel, cartItems)
+		} else if cartItems > 0 {
+			message = fmt.Sprintf("%s with %d item(s) left in the cart", message, cartItems)
+		}
+
+		tableID := session.TableID
+		sessionID := session.ID
+		notification := &models.Notification{
+			BranchID:    session.BranchID,
+			Type:        models.NotificationQRSession,
+			Title:       title,
+			Message:     message,
+			Data:        fmt.Sprintf(`{"status":%q,"cart_items":%d,"table_freed":%t}`, status, cartItems, freed),
+			TableID:     &tableID,
+			QRSessionID: &sessionID,
+		}
+		if err := tx.Create(notification).Error; err != nil {
+			return fmt.Errorf("error creating notification: %w", err)
+		}
+		return nil
+	})
+	return closed, freed, err
+}
+
+// releaseTable sets an occupied table back to AVAILABLE when it has no other active
+// session and no open orders
+func releaseTable(tx *gorm.DB, tableID uint) (bool, error) {
+	var activeSessions int64
+	if err := tx.Model(&models.QRSession{}).
+		Where("table_id = ? AND status = ?", tableID, models.QRSessionActive).
+		Count(&activeSessions).Error; err != nil {
+		return false, fmt.Errorf("error counting table sessions: %w", err)
+	}
+	if activeSessions > 0 {
+		return false, nil
+	}
+
+	var openOrders int64
+	if err := tx.Model(&models.Order{}).
+		Where("table_id = ? AND status NOT IN ?", tableID,
+			[]models.OrderStatus{models.OrderCompleted, models.OrderCancelled, models.OrderRefunded}).
+		Count(&openOrders).Error; err != nil {
+		return false, fmt.Errorf("error counting table orders: %w", err)
+	}
+	if openOrders > 0 {
+		return false, nil
+	}
+
+	res := tx.Model(&models.Table{}).
+		Where("id = ? AND status = ?", tableID, models.TableOccupied).
+		Update("status", models.TableAvailable)
+	if res.Error != nil {
+		return false, fmt.Errorf("error releasing table: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
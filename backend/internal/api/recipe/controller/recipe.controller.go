@@ -0,0 +1,293 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	recipe_dto "restaurant_os/internal/api/recipe/dto"
+	recipe_services "restaurant_os/internal/api/recipe/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/stock"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type recipeController struct{}
+
+var validate = validator.New()
+
+func NewRecipeController() *recipeController {
+	return &recipeController{}
+}
+
+func (rc *recipeController) GetItemRecipe(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	cost, err := recipe_services.GetItemRecipe(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return recipeErrorResponse(c, err, "Failed to fetch recipe")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Recipe fetched successfully",
+		Data:    toItemCostResponse(cost, true),
+	})
+}
+
+func (rc *recipeController) UpdateItemRecipe(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid menu item ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req recipe_dto.UpdateRecipeRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, recipe_dto.RecipeValidationErrorMessages)
+	}
+
+	cost, err := recipe_services.SetItemRecipe(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return recipeErrorResponse(c, err, "Failed to update recipe")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Recipe updated successfully",
+		Data:    toItemCostResponse(cost, true),
+	})
+}
+
+func (rc *recipeController) GetModifierRecipe(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid modifier option ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	cost, err := recipe_services.GetModifierRecipe(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return recipeErrorResponse(c, err, "Failed to fetch recipe")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Recipe fetched successfully",
+		Data:    toModifierRecipeResponse(cost),
+	})
+}
+
+func (rc *recipeController) UpdateModifierRecipe(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid modifier option ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req recipe_dto.UpdateRecipeRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, recipe_dto.RecipeValidationErrorMessages)
+	}
+
+	cost, err := recipe_services.SetModifierRecipe(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return recipeErrorResponse(c, err, "Failed to update recipe")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Recipe updated successfully",
+		Data:    toModifierRecipeResponse(cost),
+	})
+}
+
+func (rc *recipeController) GetFoodCost(c *fiber.Ctx) error {
+	var query recipe_dto.FoodCostQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	report, err := recipe_services.FoodCostReport(&query, middleware.GetClaims(c))
+	if err != nil {
+		return recipeErrorResponse(c, err, "Failed to fetch food cost report")
+	}
+
+	data := make([]recipe_dto.ItemCostResponse, 0, len(report))
+	for i := range report {
+		data = append(data, toItemCostResponse(&report[i], false))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Food cost report fetched successfully",
+		Data:    data,
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// recipeErrorResponse maps recipe service errors to HTTP status codes
+func recipeErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, recipe_services.ErrMenuItemNotFound), errors.Is(err, recipe_services.ErrModifierOptionNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, recipe_services.ErrInvalidIngredient):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toItemCostResponse(cost *recipe_services.ItemCost, withLines bool) recipe_dto.ItemCostResponse {
+	item := &cost.Item
+	resp := recipe_dto.ItemCostResponse{
+		MenuItemID:      item.ID,
+		BranchID:        item.BranchID,
+		Name:            item.Name,
+		Price:           item.Price,
+		CostPrice:       item.CostPrice,
+		HasRecipe:       len(cost.Lines) > 0,
+		TheoreticalCost: cost.Cost,
+	}
+	if resp.HasRecipe {
+		variance := cost.Cost.Sub(item.CostPrice)
+		resp.CostVariance = &variance
+		resp.FoodCostPercent = foodCostPercent(cost.Cost, item.Price)
+	}
+	if item.Category != nil {
+		resp.CategoryName = item.Category.Name
+	}
+	for _, v := range item.Variants {
+		variant := recipe_dto.VariantCostResponse{
+			VariantID:       v.ID,
+			Name:            v.Name,
+			Price:           v.Price,
+			TheoreticalCost: cost.VariantCosts[v.ID],
+		}
+		if resp.HasRecipe {
+			variant.FoodCostPercent = foodCostPercent(variant.TheoreticalCost, v.Price)
+		}
+		resp.Variants = append(resp.Variants, variant)
+	}
+	if withLines {
+		resp.Ingredients = toIngredientResponses(cost.Lines)
+	}
+	return resp
+}
+
+func toModifierRecipeResponse(cost *recipe_services.ModifierCost) recipe_dto.ModifierRecipeResponse {
+	return recipe_dto.ModifierRecipeResponse{
+		ModifierOptionID: cost.Option.ID,
+		Name:             cost.Option.Name,
+		GroupName:        cost.GroupName,
+		MenuItemID:       cost.Item.ID,
+		PriceDelta:       cost.Option.PriceDelta,
+		TheoreticalCost:  cost.Cost,
+		Ingredients:      toIngredientResponses(cost.Lines),
+	}
+}
+
+func toIngredientResponses(lines []models.RecipeIngredient) []recipe_dto.RecipeIngredientResponse {
+	resp := make([]recipe_dto.RecipeIngredientResponse, 0, len(lines))
+	for i := range lines {
+		line := &lines[i]
+		// Units are checked when the recipe is saved
+		cost, _ := stock.Cost(lines[i : i+1])
+		ingredient := recipe_dto.RecipeIngredientResponse{
+			ID:            line.ID,
+			InventoryID:   line.InventoryID,
+			InventoryName: line.Inventory.ItemName,
+			VariantID:     line.VariantID,
+			Quantity:      line.Quantity,
+			Unit:          string(line.Unit),
+			StockUnit:     string(line.Inventory.Unit),
+			UnitCost:      line.Inventory.UnitCost,
+			Cost:          cost,
+		}
+		if line.Variant != nil {
+			ingredient.VariantName = line.Variant.Name
+		}
+		resp = append(resp, ingredient)
+	}
+	return resp
+}
+
+// foodCostPercent returns cost as a percentage of price, rounded to two decimals
+func foodCostPercent(cost, price money.Money) *float64 {
+	if !price.IsPositive() {
+		return nil
+	}
+	percent := math.Round(cost.Float64()/price.Float64()*10000) / 100
+	return &percent
+}
@@ -0,0 +1,87 @@
+package dto
+
+import "restaurant_os/internal/money"
+
+// ============================================================================
+// RECIPE REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// RecipeIngredientInput is the quantity of an inventory item used by one portion
+type RecipeIngredientInput struct {
+	InventoryID uint    `json:"inventory_id" validate:"required"`
+	VariantID   *uint   `json:"variant_id,omitempty"` // Menu item recipes only: the line is only used for this variant
+	Quantity    float64 `json:"quantity" validate:"required,gt=0"`
+	Unit        string  `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Defaults to the inventory unit
+}
+
+// UpdateRecipeRequest replaces the recipe of a menu item or modifier option; an
+// empty list removes it
+type UpdateRecipeRequest struct {
+	Ingredients []RecipeIngredientInput `json:"ingredients" validate:"max=50,dive"`
+}
+
+// RecipeValidationErrorMessages maps recipe request fields to custom messages
+var RecipeValidationErrorMessages = map[string]string{
+	"Ingredients": "At most 50 ingredients are allowed.",
+	"InventoryID": "Each ingredient needs an inventory_id.",
+	"Quantity":    "Each ingredient needs a quantity greater than 0.",
+	"Unit":        "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+}
+
+// FoodCostQuery represents filters for the food cost report
+type FoodCostQuery struct {
+	BranchID   *uint `query:"branch_id"`
+	CategoryID *uint `query:"category_id"`
+}
+
+// RecipeIngredientResponse represents one line of a recipe with its cost per portion
+type RecipeIngredientResponse struct {
+	ID            uint        `json:"id"`
+	InventoryID   uint        `json:"inventory_id"`
+	InventoryName string      `json:"inventory_name"`
+	VariantID     *uint       `json:"variant_id,omitempty"`
+	VariantName   string      `json:"variant_name,omitempty"`
+	Quantity      float64     `json:"quantity"`
+	Unit          string      `json:"unit"`
+	StockUnit     string      `json:"stock_unit"` // Unit the inventory item is stocked and costed in
+	UnitCost      money.Money `json:"unit_cost"`  // Per stock unit
+	Cost          money.Money `json:"cost"`
+}
+
+// VariantCostResponse represents the theoretical cost of one variant of a menu item
+type VariantCostResponse struct {
+	VariantID       uint        `json:"variant_id"`
+	Name            string      `json:"name"`
+	Price           money.Money `json:"price"`
+	TheoreticalCost money.Money `json:"theoretical_cost"`
+	FoodCostPercent *float64    `json:"food_cost_percent,omitempty"`
+}
+
+// ItemCostResponse represents the theoretical food cost of a menu item compared
+// with the cost price entered on the item
+type ItemCostResponse struct {
+	MenuItemID      uint                       `json:"menu_item_id"`
+	BranchID        uint                       `json:"branch_id"`
+	Name            string                     `json:"name"`
+	CategoryName    string                     `json:"category_name,omitempty"`
+	Price           money.Money                `json:"price"`
+	CostPrice       money.Money                `json:"cost_price"`
+	HasRecipe       bool                       `json:"has_recipe"`
+	TheoreticalCost money.Money                `json:"theoretical_cost"`        // Shared lines, without variant lines
+	CostVariance    *money.Money               `json:"cost_variance,omitempty"` // Theoretical cost minus cost price, when there is a recipe
+	FoodCostPercent *float64                   `json:"food_cost_percent,omitempty"`
+	Variants        []VariantCostResponse      `json:"variants,omitempty"`
+	Ingredients     []RecipeIngredientResponse `json:"ingredients,omitempty"`
+}
+
+// ModifierRecipeResponse represents the recipe of a modifier option, used on top
+// of the item's recipe whenever the option is picked
+type ModifierRecipeResponse struct {
+	ModifierOptionID uint                       `json:"modifier_option_id"`
+	Name             string                     `json:"name"`
+	GroupName        string                     `json:"group_name"`
+	MenuItemID       uint                       `json:"menu_item_id"`
+	PriceDelta       money.Money                `json:"price_delta"`
+	TheoreticalCost  money.Money                `json:"theoretical_cost"`
+	Ingredients      []RecipeIngredientResponse `json:"ingredients"`
+}
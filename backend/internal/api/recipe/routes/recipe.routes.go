@@ -0,0 +1,22 @@
+package routes
+
+import (
+	recipe_controller "restaurant_os/internal/api/recipe/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRecipeRoutes(api fiber.Router) {
+
+	recipes := api.Group("/recipes", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"))
+
+	recipeHandler := recipe_controller.NewRecipeController()
+
+	recipes.Get("/items/:id", recipeHandler.GetItemRecipe)
+	recipes.Put("/items/:id", recipeHandler.UpdateItemRecipe)
+	recipes.Get("/modifier-options/:id", recipeHandler.GetModifierRecipe)
+	recipes.Put("/modifier-options/:id", recipeHandler.UpdateModifierRecipe)
+	// Food cost compares against cost prices, which only supervisors see
+	recipes.Get("/food-cost", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), recipeHandler.GetFoodCost)
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/recipe/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/stock"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMenuItemNotFound       = errors.New("menu item not found")
+	ErrModifierOptionNotFound = errors.New("modifier option not found")
+	ErrInvalidIngredient      = errors.New("invalid recipe ingredient")
+)
+
+// ItemCost is a menu item with its recipe and theoretical cost per portion
+type ItemCost struct {
+	Item         models.MenuItem
+	Lines        []models.RecipeIngredient
+	Cost         money.Money          // Shared lines only
+	VariantCosts map[uint]money.Money // Shared lines plus the variant's own lines
+}
+
+// ModifierCost is a modifier option with its recipe and theoretical cost per pick
+type ModifierCost struct {
+	Option    models.ModifierOption
+	GroupName string
+	Item      models.MenuItem
+	Lines     []models.RecipeIngredient
+	Cost      money.Money
+}
+
+// GetItemRecipe returns the recipe and theoretical cost of a menu item
+func GetItemRecipe(id uint, claims *common_dto.Claims) (*ItemCost, error) {
+	item, err := loadItem(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	var lines []models.RecipeIngredient
+	err = preloadLines(models.DataBase).Where("menu_item_id = ?", item.ID).Order("sort_order ASC, id ASC").Find(&lines).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching recipe: %w", err)
+	}
+	return costItem(item, lines)
+}
+
+// SetItemRecipe replaces the recipe of a menu item
+func SetItemRecipe(id uint, req *dto.UpdateRecipeRequest, claims *common_dto.Claims) (*ItemCost, error) {
+	item, err := loadItem(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := buildLines(item, req.Ingredients, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].MenuItemID = &item.ID
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		return replaceLines(tx, tx.Where("menu_item_id = ?", item.ID), lines)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetItemRecipe(item.ID, claims)
+}
+
+// GetModifierRecipe returns the recipe and theoretical cost of a modifier option
+func GetModifierRecipe(id uint, claims *common_dto.Claims) (*ModifierCost, error) {
+	modifier, err := loadModifier(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	err = preloadLines(models.DataBase).Where("modifier_option_id = ?", id).Order("sort_order ASC, id ASC").Find(&modifier.Lines).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching recipe: %w", err)
+	}
+	if modifier.Cost, err = stock.Cost(modifier.Lines); err != nil {
+		return nil, err
+	}
+	return modifier, nil
+}
+
+// SetModifierRecipe replaces the recipe of a modifier option
+func SetModifierRecipe(id uint, req *dto.UpdateRecipeRequest, claims *common_dto.Claims) (*ModifierCost, error) {
+	modifier, err := loadModifier(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := buildLines(&modifier.Item, req.Ingredients, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].ModifierOptionID = &modifier.Option.ID
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		return replaceLines(tx, tx.Where("modifier_option_id = ?", modifier.Option.ID), lines)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetModifierRecipe(modifier.Option.ID, claims)
+}
+
+// FoodCostReport returns the theoretical cost of every menu item visible to the
+// requester, with or without a recipe
+func FoodCostReport(query *dto.FoodCostQuery, claims *common_dto.Claims) ([]ItemCost, error) {
+	db := claims.ScopeBranches(models.DataBase.Model(&models.MenuItem{}), "branch_id").
+		Preload("Category").
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") })
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.CategoryID != nil {
+		db = db.Where("category_id = ?", *query.CategoryID)
+	}
+	var items []models.MenuItem
+	if err := db.Order("branch_id ASC, sort_order ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("error fetching menu items: %w", err)
+	}
+	if len(items) == 0 {
+		return []ItemCost{}, nil
+	}
+
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	var lines []models.RecipeIngredient
+	err := preloadLines(models.DataBase).Where("menu_item_id IN ?", ids).Order("sort_order ASC, id ASC").Find(&lines).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching recipes: %w", err)
+	}
+	byItem := map[uint][]models.RecipeIngredient{}
+	for _, line := range lines {
+		byItem[*line.MenuItemID] = append(byItem[*line.MenuItemID], line)
+	}
+
+	report := make([]ItemCost, 0, len(items))
+	for i := range items {
+		cost, err := costItem(&items[i], byItem[items[i].ID])
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, *cost)
+	}
+	return report, nil
+}
+
+// costItem prices the shared recipe lines and each variant's full recipe
+func costItem(item *models.MenuItem, lines []models.RecipeIngredient) (*ItemCost, error) {
+	var shared []models.RecipeIngredient
+	for _, line := range lines {
+		if line.VariantID == nil {
+			shared = append(shared, line)
+		}
+	}
+	cost := &ItemCost{Item: *item, Lines: lines, VariantCosts: map[uint]money.Money{}}
+	var err error
+	if cost.Cost, err = stock.Cost(shared); err != nil {
+		return nil, err
+	}
+	for _, v := range item.Variants {
+		variantLines := shared[:len(shared):len(shared)]
+		for _, line := range lines {
+			if line.VariantID != nil && *line.VariantID == v.ID {
+				variantLines = append(variantLines, line)
+			}
+		}
+		if cost.VariantCosts[v.ID], err = stock.Cost(variantLines); err != nil {
+			return nil, err
+		}
+	}
+	return cost, nil
+}
+
+// buildLines checks recipe input against the menu item's branch inventory and
+// variants, defaulting each unit to the inventory unit
+func buildLines(item *models.MenuItem, inputs []dto.RecipeIngredientInput, allowVariants bool) ([]models.RecipeIngredient, error) {
+	ids := make([]uint, 0, len(inputs))
+	for _, input := range inputs {
+		ids = append(ids, input.InventoryID)
+	}
+	var inventory []models.Inventory
+	if len(ids) > 0 {
+		if err := models.DataBase.Preload("Conversions").Where("branch_id = ? AND id IN ?", item.BranchID, ids).Find(&inventory).Error; err != nil {
+			return nil, fmt.Errorf("error fetching inventory: %w", err)
+		}
+	}
+	byID := make(map[uint]*models.Inventory, len(inventory))
+	for i := range inventory {
+		byID[inventory[i].ID] = &inventory[i]
+	}
+
+	lines := make([]models.RecipeIngredient, 0, len(inputs))
+	for i, input := range inputs {
+		stocked, ok := byID[input.InventoryID]
+		if !ok {
+			return nil, fmt.Errorf("%w: inventory item %d is not stocked by the menu item's branch", ErrInvalidIngredient, input.InventoryID)
+		}
+		unit := stocked.Unit
+		if input.Unit != "" {
+			unit = models.InventoryUnit(input.Unit)
+		}
+		if _, err := stocked.ToStockUnit(input.Quantity, unit); err != nil {
+			return nil, fmt.Errorf("%w: %s is stocked in %s: %v", ErrInvalidIngredient, stocked.ItemName, stocked.Unit, err)
+		}
+		if input.VariantID != nil {
+			if !allowVariants {
+				return nil, fmt.Errorf("%w: modifier recipes cannot be limited to a variant", ErrInvalidIngredient)
+			}
+			if !hasVariant(item, *input.VariantID) {
+				return nil, fmt.Errorf("%w: variant %d is not a variant of %s", ErrInvalidIngredient, *input.VariantID, item.Name)
+			}
+		}
+		lines = append(lines, models.RecipeIngredient{
+			VariantID:   input.VariantID,
+			InventoryID: stocked.ID,
+			Quantity:    input.Quantity,
+			Unit:        unit,
+			SortOrder:   i,
+		})
+	}
+	return lines, nil
+}
+
+// replaceLines deletes the recipe lines matched by existing and creates the new ones
+func replaceLines(tx *gorm.DB, existing *gorm.DB, lines []models.RecipeIngredient) error {
+	if err := existing.Delete(&models.RecipeIngredient{}).Error; err != nil {
+		return fmt.Errorf("error removing recipe: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := tx.Omit("Variant", "Inventory").Create(&lines).Error; err != nil {
+		return fmt.Errorf("error creating recipe: %w", err)
+	}
+	return nil
+}
+
+// loadItem returns a menu item with its variants if the requester can access its branch
+func loadItem(id uint, claims *common_dto.Claims) (*models.MenuItem, error) {
+	var item models.MenuItem
+	err := models.DataBase.Preload("Branch").Preload("Category").
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		First(&item, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMenuItemNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu item: %w", err)
+	}
+	if !claims.CanAccessBranch(item.BranchID, item.Branch.RestaurantID) {
+		return nil, ErrMenuItemNotFound
+	}
+	return &item, nil
+}
+
+// loadModifier returns a modifier option with its group name and menu item
+func loadModifier(id uint, claims *common_dto.Claims) (*ModifierCost, error) {
+	var option models.ModifierOption
+	if err := models.DataBase.First(&option, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrModifierOptionNotFound
+		}
+		return nil, fmt.Errorf("error fetching modifier option: %w", err)
+	}
+	var group models.ModifierGroup
+	if err := models.DataBase.First(&group, option.ModifierGroupID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrModifierOptionNotFound
+		}
+		return nil, fmt.Errorf("error fetching modifier group: %w", err)
+	}
+	item, err := loadItem(group.MenuItemID, claims)
+	if err != nil {
+		if errors.Is(err, ErrMenuItemNotFound) {
+			return nil, ErrModifierOptionNotFound
+		}
+		return nil, err
+	}
+	return &ModifierCost{Option: option, GroupName: group.Name, Item: *item}, nil
+}
+
+func preloadLines(db *gorm.DB) *gorm.DB {
+	return db.Preload("Inventory").Preload("Inventory.Conversions").Preload("Variant")
+}
+
+func hasVariant(item *models.MenuItem, variantID uint) bool {
+	for _, v := range item.Variants {
+		if v.ID == variantID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	tax_dto "restaurant_os/internal/api/tax/dto"
+	tax_services "restaurant_os/internal/api/tax/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type taxController struct{}
+
+var validate = validator.New()
+
+func NewTaxController() *taxController {
+	return &taxController{}
+}
+
+func (tc *taxController) GetTaxRules(c *fiber.Ctx) error {
+	var query tax_dto.TaxRuleListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	rules, err := tax_services.ListTaxRules(&query, middleware.GetClaims(c))
+	if err != nil {
+		return taxErrorResponse(c, err, "Failed to fetch tax rules")
+	}
+
+	data := make([]tax_dto.TaxRuleResponse, 0, len(rules))
+	for i := range rules {
+		data = append(data, toTaxRuleResponse(&rules[i]))
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tax rules fetched successfully",
+		Data:    data,
+	})
+}
+
+func (tc *taxController) GetTaxRule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid tax rule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	rule, err := tax_services.GetTaxRule(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return taxErrorResponse(c, err, "Failed to fetch tax rule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tax rule fetched successfully",
+		Data:    toTaxRuleResponse(rule),
+	})
+}
+
+func (tc *taxController) CreateTaxRule(c *fiber.Ctx) error {
+	var req tax_dto.CreateTaxRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, tax_dto.TaxRuleValidationErrorMessages)
+	}
+
+	rule, err := tax_services.CreateTaxRule(&req, middleware.GetClaims(c))
+	if err != nil {
+		return taxErrorResponse(c, err, "Failed to create tax rule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tax rule created successfully",
+		Data:    toTaxRuleResponse(rule),
+	})
+}
+
+func (tc *taxController) UpdateTaxRule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid tax rule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req tax_dto.UpdateTaxRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, tax_dto.TaxRuleValidationErrorMessages)
+	}
+
+	rule, err := tax_services.UpdateTaxRule(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return taxErrorResponse(c, err, "Failed to update tax rule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tax rule updated successfully",
+		Data:    toTaxRuleResponse(rule),
+	})
+}
+
+func (tc *taxController) DeleteTaxRule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid tax rule ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	if err := tax_services.DeleteTaxRule(uint(id), middleware.GetClaims(c)); err != nil {
+		return taxErrorResponse(c, err, "Failed to delete tax rule")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Tax rule deleted successfully",
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// taxErrorResponse maps tax service errors to HTTP status codes
+func taxErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, tax_services.ErrTaxRuleNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, tax_services.ErrOutsideScope):
+		status = fiber.StatusForbidden
+	case errors.Is(err, tax_services.ErrRestaurantRequired), errors.Is(err, tax_services.ErrInvalidBranch),
+		errors.Is(err, tax_services.ErrInvalidCategory), errors.Is(err, tax_services.ErrInvalidMenuItem),
+		errors.Is(err, tax_services.ErrServiceChargeScope):
+		status = fiber.StatusUnprocessableEntity
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toTaxRuleResponse(rule *models.TaxRule) tax_dto.TaxRuleResponse {
+	components := make([]tax_dto.TaxComponentResponse, 0, len(rule.Components))
+	for _, c := range rule.Components {
+		components = append(components, tax_dto.TaxComponentResponse{
+			ID:   c.ID,
+			Name: c.Name,
+			Rate: c.Rate,
+		})
+	}
+
+	exempt := []string{}
+	if rule.ExemptOrderTypes != "" {
+		exempt = strings.Split(rule.ExemptOrderTypes, ",")
+	}
+
+	return tax_dto.TaxRuleResponse{
+		ID:               rule.ID,
+		RestaurantID:     rule.RestaurantID,
+		BranchID:         rule.BranchID,
+		CategoryID:       rule.CategoryID,
+		MenuItemID:       rule.MenuItemID,
+		Name:             rule.Name,
+		IsInclusive:      rule.IsInclusive,
+		TaxServiceCharge: rule.TaxServiceCharge,
+		ExemptOrderTypes: exempt,
+		IsActive:         rule.IsActive,
+		TotalRate:        rule.TotalRate(),
+		Components:       components,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// TAX RULE REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// TaxComponentInput represents one component of a tax rule, e.g. CGST at 9%
+type TaxComponentInput struct {
+	Name string  `json:"name" validate:"required,max=50"`
+	Rate float64 `json:"rate" validate:"min=0,max=100"`
+}
+
+// CreateTaxRuleRequest represents create tax rule request.
+// A rule is scoped to the restaurant, optionally narrowed to a branch and to one
+// menu category or menu item.
+type CreateTaxRuleRequest struct {
+	RestaurantID     *uint               `json:"restaurant_id,omitempty"` // Super admin only; others use their own restaurant
+	BranchID         *uint               `json:"branch_id,omitempty"`
+	CategoryID       *uint               `json:"category_id,omitempty" validate:"excluded_with=MenuItemID"`
+	MenuItemID       *uint               `json:"menu_item_id,omitempty"`
+	Name             string              `json:"name" validate:"required,max=100"`
+	IsInclusive      bool                `json:"is_inclusive"`
+	TaxServiceCharge bool                `json:"tax_service_charge"`
+	ExemptOrderTypes []string            `json:"exempt_order_types,omitempty" validate:"omitempty,dive,oneof=DINE_IN TAKEAWAY DELIVERY ONLINE"`
+	IsActive         *bool               `json:"is_active,omitempty"`
+	Components       []TaxComponentInput `json:"components" validate:"required,min=1,max=5,dive"`
+}
+
+// UpdateTaxRuleRequest represents update tax rule request. The scope of a rule
+// (restaurant, branch, category, item) cannot change; create a new rule instead.
+type UpdateTaxRuleRequest struct {
+	Name             *string             `json:"name,omitempty" validate:"omitempty,max=100"`
+	IsInclusive      *bool               `json:"is_inclusive,omitempty"`
+	TaxServiceCharge *bool               `json:"tax_service_charge,omitempty"`
+	ExemptOrderTypes *[]string           `json:"exempt_order_types,omitempty" validate:"omitempty,dive,oneof=DINE_IN TAKEAWAY DELIVERY ONLINE"`
+	IsActive         *bool               `json:"is_active,omitempty"`
+	Components       []TaxComponentInput `json:"components,omitempty" validate:"omitempty,min=1,max=5,dive"` // Replaces all components when set
+}
+
+// TaxRuleValidationErrorMessages maps tax rule request fields to custom messages
+var TaxRuleValidationErrorMessages = map[string]string{
+	"CategoryID":       "A rule can target a category or a menu item, not both.",
+	"Name":             "Name is required and must be at most 100 characters (50 for components).",
+	"ExemptOrderTypes": "Exempt order types must be any of: DINE_IN, TAKEAWAY, DELIVERY, ONLINE.",
+	"Components":       "Between 1 and 5 tax components are required.",
+	"Rate":             "Each component rate must be between 0 and 100.",
+}
+
+// TaxRuleListQuery represents filters for listing tax rules
+type TaxRuleListQuery struct {
+	RestaurantID *uint `query:"restaurant_id"` // Super admin only
+	BranchID     *uint `query:"branch_id"`
+	IsActive     *bool `query:"is_active"`
+}
+
+// TaxComponentResponse represents a tax component in responses
+type TaxComponentResponse struct {
+	ID   uint    `json:"id"`
+	Name string  `json:"name"`
+	Rate float64 `json:"rate"`
+}
+
+// TaxRuleResponse represents tax rule response
+type TaxRuleResponse struct {
+	ID               uint                   `json:"id"`
+	RestaurantID     uint                   `json:"restaurant_id"`
+	BranchID         *uint                  `json:"branch_id,omitempty"`
+	CategoryID       *uint                  `json:"category_id,omitempty"`
+	MenuItemID       *uint                  `json:"menu_item_id,omitempty"`
+	Name             string                 `json:"name"`
+	IsInclusive      bool                   `json:"is_inclusive"`
+	TaxServiceCharge bool                   `json:"tax_service_charge"`
+	ExemptOrderTypes []string               `json:"exempt_order_types"`
+	IsActive         bool                   `json:"is_active"`
+	TotalRate        float64                `json:"total_rate"`
+	Components       []TaxComponentResponse `json:"components"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	tax_controller "restaurant_os/internal/api/tax/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterTaxRoutes(api fiber.Router) {
+
+	taxRules := api.Group("/tax-rules", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"))
+
+	taxHandler := tax_controller.NewTaxController()
+
+	taxRules.Get("/", taxHandler.GetTaxRules)
+	taxRules.Post("/", taxHandler.CreateTaxRule)
+	taxRules.Get("/:id", taxHandler.GetTaxRule)
+	taxRules.Put("/:id", taxHandler.UpdateTaxRule)
+	taxRules.Delete("/:id", taxHandler.DeleteTaxRule)
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/tax/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTaxRuleNotFound    = errors.New("tax rule not found")
+	ErrRestaurantRequired = errors.New("restaurant_id is required")
+	ErrOutsideScope       = errors.New("you can only manage tax rules of your own restaurant and branch")
+	ErrInvalidBranch      = errors.New("branch does not belong to the restaurant")
+	ErrInvalidCategory    = errors.New("menu category does not belong to the restaurant or branch")
+	ErrInvalidMenuItem    = errors.New("menu item does not belong to the restaurant or branch")
+	ErrServiceChargeScope = errors.New("only restaurant or branch wide rules can tax the service charge")
+)
+
+// scopeRules restricts a tax rule query to the requester's restaurant. Managers also
+// see the restaurant-wide rules that apply to their branch.
+func scopeRules(db *gorm.DB, claims *common_dto.Claims) *gorm.DB {
+	if claims.IsSuperAdmin() {
+		return db
+	}
+	if claims.RestaurantID == nil {
+		return db.Where("1 = 0")
+	}
+	db = db.Where("restaurant_id = ?", *claims.RestaurantID)
+	if claims.Role == string(models.RoleManager) && claims.BranchID != nil {
+		db = db.Where("branch_id IS NULL OR branch_id = ?", *claims.BranchID)
+	}
+	return db
+}
+
+// canManage reports whether the requester may change a rule; managers only
+// manage the rules scoped to their own branch
+func canManage(rule *models.TaxRule, claims *common_dto.Claims) bool {
+	if claims.Role != string(models.RoleManager) || claims.IsSuperAdmin() {
+		return true
+	}
+	return claims.BranchID != nil && rule.BranchID != nil && *rule.BranchID == *claims.BranchID
+}
+
+// ListTaxRules returns the tax rules visible to the requester
+func ListTaxRules(query *dto.TaxRuleListQuery, claims *common_dto.Claims) ([]models.TaxRule, error) {
+	db := scopeRules(models.DataBase.Model(&models.TaxRule{}), claims)
+	if query.RestaurantID != nil && claims.IsSuperAdmin() {
+		db = db.Where("restaurant_id = ?", *query.RestaurantID)
+	}
+	if query.BranchID != nil {
+		// Rules for a branch include the restaurant-wide ones
+		db = db.Where("branch_id IS NULL OR branch_id = ?", *query.BranchID)
+	}
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+
+	var rules []models.TaxRule
+	if err := db.Preload("Components", orderComponents).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("error fetching tax rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetTaxRule returns a tax rule if it is visible to the requester
+func GetTaxRule(id uint, claims *common_dto.Claims) (*models.TaxRule, error) {
+	var rule models.TaxRule
+	err := scopeRules(models.DataBase, claims).Preload("Components", orderComponents).First(&rule, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaxRuleNotFound
+		}
+		return nil, fmt.Errorf("error fetching tax rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// CreateTaxRule creates a tax rule with its components. Changing rules never
+// affects placed orders, which keep the tax lines computed at the time.
+func CreateTaxRule(req *dto.CreateTaxRuleRequest, claims *common_dto.Claims) (*models.TaxRule, error) {
+	restaurantID := req.RestaurantID
+	if !claims.IsSuperAdmin() {
+		restaurantID = claims.RestaurantID
+		if claims.Role == string(models.RoleManager) {
+			if claims.BranchID == nil || (req.BranchID != nil && *req.BranchID != *claims.BranchID) {
+				return nil, ErrOutsideScope
+			}
+			req.BranchID = claims.BranchID
+		}
+	}
+	if restaurantID == nil {
+		return nil, ErrRestaurantRequired
+	}
+	if err := validateScope(*restaurantID, req.BranchID, req.CategoryID, req.MenuItemID); err != nil {
+		return nil, err
+	}
+	if req.TaxServiceCharge && (req.CategoryID != nil || req.MenuItemID != nil) {
+		return nil, ErrServiceChargeScope
+	}
+
+	rule := &models.TaxRule{
+		RestaurantID:     *restaurantID,
+		BranchID:         req.BranchID,
+		CategoryID:       req.CategoryID,
+		MenuItemID:       req.MenuItemID,
+		Name:             req.Name,
+		IsInclusive:      req.IsInclusive,
+		TaxServiceCharge: req.TaxServiceCharge,
+		ExemptOrderTypes: strings.Join(req.ExemptOrderTypes, ","),
+		IsActive:         true,
+		Components:       toComponents(req.Components),
+	}
+
+	err := models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rule).Error; err != nil {
+			return fmt.Errorf("error creating tax rule: %w", err)
+		}
+		// A false is_active would be replaced by the column default on insert
+		if req.IsActive != nil && !*req.IsActive {
+			if err := tx.Model(rule).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("error creating tax rule: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTaxRule(rule.ID, claims)
+}
+
+// UpdateTaxRule applies the non-nil fields of req; components are replaced as a whole
+func UpdateTaxRule(id uint, req *dto.UpdateTaxRuleRequest, claims *common_dto.Claims) (*models.TaxRule, error) {
+	rule, err := GetTaxRule(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(rule, claims) {
+		return nil, ErrOutsideScope
+	}
+
+	if req.Name != nil {
+		rule.Name = *req.Name
+	}
+	if req.IsInclusive != nil {
+		rule.IsInclusive = *req.IsInclusive
+	}
+	if req.TaxServiceCharge != nil {
+		if *req.TaxServiceCharge && (rule.CategoryID != nil || rule.MenuItemID != nil) {
+			return nil, ErrServiceChargeScope
+		}
+		rule.TaxServiceCharge = *req.TaxServiceCharge
+	}
+	if req.ExemptOrderTypes != nil {
+		rule.ExemptOrderTypes = strings.Join(*req.ExemptOrderTypes, ",")
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Components").Save(rule).Error; err != nil {
+			return fmt.Errorf("error updating tax rule: %w", err)
+		}
+		if req.Components == nil {
+			return nil
+		}
+		if err := tx.Where("tax_rule_id = ?", rule.ID).Delete(&models.TaxComponent{}).Error; err != nil {
+			return fmt.Errorf("error replacing tax components: %w", err)
+		}
+		components := toComponents(req.Components)
+		for i := range components {
+			components[i].TaxRuleID = rule.ID
+		}
+		if err := tx.Create(&components).Error; err != nil {
+			return fmt.Errorf("error replacing tax components: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTaxRule(rule.ID, claims)
+}
+
+// DeleteTaxRule soft deletes a tax rule within the requester's scope
+func DeleteTaxRule(id uint, claims *common_dto.Claims) error {
+	rule, err := GetTaxRule(id, claims)
+	if err != nil {
+		return err
+	}
+	if !canManage(rule, claims) {
+		return ErrOutsideScope
+	}
+	if err := models.DataBase.Delete(rule).Error; err != nil {
+		return fmt.Errorf("error deleting tax rule: %w", err)
+	}
+	return nil
+}
+
+// validateScope checks that the branch, category and menu item of a rule belong to
+// the restaurant and, when a branch is set, to that branch
+func validateScope(restaurantID uint, branchID, categoryID, menuItemID *uint) error {
+	if branchID != nil {
+		var branch models.Branch
+		if err := models.DataBase.Select("id", "restaurant_id").First(&branch, *branchID).Error; err != nil || branch.RestaurantID != restaurantID {
+			return ErrInvalidBranch
+		}
+	}
+	if categoryID != nil {
+		var category models.MenuCategory
+		if err := models.DataBase.Preload("Branch").First(&category, *categoryID).Error; err != nil ||
+			category.Branch.RestaurantID != restaurantID || (branchID != nil && category.BranchID != *branchID) {
+			return ErrInvalidCategory
+		}
+	}
+	if menuItemID != nil {
+		var item models.MenuItem
+		if err := models.DataBase.Preload("Branch").First(&item, *menuItemID).Error; err != nil ||
+			item.Branch.RestaurantID != restaurantID || (branchID != nil && item.BranchID != *branchID) {
+			return ErrInvalidMenuItem
+		}
+	}
+	return nil
+}
+
+func toComponents(inputs []dto.TaxComponentInput) []models.TaxComponent {
+	components := make([]models.TaxComponent, 0, len(inputs))
+	for i, c := range inputs {
+		components = append(components, models.TaxComponent{
+			Name:      strings.TrimSpace(c.Name),
+			Rate:      c.Rate,
+			SortOrder: i,
+		})
+	}
+	return components
+}
+
+func orderComponents(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	transfer_dto "restaurant_os/internal/api/transfer/dto"
+	transfer_services "restaurant_os/internal/api/transfer/services"
+	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/stock"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type transferController struct{}
+
+var validate = validator.New()
+
+func NewTransferController() *transferController {
+	return &transferController{}
+}
+
+// ============================================================================
+// STOCK TRANSFERS
+// ============================================================================
+
+func (tc *transferController) GetTransfers(c *fiber.Ctx) error {
+	var query transfer_dto.TransferListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	transfers, total, err := transfer_services.ListTransfers(&query, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to fetch stock transfers")
+	}
+
+	data := make([]transfer_dto.TransferResponse, 0, len(transfers))
+	for i := range transfers {
+		data = append(data, toTransferResponse(&transfers[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Stock transfers fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
+}
+
+func (tc *transferController) GetTransfer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock transfer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	transfer, err := transfer_services.GetTransfer(uint(id), middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to fetch stock transfer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock transfer fetched successfully",
+		Data:    toTransferResponse(transfer),
+	})
+}
+
+func (tc *transferController) CreateTransfer(c *fiber.Ctx) error {
+	var req transfer_dto.CreateTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, transfer_dto.TransferValidationErrorMessages)
+	}
+
+	transfer, err := transfer_services.CreateTransfer(&req, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to create stock transfer")
+	}
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock transfer requested successfully",
+		Data:    toTransferResponse(transfer),
+	})
+}
+
+func (tc *transferController) DispatchTransfer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock transfer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req transfer_dto.DispatchTransferRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, transfer_dto.TransferValidationErrorMessages)
+	}
+
+	transfer, err := transfer_services.DispatchTransfer(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to dispatch stock transfer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock transfer dispatched successfully",
+		Data:    toTransferResponse(transfer),
+	})
+}
+
+func (tc *transferController) ReceiveTransfer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock transfer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req transfer_dto.ReceiveTransferRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			errMsg := err.Error()
+			return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   &errMsg,
+			})
+		}
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, transfer_dto.TransferValidationErrorMessages)
+	}
+
+	transfer, err := transfer_services.ReceiveTransfer(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to receive stock transfer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock transfer received successfully",
+		Data:    toTransferResponse(transfer),
+	})
+}
+
+func (tc *transferController) CancelTransfer(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid stock transfer ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req transfer_dto.CancelTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, transfer_dto.TransferValidationErrorMessages)
+	}
+
+	transfer, err := transfer_services.CancelTransfer(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to cancel stock transfer")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Stock transfer cancelled successfully",
+		Data:    toTransferResponse(transfer),
+	})
+}
+
+func (tc *transferController) GetTransferSummary(c *fiber.Ctx) error {
+	var query transfer_dto.TransferSummaryQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	summary, err := transfer_services.TransferSummary(&query, middleware.GetClaims(c))
+	if err != nil {
+		return transferErrorResponse(c, err, "Failed to fetch transfer summary")
+	}
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Transfer summary fetched successfully",
+		Data:    summary,
+	})
+}
+
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// transferErrorResponse maps stock transfer service errors to HTTP status codes
+func transferErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, transfer_services.ErrTransferNotFound), errors.Is(err, transfer_services.ErrBranchNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, dto.ErrBranchForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, transfer_services.ErrTransferStatus):
+		status = fiber.StatusConflict
+	case errors.Is(err, transfer_services.ErrInvalidTransfer):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, dto.ErrBranchRequired), errors.Is(err, transfer_services.ErrInvalidTransferQuery):
+		status = fiber.StatusBadRequest
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toTransferResponse(t *models.StockTransfer) transfer_dto.TransferResponse {
+	resp := transfer_dto.TransferResponse{
+		ID:              t.ID,
+		TransferNumber:  t.TransferNumber,
+		FromBranchID:    t.FromBranchID,
+		FromBranchName:  t.FromBranch.Name,
+		ToBranchID:      t.ToBranchID,
+		ToBranchName:    t.ToBranch.Name,
+		Status:          string(t.Status),
+		Notes:           t.Notes,
+		RequestedBy:     t.RequestedBy,
+		RequestedByName: t.RequestedByUser.Name,
+		DispatchedBy:    t.DispatchedBy,
+		DispatchedAt:    t.DispatchedAt,
+		ReceivedBy:      t.ReceivedBy,
+		ReceivedAt:      t.ReceivedAt,
+		CancelledAt:     t.CancelledAt,
+		CancelReason:    t.CancelReason,
+		Lines:           make([]transfer_dto.TransferLineResponse, 0, len(t.Lines)),
+		CreatedAt:       t.CreatedAt,
+	}
+	if t.DispatchedByUser != nil {
+		resp.DispatchedByName = t.DispatchedByUser.Name
+	}
+	if t.ReceivedByUser != nil {
+		resp.ReceivedByName = t.ReceivedByUser.Name
+	}
+	for _, line := range t.Lines {
+		l := transfer_dto.TransferLineResponse{
+			ID:                 line.ID,
+			FromInventoryID:    line.FromInventoryID,
+			ToInventoryID:      line.ToInventoryID,
+			ItemName:           line.FromInventory.ItemName,
+			Unit:               string(line.FromInventory.Unit),
+			RequestedQuantity:  line.RequestedQuantity,
+			DispatchedQuantity: line.DispatchedQuantity,
+			ReceivedQuantity:   line.ReceivedQuantity,
+			UnitCost:           line.UnitCost,
+			DispatchedValue:    stock.Value(line.UnitCost, line.DispatchedQuantity),
+			ExpiryDate:         line.ExpiryDate,
+			DiscrepancyReason:  line.DiscrepancyReason,
+			OutMovementID:      line.OutMovementID,
+			InMovementID:       line.InMovementID,
+		}
+		// Nothing has arrived or gone missing until the receiving branch checks the transfer in
+		switch t.Status {
+		case models.TransferDispatched:
+			l.InTransitQuantity = line.DispatchedQuantity
+		case models.TransferReceived:
+			l.ShortfallQuantity = line.Shortfall()
+			l.ReceivedValue = stock.Value(line.UnitCost, line.ReceivedQuantity)
+			l.ShortfallValue = stock.Value(line.UnitCost, l.ShortfallQuantity)
+		}
+		resp.DispatchedValue = resp.DispatchedValue.Add(l.DispatchedValue)
+		resp.ReceivedValue = resp.ReceivedValue.Add(l.ReceivedValue)
+		resp.ShortfallValue = resp.ShortfallValue.Add(l.ShortfallValue)
+		resp.Lines = append(resp.Lines, l)
+	}
+	return resp
+}
@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"restaurant_os/internal/dto"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ============================================================================
+// TRANSFER REQUEST/RESPONSE STRUCTS
+// ============================================================================
+
+// TransferListQuery represents filters for listing stock transfers
+type TransferListQuery struct {
+	dto.PaginationQuery
+	BranchID  *uint  `query:"branch_id"` // Transfers sent or received by this branch
+	Direction string `query:"direction"` // IN or OUT, relative to branch_id
+	Status    string `query:"status"`
+}
+
+// TransferLineInput is an item requested from the sending branch
+type TransferLineInput struct {
+	FromInventoryID uint    `json:"from_inventory_id" validate:"required"`
+	ToInventoryID   *uint   `json:"to_inventory_id,omitempty"` // Defaults to the receiving branch's item with the same code or name
+	Quantity        float64 `json:"quantity" validate:"required,gt=0"`
+	Unit            string  `json:"unit,omitempty" validate:"omitempty,oneof=KG GRAM LITER ML PIECE PACK BOTTLE"` // Defaults to the sending item's unit
+}
+
+// CreateTransferRequest requests stock from one branch for another of the same restaurant
+type CreateTransferRequest struct {
+	FromBranchID uint                `json:"from_branch_id" validate:"required"`
+	ToBranchID   *uint               `json:"to_branch_id,omitempty"` // Defaults to the user's branch
+	Notes        string              `json:"notes,omitempty" validate:"max=1000"`
+	Lines        []TransferLineInput `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+// DispatchLineInput overrides what is sent for one line
+type DispatchLineInput struct {
+	LineID     uint     `json:"line_id" validate:"required"`
+	Quantity   *float64 `json:"quantity" validate:"required,min=0"` // In the sending item's unit; 0 sends none
+	ExpiryDate string   `json:"expiry_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// DispatchTransferRequest sends a transfer; lines not listed are sent as requested
+type DispatchTransferRequest struct {
+	Lines []DispatchLineInput `json:"lines,omitempty" validate:"max=100,dive"`
+}
+
+// ReceiveLineInput records what arrived for one line
+type ReceiveLineInput struct {
+	LineID            uint     `json:"line_id" validate:"required"`
+	ReceivedQuantity  *float64 `json:"received_quantity" validate:"required,min=0"` // In the sending item's unit
+	DiscrepancyReason string   `json:"discrepancy_reason,omitempty" validate:"max=200"`
+}
+
+// ReceiveTransferRequest checks a transfer in; lines not listed arrived in full
+type ReceiveTransferRequest struct {
+	Lines []ReceiveLineInput `json:"lines,omitempty" validate:"max=100,dive"`
+}
+
+// CancelTransferRequest represents the request to cancel a transfer that was not dispatched
+type CancelTransferRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// TransferValidationErrorMessages maps transfer request fields to custom messages
+var TransferValidationErrorMessages = map[string]string{
+	"FromBranchID":      "The sending branch is required.",
+	"Notes":             "Notes must be at most 1000 characters.",
+	"Lines":             "Between 1 and 100 lines are required.",
+	"FromInventoryID":   "Each line needs the from_inventory_id of the sending branch's item.",
+	"LineID":            "Each line needs the line_id of a transfer line.",
+	"Quantity":          "Each line needs a quantity greater than 0, or 0 to send none when dispatching.",
+	"ReceivedQuantity":  "Each line needs a received_quantity of 0 or more.",
+	"Unit":              "Unit must be one of KG, GRAM, LITER, ML, PIECE, PACK or BOTTLE.",
+	"ExpiryDate":        "Expiry date must be a date like 2006-01-02.",
+	"DiscrepancyReason": "Discrepancy reason must be at most 200 characters.",
+	"Reason":            "Reason is required and must be between 3 and 500 characters.",
+}
+
+// TransferLineResponse represents one line of a stock transfer
+type TransferLineResponse struct {
+	ID                 uint        `json:"id"`
+	FromInventoryID    uint        `json:"from_inventory_id"`
+	ToInventoryID      *uint       `json:"to_inventory_id,omitempty"`
+	ItemName           string      `json:"item_name"`
+	Unit               string      `json:"unit"` // The sending item's unit, which all quantities are in
+	RequestedQuantity  float64     `json:"requested_quantity"`
+	DispatchedQuantity float64     `json:"dispatched_quantity"`
+	ReceivedQuantity   float64     `json:"received_quantity"`
+	InTransitQuantity  float64     `json:"in_transit_quantity"`
+	ShortfallQuantity  float64     `json:"shortfall_quantity"`
+	UnitCost           money.Money `json:"unit_cost"`
+	DispatchedValue    money.Money `json:"dispatched_value"`
+	ReceivedValue      money.Money `json:"received_value"`
+	ShortfallValue     money.Money `json:"shortfall_value"`
+	ExpiryDate         *time.Time  `json:"expiry_date,omitempty"`
+	DiscrepancyReason  string      `json:"discrepancy_reason,omitempty"`
+	OutMovementID      *uint       `json:"out_movement_id,omitempty"`
+	InMovementID       *uint       `json:"in_movement_id,omitempty"`
+}
+
+// TransferResponse represents a stock transfer with its lines
+type TransferResponse struct {
+	ID               uint                   `json:"id"`
+	TransferNumber   string                 `json:"transfer_number"`
+	FromBranchID     uint                   `json:"from_branch_id"`
+	FromBranchName   string                 `json:"from_branch_name,omitempty"`
+	ToBranchID       uint                   `json:"to_branch_id"`
+	ToBranchName     string                 `json:"to_branch_name,omitempty"`
+	Status           string                 `json:"status"`
+	Notes            string                 `json:"notes,omitempty"`
+	RequestedBy      uint                   `json:"requested_by"`
+	RequestedByName  string                 `json:"requested_by_name,omitempty"`
+	DispatchedBy     *uint                  `json:"dispatched_by,omitempty"`
+	DispatchedByName string                 `json:"dispatched_by_name,omitempty"`
+	DispatchedAt     *time.Time             `json:"dispatched_at,omitempty"`
+	ReceivedBy       *uint                  `json:"received_by,omitempty"`
+	ReceivedByName   string                 `json:"received_by_name,omitempty"`
+	ReceivedAt       *time.Time             `json:"received_at,omitempty"`
+	CancelledAt      *time.Time             `json:"cancelled_at,omitempty"`
+	CancelReason     string                 `json:"cancel_reason,omitempty"`
+	DispatchedValue  money.Money            `json:"dispatched_value"`
+	ReceivedValue    money.Money            `json:"received_value"`
+	ShortfallValue   money.Money            `json:"shortfall_value"`
+	Lines            []TransferLineResponse `json:"lines"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// TransferSummaryQuery selects the branch and dates (YYYY-MM-DD, server time
+// zone, both inclusive) of a transfer summary
+type TransferSummaryQuery struct {
+	BranchID *uint  `query:"branch_id"` // Defaults to the user's branch
+	From     string `query:"from"`
+	To       string `query:"to"`
+}
+
+// TransferSummaryResponse values a branch's transfers for its P&L. Sent and
+// received values count dispatches and receipts in the period; stock still in
+// transit is counted from every open transfer.
+type TransferSummaryResponse struct {
+	BranchID            uint        `json:"branch_id"`
+	SentValue           money.Money `json:"sent_value"`            // Dispatched to other branches
+	ReceivedValue       money.Money `json:"received_value"`        // Checked in from other branches
+	InboundShortfall    money.Money `json:"inbound_shortfall"`     // Dispatched to this branch but never arrived
+	OutboundShortfall   money.Money `json:"outbound_shortfall"`    // Sent by this branch but never arrived
+	InTransitInValue    money.Money `json:"in_transit_in_value"`   // On its way to this branch
+	InTransitOutValue   money.Money `json:"in_transit_out_value"`  // Sent by this branch, not yet received
+	NetTransferredValue money.Money `json:"net_transferred_value"` // Received minus sent
+}
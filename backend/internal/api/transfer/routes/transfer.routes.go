@@ -0,0 +1,25 @@
+package routes
+
+import (
+	transfer_controller "restaurant_os/internal/api/transfer/controller"
+	"restaurant_os/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterTransferRoutes(api fiber.Router) {
+
+	transferHandler := transfer_controller.NewTransferController()
+	supervisors := middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER")
+
+	// Kitchens request, dispatch and check in transfers; supervisors cancel them and review costs
+	transfers := api.Group("/transfers", middleware.RequireAuth(), middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER", "CHEF"))
+
+	transfers.Get("/", transferHandler.GetTransfers)
+	transfers.Post("/", transferHandler.CreateTransfer)
+	transfers.Get("/summary", supervisors, transferHandler.GetTransferSummary)
+	transfers.Get("/:id", transferHandler.GetTransfer)
+	transfers.Post("/:id/dispatch", transferHandler.DispatchTransfer)
+	transfers.Post("/:id/receive", transferHandler.ReceiveTransfer)
+	transfers.Post("/:id/cancel", supervisors, transferHandler.CancelTransfer)
+}
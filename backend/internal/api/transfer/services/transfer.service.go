@@ -0,0 +1,625 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/api/transfer/dto"
+	common_dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/numbering"
+	"restaurant_os/internal/stock"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTransferNotFound     = errors.New("stock transfer not found")
+	ErrBranchNotFound       = errors.New("branch not found")
+	ErrInvalidTransfer      = errors.New("invalid stock transfer")
+	ErrTransferStatus       = errors.New("stock transfer status does not allow this")
+	ErrInvalidTransferQuery = errors.New("invalid transfer query")
+)
+
+// ListTransfers returns a page of stock transfers sent or received by branches
+// visible to the requester, newest first
+func ListTransfers(query *dto.TransferListQuery, claims *common_dto.Claims) ([]models.StockTransfer, int64, error) {
+	query.Normalize()
+
+	db := scopeTransfers(models.DataBase.Model(&models.StockTransfer{}), claims)
+	direction := strings.ToUpper(query.Direction)
+	switch {
+	case direction != "" && direction != "IN" && direction != "OUT":
+		return nil, 0, fmt.Errorf("%w: direction must be IN or OUT", ErrInvalidTransferQuery)
+	case direction != "" && query.BranchID == nil:
+		return nil, 0, fmt.Errorf("%w: direction needs a branch_id", ErrInvalidTransferQuery)
+	case direction == "IN":
+		db = db.Where("to_branch_id = ?", *query.BranchID)
+	case direction == "OUT":
+		db = db.Where("from_branch_id = ?", *query.BranchID)
+	case query.BranchID != nil:
+		db = db.Where("(from_branch_id = ? OR to_branch_id = ?)", *query.BranchID, *query.BranchID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", strings.ToUpper(query.Status))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting stock transfers: %w", err)
+	}
+
+	var transfers []models.StockTransfer
+	err := preloadTransfer(db).
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).Limit(query.Limit).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching stock transfers: %w", err)
+	}
+	return transfers, total, nil
+}
+
+// GetTransfer returns a stock transfer with its lines if either branch is
+// visible to the requester
+func GetTransfer(id uint, claims *common_dto.Claims) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	if err := preloadTransfer(scopeTransfers(models.DataBase, claims)).First(&transfer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("error fetching stock transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+// CreateTransfer requests stock from one branch for another branch of the same
+// restaurant. Either side may raise it, so an outlet can ask its central
+// kitchen for stock and the kitchen can push stock to an outlet.
+func CreateTransfer(req *dto.CreateTransferRequest, claims *common_dto.Claims) (*models.StockTransfer, error) {
+	toBranchID := req.ToBranchID
+	if toBranchID == nil {
+		if claims.BranchID == nil {
+			return nil, common_dto.ErrBranchRequired
+		}
+		toBranchID = claims.BranchID
+	}
+	from, err := getBranch(req.FromBranchID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := getBranch(*toBranchID)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(from.ID, from.RestaurantID) && !claims.CanAccessBranch(to.ID, to.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	if from.ID == to.ID {
+		return nil, fmt.Errorf("%w: a branch cannot transfer stock to itself", ErrInvalidTransfer)
+	}
+	if from.RestaurantID != to.RestaurantID {
+		return nil, fmt.Errorf("%w: stock can only move between branches of the same restaurant", ErrInvalidTransfer)
+	}
+	if !from.IsActive || !to.IsActive {
+		return nil, fmt.Errorf("%w: both branches must be active", ErrInvalidTransfer)
+	}
+
+	lines, err := buildLines(from.ID, to.ID, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	transfer := &models.StockTransfer{
+		FromBranchID: from.ID,
+		ToBranchID:   to.ID,
+		Status:       models.TransferRequested,
+		Notes:        req.Notes,
+		RequestedBy:  claims.UserID,
+		Lines:        lines,
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if transfer.TransferNumber, err = nextNumber(tx, from.ID); err != nil {
+			return err
+		}
+		err := tx.Omit("FromBranch", "ToBranch", "RequestedByUser", "DispatchedByUser", "ReceivedByUser",
+			"Lines.FromInventory", "Lines.ToInventory").Create(transfer).Error
+		if err != nil {
+			return fmt.Errorf("error creating stock transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTransfer(transfer.ID, claims)
+}
+
+// DispatchTransfer sends a requested transfer. Each line posts a TRANSFER_OUT
+// movement on the sending branch, drawing its oldest batches first, at the
+// item's unit cost, which becomes the transfer cost. Unlike sales, a transfer
+// cannot take stock the branch does not have.
+func DispatchTransfer(id uint, req *dto.DispatchTransferRequest, claims *common_dto.Claims) (*models.StockTransfer, error) {
+	transfer, err := GetTransfer(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(transfer.FromBranchID, transfer.FromBranch.RestaurantID) {
+		return nil, fmt.Errorf("%w: only the sending branch can dispatch a transfer", common_dto.ErrBranchForbidden)
+	}
+
+	overrides := make(map[uint]dto.DispatchLineInput, len(req.Lines))
+	for _, in := range req.Lines {
+		overrides[in.LineID] = in
+	}
+	lines := make(map[uint]bool, len(transfer.Lines))
+	for _, line := range transfer.Lines {
+		lines[line.ID] = true
+	}
+	for _, in := range req.Lines {
+		if !lines[in.LineID] {
+			return nil, fmt.Errorf("%w: line %d is not on this transfer", ErrInvalidTransfer, in.LineID)
+		}
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := lockStatus(tx, transfer.ID, models.TransferRequested, "only requested transfers can be dispatched"); err != nil {
+			return err
+		}
+
+		sent := 0
+		for i := range transfer.Lines {
+			line := &transfer.Lines[i]
+			quantity := line.RequestedQuantity
+			expiry := line.FromInventory.ExpiryDate
+			if in, ok := overrides[line.ID]; ok {
+				quantity = stock.RoundQuantity(*in.Quantity)
+				if in.ExpiryDate != "" {
+					date, err := time.ParseInLocation(time.DateOnly, in.ExpiryDate, time.Local)
+					if err != nil {
+						return fmt.Errorf("%w: line %d: expiry dates must look like 2006-01-02", ErrInvalidTransfer, line.ID)
+					}
+					expiry = &date
+				}
+			}
+			if quantity <= 0 {
+				continue
+			}
+
+			movement, err := stock.Post(tx, stock.Movement{
+				InventoryID:   line.FromInventoryID,
+				Type:          models.MovementTransferOut,
+				Quantity:      -quantity,
+				Reason:        fmt.Sprintf("%s to %s", transfer.TransferNumber, transfer.ToBranch.Name),
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &transfer.ID,
+				PostedBy:      &claims.UserID,
+			})
+			if err != nil {
+				return err
+			}
+			if movement.BalanceAfter < 0 {
+				return fmt.Errorf("%w: only %g %s of %s is in stock", ErrInvalidTransfer,
+					stock.RoundQuantity(movement.BalanceAfter+quantity), line.FromInventory.Unit, line.FromInventory.ItemName)
+			}
+			err = tx.Model(&models.StockTransferLine{}).Where("id = ?", line.ID).Updates(map[string]interface{}{
+				"dispatched_quantity": quantity,
+				"unit_cost":           movement.UnitCost,
+				"expiry_date":         expiry,
+				"out_movement_id":     movement.ID,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("error updating transfer line: %w", err)
+			}
+			sent++
+		}
+		if sent == 0 {
+			return fmt.Errorf("%w: nothing to dispatch, cancel the transfer instead", ErrInvalidTransfer)
+		}
+
+		err := tx.Model(&models.StockTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]interface{}{
+			"status":        models.TransferDispatched,
+			"dispatched_by": claims.UserID,
+			"dispatched_at": time.Now(),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error updating stock transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTransfer(transfer.ID, claims)
+}
+
+// ReceiveTransfer checks a dispatched transfer in on the receiving branch.
+// Each line posts a TRANSFER_IN movement at the transfer cost, opening a batch
+// with the dispatched expiry date. Less than was dispatched needs a reason; the
+// shortfall stays on the transfer as the in-transit loss.
+func ReceiveTransfer(id uint, req *dto.ReceiveTransferRequest, claims *common_dto.Claims) (*models.StockTransfer, error) {
+	transfer, err := GetTransfer(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(transfer.ToBranchID, transfer.ToBranch.RestaurantID) {
+		return nil, fmt.Errorf("%w: only the receiving branch can receive a transfer", common_dto.ErrBranchForbidden)
+	}
+
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := lockStatus(tx, id, models.TransferDispatched, "only dispatched transfers can be received"); err != nil {
+			return err
+		}
+		// Validate against the lines as dispatched, not as read before the lock
+		transfer = &models.StockTransfer{}
+		if err := preloadTransfer(tx).First(transfer, id).Error; err != nil {
+			return fmt.Errorf("error fetching stock transfer: %w", err)
+		}
+		if err := setReceivedQuantities(transfer, req.Lines); err != nil {
+			return err
+		}
+
+		for i := range transfer.Lines {
+			line := &transfer.Lines[i]
+			updates := map[string]interface{}{
+				"received_quantity":  line.ReceivedQuantity,
+				"discrepancy_reason": line.DiscrepancyReason,
+			}
+			if line.ReceivedQuantity > 0 {
+				if line.ToInventory == nil {
+					if line.ToInventory, err = stockItem(tx, &line.FromInventory, transfer.ToBranchID); err != nil {
+						return err
+					}
+					updates["to_inventory_id"] = line.ToInventory.ID
+				}
+				quantity, err := line.ToInventory.ToStockUnit(line.ReceivedQuantity, line.FromInventory.Unit)
+				if err != nil {
+					return fmt.Errorf("%w: %s: %v", ErrInvalidTransfer, line.ToInventory.ItemName, err)
+				}
+				quantity = stock.RoundQuantity(quantity)
+				value := stock.Value(line.UnitCost, line.ReceivedQuantity)
+				movement, err := stock.Receive(tx, stock.Movement{
+					InventoryID:   line.ToInventory.ID,
+					Type:          models.MovementTransferIn,
+					Quantity:      quantity,
+					Reason:        fmt.Sprintf("%s from %s", transfer.TransferNumber, transfer.FromBranch.Name),
+					ReferenceType: "stock_transfer",
+					ReferenceID:   &transfer.ID,
+					PostedBy:      &claims.UserID,
+					ExpiryDate:    line.ExpiryDate,
+				}, stock.PerUnit(value, quantity))
+				if err != nil {
+					return err
+				}
+				updates["in_movement_id"] = movement.ID
+			}
+			if err := tx.Model(&models.StockTransferLine{}).Where("id = ?", line.ID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("error updating transfer line: %w", err)
+			}
+		}
+
+		err := tx.Model(&models.StockTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]interface{}{
+			"status":      models.TransferReceived,
+			"received_by": claims.UserID,
+			"received_at": time.Now(),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error updating stock transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTransfer(transfer.ID, claims)
+}
+
+// setReceivedQuantities applies what arrived to the dispatched lines. Lines not
+// listed arrived in full; anything short of the dispatched quantity needs a reason.
+func setReceivedQuantities(transfer *models.StockTransfer, inputs []dto.ReceiveLineInput) error {
+	received := make(map[uint]dto.ReceiveLineInput, len(inputs))
+	for _, in := range inputs {
+		received[in.LineID] = in
+	}
+	for i := range transfer.Lines {
+		line := &transfer.Lines[i]
+		in, ok := received[line.ID]
+		delete(received, line.ID)
+		if !ok {
+			line.ReceivedQuantity = line.DispatchedQuantity
+			continue
+		}
+		line.ReceivedQuantity = stock.RoundQuantity(*in.ReceivedQuantity)
+		line.DiscrepancyReason = strings.TrimSpace(in.DiscrepancyReason)
+		if line.ReceivedQuantity > line.DispatchedQuantity {
+			return fmt.Errorf("%w: line %d: only %g %s was dispatched", ErrInvalidTransfer, line.ID, line.DispatchedQuantity, line.FromInventory.Unit)
+		}
+		if line.ReceivedQuantity < line.DispatchedQuantity && line.DiscrepancyReason == "" {
+			return fmt.Errorf("%w: line %d: give a discrepancy_reason for the %g %s that did not arrive",
+				ErrInvalidTransfer, line.ID, stock.RoundQuantity(line.Shortfall()), line.FromInventory.Unit)
+		}
+	}
+	for lineID := range received {
+		return fmt.Errorf("%w: line %d is not on this transfer", ErrInvalidTransfer, lineID)
+	}
+	return nil
+}
+
+// CancelTransfer withdraws a transfer that was not dispatched yet
+func CancelTransfer(id uint, req *dto.CancelTransferRequest, claims *common_dto.Claims) (*models.StockTransfer, error) {
+	transfer, err := GetTransfer(id, claims)
+	if err != nil {
+		return nil, err
+	}
+	err = models.DataBase.Transaction(func(tx *gorm.DB) error {
+		if err := lockStatus(tx, transfer.ID, models.TransferRequested, "dispatched transfers must be received, with any shortfall explained"); err != nil {
+			return err
+		}
+		err := tx.Model(&models.StockTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]interface{}{
+			"status":        models.TransferCancelled,
+			"cancelled_at":  time.Now(),
+			"cancel_reason": strings.TrimSpace(req.Reason),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("error cancelling stock transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTransfer(transfer.ID, claims)
+}
+
+// TransferSummary values what a branch sent and received in a period, what it
+// lost in transit, and what is still on its way
+func TransferSummary(query *dto.TransferSummaryQuery, claims *common_dto.Claims) (*dto.TransferSummaryResponse, error) {
+	branch, err := resolveBranch(query.BranchID, claims)
+	if err != nil {
+		return nil, err
+	}
+	inPeriod := func(t *time.Time) bool { return t != nil }
+	if query.From != "" || query.To != "" {
+		from, to, err := parseRange(query.From, query.To)
+		if err != nil {
+			return nil, err
+		}
+		inPeriod = func(t *time.Time) bool {
+			return t != nil && (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+		}
+	}
+
+	var transfers []models.StockTransfer
+	err = models.DataBase.Preload("Lines").
+		Where("(from_branch_id = ? OR to_branch_id = ?) AND status IN ?", branch.ID, branch.ID,
+			[]models.StockTransferStatus{models.TransferDispatched, models.TransferReceived}).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stock transfers: %w", err)
+	}
+
+	summary := &dto.TransferSummaryResponse{BranchID: branch.ID}
+	for _, transfer := range transfers {
+		outbound := transfer.FromBranchID == branch.ID
+		for _, line := range transfer.Lines {
+			dispatched := stock.Value(line.UnitCost, line.DispatchedQuantity)
+			if transfer.Status == models.TransferDispatched {
+				if outbound {
+					summary.InTransitOutValue = summary.InTransitOutValue.Add(dispatched)
+				} else {
+					summary.InTransitInValue = summary.InTransitInValue.Add(dispatched)
+				}
+			}
+			if outbound && inPeriod(transfer.DispatchedAt) {
+				summary.SentValue = summary.SentValue.Add(dispatched)
+			}
+			if transfer.Status != models.TransferReceived || !inPeriod(transfer.ReceivedAt) {
+				continue
+			}
+			shortfall := stock.Value(line.UnitCost, line.Shortfall())
+			if outbound {
+				summary.OutboundShortfall = summary.OutboundShortfall.Add(shortfall)
+			} else {
+				summary.ReceivedValue = summary.ReceivedValue.Add(stock.Value(line.UnitCost, line.ReceivedQuantity))
+				summary.InboundShortfall = summary.InboundShortfall.Add(shortfall)
+			}
+		}
+	}
+	summary.NetTransferredValue = summary.ReceivedValue.Sub(summary.SentValue)
+	return summary, nil
+}
+
+// buildLines checks requested items against both branches, matching each to
+// the receiving branch's item by code, then by name, when it is not given
+func buildLines(fromBranchID, toBranchID uint, inputs []dto.TransferLineInput) ([]models.StockTransferLine, error) {
+	ids := make([]uint, 0, len(inputs))
+	seen := map[uint]bool{}
+	for _, in := range inputs {
+		if seen[in.FromInventoryID] {
+			return nil, fmt.Errorf("%w: inventory item %d is listed twice", ErrInvalidTransfer, in.FromInventoryID)
+		}
+		seen[in.FromInventoryID] = true
+		ids = append(ids, in.FromInventoryID)
+	}
+
+	var sending []models.Inventory
+	if err := models.DataBase.Preload("Conversions").Where("id IN ? AND branch_id = ?", ids, fromBranchID).Find(&sending).Error; err != nil {
+		return nil, fmt.Errorf("error fetching inventory: %w", err)
+	}
+	var receiving []models.Inventory
+	if err := models.DataBase.Preload("Conversions").Where("branch_id = ?", toBranchID).Find(&receiving).Error; err != nil {
+		return nil, fmt.Errorf("error fetching inventory: %w", err)
+	}
+	byID := make(map[uint]*models.Inventory, len(sending)+len(receiving))
+	for i := range sending {
+		byID[sending[i].ID] = &sending[i]
+	}
+	for i := range receiving {
+		byID[receiving[i].ID] = &receiving[i]
+	}
+
+	lines := make([]models.StockTransferLine, 0, len(inputs))
+	for _, in := range inputs {
+		item, ok := byID[in.FromInventoryID]
+		if !ok || item.BranchID != fromBranchID {
+			return nil, fmt.Errorf("%w: inventory item %d is not stocked by the sending branch", ErrInvalidTransfer, in.FromInventoryID)
+		}
+		quantity := in.Quantity
+		if in.Unit != "" {
+			var err error
+			if quantity, err = item.ToStockUnit(in.Quantity, models.InventoryUnit(in.Unit)); err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTransfer, item.ItemName, err)
+			}
+		}
+		quantity = stock.RoundQuantity(quantity)
+		if quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of %s rounds to 0", ErrInvalidTransfer, item.ItemName)
+		}
+
+		var target *models.Inventory
+		if in.ToInventoryID != nil {
+			target, ok = byID[*in.ToInventoryID]
+			if !ok || target.BranchID != toBranchID {
+				return nil, fmt.Errorf("%w: inventory item %d is not stocked by the receiving branch", ErrInvalidTransfer, *in.ToInventoryID)
+			}
+		} else {
+			target = matchItem(item, receiving)
+		}
+		line := models.StockTransferLine{FromInventoryID: item.ID, RequestedQuantity: quantity}
+		if target != nil {
+			if _, err := target.ToStockUnit(1, item.Unit); err != nil {
+				return nil, fmt.Errorf("%w: %s is stocked in %s by the receiving branch: %v", ErrInvalidTransfer, item.ItemName, target.Unit, err)
+			}
+			line.ToInventoryID = &target.ID
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func matchItem(item *models.Inventory, candidates []models.Inventory) *models.Inventory {
+	if item.ItemCode != "" {
+		for i := range candidates {
+			if strings.EqualFold(candidates[i].ItemCode, item.ItemCode) {
+				return &candidates[i]
+			}
+		}
+	}
+	for i := range candidates {
+		if strings.EqualFold(candidates[i].ItemName, item.ItemName) {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
+
+// stockItem creates the receiving branch's inventory item for a transferred
+// item it did not stock yet, in the same unit
+func stockItem(tx *gorm.DB, source *models.Inventory, branchID uint) (*models.Inventory, error) {
+	item := &models.Inventory{
+		BranchID: branchID,
+		ItemName: source.ItemName,
+		ItemCode: source.ItemCode,
+		Category: source.Category,
+		Unit:     source.Unit,
+	}
+	if err := tx.Omit("Branch", "Supplier", "Conversions").Create(item).Error; err != nil {
+		return nil, fmt.Errorf("error creating inventory item: %w", err)
+	}
+	return item, nil
+}
+
+// lockStatus locks a transfer for the rest of the transaction and checks that it
+// is still in the expected status
+func lockStatus(tx *gorm.DB, id uint, status models.StockTransferStatus, message string) error {
+	var current models.StockTransfer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status").First(&current, id).Error; err != nil {
+		return fmt.Errorf("error fetching stock transfer: %w", err)
+	}
+	if current.Status != status {
+		return fmt.Errorf("%w: %s", ErrTransferStatus, message)
+	}
+	return nil
+}
+
+func getBranch(id uint) (*models.Branch, error) {
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBranchNotFound
+		}
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+	return &branch, nil
+}
+
+func resolveBranch(requested *uint, claims *common_dto.Claims) (*models.Branch, error) {
+	branchID, err := claims.ResolveBranchID(requested)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := getBranch(branchID)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.CanAccessBranch(branch.ID, branch.RestaurantID) {
+		return nil, common_dto.ErrBranchForbidden
+	}
+	return branch, nil
+}
+
+// nextNumber returns a transfer number unique per sending branch and day, e.g.
+// TRF-20250614-1-0007; the row lock on the branch serializes concurrent numbering
+func nextNumber(tx *gorm.DB, branchID uint) (string, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Branch{}, branchID).Error; err != nil {
+		return "", fmt.Errorf("error generating transfer number: %w", err)
+	}
+	return numbering.Next(tx, &models.StockTransfer{}, "transfer_number", "TRF", branchID)
+}
+
+func parseRange(from, to string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if from != "" {
+		date, err := time.ParseInLocation(time.DateOnly, from, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: from must be a date like 2006-01-02", ErrInvalidTransferQuery)
+		}
+		start = &date
+	}
+	if to != "" {
+		date, err := time.ParseInLocation(time.DateOnly, to, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: to must be a date like 2006-01-02", ErrInvalidTransferQuery)
+		}
+		date = date.AddDate(0, 0, 1)
+		end = &date
+	}
+	return start, end, nil
+}
+
+func preloadTransfer(db *gorm.DB) *gorm.DB {
+	return db.Preload("FromBranch").Preload("ToBranch").
+		Preload("RequestedByUser").Preload("DispatchedByUser").Preload("ReceivedByUser").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.FromInventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Lines.ToInventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Lines.ToInventory.Conversions")
+}
+
+// scopeTransfers restricts a query on stock transfers to those sent or received
+// by a branch the requester can access
+func scopeTransfers(db *gorm.DB, claims *common_dto.Claims) *gorm.DB {
+	switch {
+	case claims.IsSuperAdmin():
+		return db
+	case claims.BranchID != nil:
+		return db.Where("(from_branch_id = ? OR to_branch_id = ?)", *claims.BranchID, *claims.BranchID)
+	case claims.RestaurantID != nil:
+		branches := models.DataBase.Model(&models.Branch{}).Select("id").Where("restaurant_id = ?", *claims.RestaurantID)
+		return db.Where("(from_branch_id IN (?) OR to_branch_id IN (?))", branches, branches)
+	default:
+		return db.Where("1 = 0")
+	}
+}
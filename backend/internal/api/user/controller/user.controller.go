@@ -2,10 +2,13 @@ package user_controller
 
 import (
 	"encoding/json"
+	"errors"
 	user_dto "restaurant_os/internal/api/user/dto"
 	user_service "restaurant_os/internal/api/user/services"
 	dto "restaurant_os/internal/dto"
+	"restaurant_os/internal/middleware"
 	"restaurant_os/internal/models"
+	"restaurant_os/internal/password"
 	"strings"
 
 	validator "github.com/go-playground/validator/v10"
@@ -21,7 +24,37 @@ func NewUserController() *userController {
 }
 
 func (u *userController) GetUsers(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Get all users"})
+	var query user_dto.UserListQuery
+	if err := c.QueryParser(&query); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Error:   &errMsg,
+		})
+	}
+
+	users, total, err := user_service.ListUsers(&query, middleware.GetClaims(c))
+	if err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch users",
+			Error:   &errMsg,
+		})
+	}
+
+	data := make([]user_dto.UserResponse, 0, len(users))
+	for i := range users {
+		data = append(data, toUserResponse(&users[i]))
+	}
+
+	return c.JSON(dto.PaginatedResponse{
+		Success:    true,
+		Message:    "Users fetched successfully",
+		Data:       data,
+		Pagination: dto.NewPagination(query.Page, query.Limit, total),
+	})
 }
 
 func (u *userController) CreateUser(c *fiber.Ctx) error {
@@ -30,39 +63,214 @@ func (u *userController) CreateUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body", "details": err.Error()})
 	}
 
-	err := validate.Struct(&req)
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, user_dto.CreateUserValidationErrorMessages)
+	}
+
+	// Assuming user service is available to handle user creation
+	user, err := user_service.CreateUser(&req, middleware.GetClaims(c))
 	if err != nil {
-		errs := err.(validator.ValidationErrors)
-		validationErrors := make(map[string]string)
-		for _, e := range errs {
-			field := e.Field()
-			msg, ok := user_dto.CreateUserValidationErrorMessages[field]
-			if !ok {
-				msg = "Invalid value"
-			}
-			validationErrors[strings.ToLower(field)] = msg
-		}
-		validationErrorsJSON, _ := json.Marshal(validationErrors)
-		validationErrorsStr := string(validationErrorsJSON)
+		return userErrorResponse(c, err, "Failed to create user")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
+		Success: true,
+		Message: "User created successfully",
+		Data:    toUserResponse(user),
+	})
+}
+
+func (u *userController) GetUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid user ID"
 		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
 			Success: false,
-			Message: "Validation failed",
-			Error:   &validationErrorsStr,
+			Message: errMsg,
+			Error:   &errMsg,
 		})
 	}
 
-	// Assuming user service is available to handle user creation
-	user, err := user_service.CreateUser(&req)
+	user, err := user_service.GetUserByID(uint(id), middleware.GetClaims(c))
 	if err != nil {
+		return userErrorResponse(c, err, "Failed to fetch user")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "User fetched successfully",
+		Data:    toUserResponse(user),
+	})
+}
+
+func (u *userController) UpdateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid user ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: errMsg,
+			Error:   &errMsg,
+		})
+	}
+
+	var req user_dto.UpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
 		errMsg := err.Error()
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.APIResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, user_dto.UpdateUserValidationErrorMessages)
+	}
+
+	user, err := user_service.UpdateUser(uint(id), &req, middleware.GetClaims(c))
+	if err != nil {
+		return userErrorResponse(c, err, "Failed to update user")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "User updated successfully",
+		Data:    toUserResponse(user),
+	})
+}
+
+func (u *userController) DeleteUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		errMsg := "Invalid user ID"
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
 			Success: false,
-			Message: "Failed to create user",
+			Message: errMsg,
 			Error:   &errMsg,
 		})
 	}
 
-	userResponse := user_dto.UserResponse{
+	if err := user_service.DeleteUser(uint(id), middleware.GetClaims(c)); err != nil {
+		return userErrorResponse(c, err, "Failed to delete user")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "User deleted successfully",
+	})
+}
+
+func (u *userController) GetProfile(c *fiber.Ctx) error {
+	user, err := user_service.GetProfile(middleware.GetClaims(c).UserID)
+	if err != nil {
+		return userErrorResponse(c, err, "Failed to fetch profile")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Profile fetched successfully",
+		Data:    toUserResponse(user),
+	})
+}
+
+func (u *userController) UpdateProfile(c *fiber.Ctx) error {
+	var req user_dto.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, user_dto.UpdateUserValidationErrorMessages)
+	}
+
+	user, err := user_service.UpdateProfile(middleware.GetClaims(c).UserID, &req)
+	if err != nil {
+		return userErrorResponse(c, err, "Failed to update profile")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Data:    toUserResponse(user),
+	})
+}
+
+// SetPin sets the manager PIN used to approve refunds and voids on a cashier's screen
+func (u *userController) SetPin(c *fiber.Ctx) error {
+	var req user_dto.SetPinRequest
+	if err := c.BodyParser(&req); err != nil {
+		errMsg := err.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   &errMsg,
+		})
+	}
+	if err := validate.Struct(&req); err != nil {
+		return validationErrorResponse(c, err, user_dto.SetPinValidationErrorMessages)
+	}
+
+	if err := user_service.SetPin(middleware.GetClaims(c).UserID, &req); err != nil {
+		return userErrorResponse(c, err, "Failed to set PIN")
+	}
+
+	return c.JSON(dto.APIResponse{
+		Success: true,
+		Message: "PIN updated successfully",
+	})
+}
+
+// validationErrorResponse renders validator errors using the per-field messages
+func validationErrorResponse(c *fiber.Ctx, err error, messages map[string]string) error {
+	validationErrors := make(map[string]string)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			field := e.Field()
+			msg, ok := messages[field]
+			if !ok {
+				msg = "Invalid value"
+			}
+			validationErrors[strings.ToLower(field)] = msg
+		}
+	}
+	validationErrorsJSON, _ := json.Marshal(validationErrors)
+	validationErrorsStr := string(validationErrorsJSON)
+	return c.Status(fiber.StatusBadRequest).JSON(dto.APIResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   &validationErrorsStr,
+	})
+}
+
+// userErrorResponse maps user service errors to HTTP status codes
+func userErrorResponse(c *fiber.Ctx, err error, message string) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, user_service.ErrUserNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, user_service.ErrOutsideScope):
+		status = fiber.StatusForbidden
+	case errors.Is(err, user_service.ErrEmailTaken):
+		status = fiber.StatusConflict
+	case errors.Is(err, user_service.ErrCannotDelete), errors.Is(err, user_service.ErrInvalidBranch),
+		errors.Is(err, user_service.ErrWrongPassword), password.IsPolicyError(err):
+		status = fiber.StatusBadRequest
+	}
+	errMsg := err.Error()
+	return c.Status(status).JSON(dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   &errMsg,
+	})
+}
+
+func toUserResponse(user *models.User) user_dto.UserResponse {
+	return user_dto.UserResponse{
 		ID:       user.ID,
 		Name:     user.Name,
 		Email:    user.Email,
@@ -77,34 +285,10 @@ func (u *userController) CreateUser(c *fiber.Ctx) error {
 		}(user.Role),
 		RestaurantID: user.RestaurantID,
 		BranchID:     user.BranchID,
+		Access:       user.Access,
 		IsActive:     user.IsActive,
+		LastLogin:    user.LastLogin,
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}
-
-	return c.Status(fiber.StatusCreated).JSON(dto.APIResponse{
-		Success: true,
-		Message: "User created successfully",
-		Data:    userResponse,
-	})
-}
-
-func (u *userController) GetUser(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Get user by ID", "id": c.Params("id")})
-}
-
-func (u *userController) UpdateUser(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Update user", "id": c.Params("id")})
-}
-
-func (u *userController) DeleteUser(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Delete user", "id": c.Params("id")})
-}
-
-func (u *userController) GetProfile(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Get current user's profile"})
-}
-
-func (u *userController) UpdateProfile(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Update current user's profile"})
 }
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"restaurant_os/internal/dto"
+	"time"
+)
 
 // ============================================================================
 // USER REQUEST/RESPONSE STRUCTS
@@ -10,7 +13,7 @@ import "time"
 type CreateUserRequest struct {
 	Name         string  `json:"name" validate:"required,max=100"`
 	Email        string  `json:"email" validate:"required,email"`
-	Password     string  `json:"password" validate:"required,min=6"`
+	Password     string  `json:"password" validate:"required"` // Checked against the password policy
 	Phone        string  `json:"phone" validate:"max=20"`
 	UserType     string  `json:"user_type" validate:"required,oneof=SUPER_ADMIN RESTAURANT EMPLOYEE"`
 	Role         *string `json:"role,omitempty" validate:"omitempty,oneof=WAITER CHEF CASHIER MANAGER KITCHEN_STAFF HOST"`
@@ -23,7 +26,7 @@ type CreateUserRequest struct {
 var CreateUserValidationErrorMessages = map[string]string{
 	"Name":     "Name is required and must be at most 100 characters.",
 	"Email":    "A valid email is required.",
-	"Password": "Password is required and must be at least 6 characters.",
+	"Password": "Password is required.",
 	"UserType": "User type is required and must be one of: SUPER_ADMIN, RESTAURANT, EMPLOYEE.",
 	"Role":     "Role must be one of: WAITER, CHEF, CASHIER, MANAGER, KITCHEN_STAFF, HOST.",
 	"Phone":    "Phone must be at most 20 characters.",
@@ -40,6 +43,41 @@ type UpdateUserRequest struct {
 	IsActive *bool   `json:"is_active,omitempty"`
 }
 
+// UpdateUserValidationErrorMessages maps UpdateUserRequest fields to custom messages
+var UpdateUserValidationErrorMessages = map[string]string{
+	"Name":  "Name must be at most 100 characters.",
+	"Email": "Email must be a valid email address.",
+	"Phone": "Phone must be at most 20 characters.",
+	"Role":  "Role must be one of: WAITER, CHEF, CASHIER, MANAGER, KITCHEN_STAFF, HOST.",
+}
+
+// UpdateProfileRequest represents the fields a user may change on their own profile
+type UpdateProfileRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Phone *string `json:"phone,omitempty" validate:"omitempty,max=20"`
+}
+
+// SetPinRequest represents a request to set the manager approval PIN
+type SetPinRequest struct {
+	Pin             string `json:"pin" validate:"required,numeric,min=4,max=8"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+// SetPinValidationErrorMessages maps SetPinRequest fields to custom messages
+var SetPinValidationErrorMessages = map[string]string{
+	"Pin":             "PIN must be 4 to 8 digits.",
+	"CurrentPassword": "Current password is required.",
+}
+
+// UserListQuery represents filters for listing users
+type UserListQuery struct {
+	dto.PaginationQuery
+	Role     string `query:"role"`
+	BranchID *uint  `query:"branch_id"`
+	IsActive *bool  `query:"is_active"`
+	Search   string `query:"search"` // Matches name, email or phone
+}
+
 // UserResponse represents user response
 type UserResponse struct {
 	ID           uint       `json:"id"`
@@ -9,17 +9,19 @@ import (
 
 func RegisterUserRoutes(api fiber.Router) {
 
-	protected := api.Group("", middleware.RequireAuth())
-	users := protected.Group("/users")
+	users := api.Group("/users", middleware.RequireAuth())
 
 	userHandler := user_controller.NewUserController()
 
-	// User management routes
-	users.Get("/", middleware.RequireRole("SUPER_ADMIN", "MANAGER"), userHandler.GetUsers)
-	users.Post("/", middleware.RequireRole("SUPER_ADMIN", "MANAGER"), userHandler.CreateUser)
-	users.Get("/:id", userHandler.GetUser)
-	users.Put("/:id", middleware.RequireRole("SUPER_ADMIN", "MANAGER"), userHandler.UpdateUser)
-	users.Delete("/:id", middleware.RequireRole("SUPER_ADMIN", "MANAGER"), userHandler.DeleteUser)
+	// Profile routes must be registered before /:id so "profile" is not parsed as an ID
 	users.Get("/profile", userHandler.GetProfile)
 	users.Put("/profile", userHandler.UpdateProfile)
+	users.Put("/profile/pin", middleware.RequireRole("RESTAURANT", "MANAGER"), userHandler.SetPin)
+
+	// User management routes
+	users.Get("/", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), userHandler.GetUsers)
+	users.Post("/", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), userHandler.CreateUser)
+	users.Get("/:id", userHandler.GetUser)
+	users.Put("/:id", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), userHandler.UpdateUser)
+	users.Delete("/:id", middleware.RequireRole("SUPER_ADMIN", "RESTAURANT", "MANAGER"), userHandler.DeleteUser)
 }
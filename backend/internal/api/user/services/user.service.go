@@ -1,26 +1,84 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	auth_services "restaurant_os/internal/api/auth/services"
 	"restaurant_os/internal/api/user/dto"
+	common_dto "restaurant_os/internal/dto"
 	"restaurant_os/internal/models"
+	"restaurant_os/internal/password"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrEmailTaken    = errors.New("email is already in use")
+	ErrOutsideScope  = errors.New("you can only manage users of your own restaurant and branch")
+	ErrCannotDelete  = errors.New("you cannot delete your own account")
+	ErrInvalidBranch = errors.New("branch does not belong to the user's restaurant")
+	ErrWrongPassword = errors.New("current password is incorrect")
 )
 
-func CreateUser(userDetails *dto.CreateUserRequest) (*models.User, error) {
-	// check is user exists
-	existingUser := &models.User{}
-	if err := models.DataBase.Where("email = ?", userDetails.Email).First(existingUser).Error; err == nil {
-		return nil, fmt.Errorf("user already exists") // Assuming this error is defined in models
+// scopeUsers restricts a user query to what the requester may see:
+// SUPER_ADMIN sees everyone, a RESTAURANT owner their restaurant,
+// a MANAGER their restaurant and branch, everybody else only themselves.
+func scopeUsers(db *gorm.DB, claims *common_dto.Claims) *gorm.DB {
+	switch {
+	case claims.IsSuperAdmin():
+		return db
+	case claims.UserType == string(models.UserTypeRestaurant) && claims.RestaurantID != nil:
+		return db.Where("restaurant_id = ?", *claims.RestaurantID)
+	case claims.Role == string(models.RoleManager) && claims.RestaurantID != nil:
+		db = db.Where("restaurant_id = ?", *claims.RestaurantID)
+		if claims.BranchID != nil {
+			db = db.Where("branch_id = ?", *claims.BranchID)
+		}
+		return db
+	default:
+		return db.Where("id = ?", claims.UserID)
+	}
+}
+
+func CreateUser(userDetails *dto.CreateUserRequest, claims *common_dto.Claims) (*models.User, error) {
+	// Non super admins can only create employees inside their own restaurant/branch
+	if !claims.IsSuperAdmin() {
+		if userDetails.UserType != string(models.UserTypeEmployee) {
+			return nil, ErrOutsideScope
+		}
+		userDetails.RestaurantID = claims.RestaurantID
+		if claims.Role == string(models.RoleManager) {
+			userDetails.BranchID = claims.BranchID
+		}
+	}
+	if err := validateBranch(userDetails.BranchID, userDetails.RestaurantID); err != nil {
+		return nil, err
+	}
+	// Checked once the requester is known to be allowed to create here, so the
+	// endpoint cannot be used to probe emails of other tenants
+	if err := checkEmailFree(userDetails.Email, 0); err != nil {
+		return nil, err
+	}
+
+	if err := password.CurrentPolicy().Validate(userDetails.Password); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := password.Hash(userDetails.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
 	}
 
 	user := &models.User{
-		Name:     userDetails.Name,
-		Email:    userDetails.Email,
-		Password: userDetails.Password, // Hash before saving in real use
-		Phone:    userDetails.Phone,
-		UserType: models.UserType(userDetails.UserType),
-		Access:   userDetails.Access,
-		IsActive: true,
+		Name:      userDetails.Name,
+		Email:     userDetails.Email,
+		Password:  hashedPassword,
+		Phone:     userDetails.Phone,
+		UserType:  models.UserType(userDetails.UserType),
+		Access:    userDetails.Access,
+		IsActive:  true,
+		CreatedBy: &claims.UserID,
 	}
 	if userDetails.Role != nil {
 		role := models.EmployeeRole(*userDetails.Role)
@@ -39,3 +97,193 @@ func CreateUser(userDetails *dto.CreateUserRequest) (*models.User, error) {
 	}
 	return user, nil
 }
+
+// ListUsers returns a page of users visible to the requester matching the query filters
+func ListUsers(query *dto.UserListQuery, claims *common_dto.Claims) ([]models.User, int64, error) {
+	query.Normalize()
+
+	db := scopeUsers(models.DataBase.Model(&models.User{}), claims)
+	if query.Role != "" {
+		db = db.Where("role = ?", query.Role)
+	}
+	if query.BranchID != nil {
+		db = db.Where("branch_id = ?", *query.BranchID)
+	}
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+	if search := strings.TrimSpace(query.Search); search != "" {
+		like := "%" + strings.ToLower(search) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ? OR phone LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error counting users: %w", err)
+	}
+
+	var users []models.User
+	err := db.Order("id ASC").Offset(query.Offset()).Limit(query.Limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching users: %w", err)
+	}
+	return users, total, nil
+}
+
+// GetUserByID returns a user if it is visible to the requester
+func GetUserByID(id uint, claims *common_dto.Claims) (*models.User, error) {
+	var user models.User
+	err := scopeUsers(models.DataBase, claims).First(&user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("error fetching user: %w", err)
+	}
+	return &user, nil
+}
+
+// checkEmailFree returns ErrEmailTaken when another user, deleted ones included
+// since they keep the unique index, already has the email
+func checkEmailFree(email string, exceptID uint) error {
+	var count int64
+	err := models.DataBase.Unscoped().Model(&models.User{}).Where("email = ? AND id <> ?", email, exceptID).Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("error checking email: %w", err)
+	}
+	if count > 0 {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+// UpdateUser applies the non-nil fields of req to a user within the requester's scope
+func UpdateUser(id uint, req *dto.UpdateUserRequest, claims *common_dto.Claims) (*models.User, error) {
+	user, err := GetUserByID(id, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Email != nil && *req.Email != user.Email {
+		if err := checkEmailFree(*req.Email, user.ID); err != nil {
+			return nil, err
+		}
+		user.Email = *req.Email
+	}
+	if req.BranchID != nil {
+		if claims.Role == string(models.RoleManager) && (claims.BranchID == nil || *claims.BranchID != *req.BranchID) {
+			return nil, ErrOutsideScope
+		}
+		if err := validateBranch(req.BranchID, user.RestaurantID); err != nil {
+			return nil, err
+		}
+		user.BranchID = req.BranchID
+	}
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	if req.Phone != nil {
+		user.Phone = *req.Phone
+	}
+	if req.Role != nil {
+		role := models.EmployeeRole(*req.Role)
+		user.Role = &role
+	}
+	if req.Access != nil {
+		user.Access = *req.Access
+	}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+
+	if err := models.DataBase.Save(user).Error; err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	// A deactivated user must not be able to keep refreshing sessions
+	if !user.IsActive {
+		if err := auth_services.RevokeAllUserTokens(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// DeleteUser soft deletes a user within the requester's scope and revokes their sessions
+func DeleteUser(id uint, claims *common_dto.Claims) error {
+	if id == claims.UserID {
+		return ErrCannotDelete
+	}
+	user, err := GetUserByID(id, claims)
+	if err != nil {
+		return err
+	}
+	if err := models.DataBase.Delete(user).Error; err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	return auth_services.RevokeAllUserTokens(user.ID)
+}
+
+// GetProfile returns the authenticated user's own record
+func GetProfile(userID uint) (*models.User, error) {
+	var user models.User
+	if err := models.DataBase.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("error fetching user: %w", err)
+	}
+	return &user, nil
+}
+
+// UpdateProfile updates the self-service fields of the authenticated user
+func UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*models.User, error) {
+	user, err := GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	if req.Phone != nil {
+		user.Phone = *req.Phone
+	}
+	if err := models.DataBase.Save(user).Error; err != nil {
+		return nil, fmt.Errorf("error updating profile: %w", err)
+	}
+	return user, nil
+}
+
+// SetPin stores the authenticated user's manager approval PIN after re-checking their password
+func SetPin(userID uint, req *dto.SetPinRequest) error {
+	user, err := GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if !password.Check(req.CurrentPassword, user.Password) {
+		return ErrWrongPassword
+	}
+	hash, err := password.Hash(req.Pin)
+	if err != nil {
+		return fmt.Errorf("error hashing PIN: %w", err)
+	}
+	if err := models.DataBase.Model(user).Update("pin_hash", hash).Error; err != nil {
+		return fmt.Errorf("error saving PIN: %w", err)
+	}
+	return nil
+}
+
+// validateBranch checks that branchID (if set) exists and belongs to restaurantID
+func validateBranch(branchID, restaurantID *uint) error {
+	if branchID == nil {
+		return nil
+	}
+	var branch models.Branch
+	if err := models.DataBase.First(&branch, *branchID).Error; err != nil {
+		return ErrInvalidBranch
+	}
+	if restaurantID != nil && branch.RestaurantID != *restaurantID {
+		return ErrInvalidBranch
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"restaurant_os/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ActionOrderRefund      = "ORDER_REFUND"
+	ActionOrderVoid        = "ORDER_VOID"
+	ActionManagerPINFailed = "MANAGER_PIN_FAILED"
+)
+
+// Entry describes an action to record in the audit trail
+type Entry struct {
+	BranchID   *uint
+	UserID     *uint
+	ApprovedBy *uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	Reason     string
+	Details    map[string]interface{}
+	IPAddress  string
+}
+
+// Record writes an audit entry with tx so it commits or rolls back with the action it describes
+func Record(tx *gorm.DB, entry Entry) error {
+	details := "{}"
+	if len(entry.Details) > 0 {
+		b, err := json.Marshal(entry.Details)
+		if err != nil {
+			return fmt.Errorf("error encoding audit details: %w", err)
+		}
+		details = string(b)
+	}
+
+	log := &models.AuditLog{
+		BranchID:   entry.BranchID,
+		UserID:     entry.UserID,
+		ApprovedBy: entry.ApprovedBy,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Reason:     entry.Reason,
+		Details:    details,
+		IPAddress:  entry.IPAddress,
+	}
+	if err := tx.Create(log).Error; err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
@@ -24,6 +24,40 @@ type Config struct {
 	JWTRefreshSecret string `env:"JWT_REFRESH_SECRET" envDefault:"your_refresh_secret"`
 	JWTAccessExpiry  string `env:"JWT_ACCESS_EXPIRY" envDefault:"1h"`
 	JWTRefreshExpiry string `env:"JWT_REFRESH_EXPIRY" envDefault:"7d"`
+
+	PasswordBcryptCost    string `env:"PASSWORD_BCRYPT_COST" envDefault:"12"`
+	PasswordMinLength     string `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordRequireUpper  string `env:"PASSWORD_REQUIRE_UPPER" envDefault:"true"`
+	PasswordRequireLower  string `env:"PASSWORD_REQUIRE_LOWER" envDefault:"true"`
+	PasswordRequireDigit  string `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"true"`
+	PasswordRequireSymbol string `env:"PASSWORD_REQUIRE_SYMBOL" envDefault:"false"`
+	PasswordResetExpiry   string `env:"PASSWORD_RESET_EXPIRY" envDefault:"30m"`
+	PasswordResetURL      string `env:"PASSWORD_RESET_URL"` // page the reset email links to, the token is appended as ?token=
+
+	SMTPHost     string `env:"SMTP_HOST"` // password reset emails are only sent when set
+	SMTPPort     string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM"`
+
+	OrderTaxRate           string `env:"ORDER_TAX_RATE" envDefault:"18"`           // percent, used when no tax rule applies
+	OrderServiceChargeRate string `env:"ORDER_SERVICE_CHARGE_RATE" envDefault:"5"` // percent, dine-in only
+	TaxRounding            string `env:"TAX_ROUNDING" envDefault:"LINE"`           // LINE rounds each tax line, INVOICE once per order
+	CashRounding           string `env:"CASH_ROUNDING"`                            // e.g. 0.05 or 1.00; defaults to the currency's smallest coin
+
+	QRSessionTTL           string `env:"QR_SESSION_TTL" envDefault:"2h"`           // extended on every customer activity
+	QRSessionMaxDuration   string `env:"QR_SESSION_MAX_DURATION" envDefault:"8h"`  // hard cap from session start
+	QRSessionIdleTimeout   string `env:"QR_SESSION_IDLE_TIMEOUT" envDefault:"30m"` // idle sessions with a cart are abandoned
+	QRSessionSweepInterval string `env:"QR_SESSION_SWEEP_INTERVAL" envDefault:"1m"`
+
+	MenuCacheTTL string `env:"MENU_CACHE_TTL" envDefault:"5m"` // customer menu cache, invalidated on every menu change
+
+	StockDeductOn        string `env:"STOCK_DEDUCT_ON" envDefault:"FIRED"`      // FIRED takes recipe stock when the kitchen starts an item, SERVED when it is served
+	ReorderCheckInterval string `env:"REORDER_CHECK_INTERVAL" envDefault:"15m"` // low stock notifications and draft purchase order suggestions
+	ExpiryCheckInterval  string `env:"EXPIRY_CHECK_INTERVAL" envDefault:"24h"`  // notifications for stock batches close to expiry
+	ExpiryAlertDays      string `env:"EXPIRY_ALERT_DAYS" envDefault:"3"`        // batches expiring within this many days are flagged
+
+	LoyaltySpendPerPoint string `env:"LOYALTY_SPEND_PER_POINT" envDefault:"100"` // customers earn a loyalty point for every this much spent
 }
 
 // LoadConfig loads configuration from environment variables or .env file
@@ -47,6 +81,40 @@ func LoadConfig() (*Config, error) {
 		JWTRefreshSecret: os.Getenv("JWT_REFRESH_SECRET"),
 		JWTAccessExpiry:  os.Getenv("JWT_ACCESS_EXPIRY"),
 		JWTRefreshExpiry: os.Getenv("JWT_REFRESH_EXPIRY"),
+
+		PasswordBcryptCost:    os.Getenv("PASSWORD_BCRYPT_COST"),
+		PasswordMinLength:     os.Getenv("PASSWORD_MIN_LENGTH"),
+		PasswordRequireUpper:  os.Getenv("PASSWORD_REQUIRE_UPPER"),
+		PasswordRequireLower:  os.Getenv("PASSWORD_REQUIRE_LOWER"),
+		PasswordRequireDigit:  os.Getenv("PASSWORD_REQUIRE_DIGIT"),
+		PasswordRequireSymbol: os.Getenv("PASSWORD_REQUIRE_SYMBOL"),
+		PasswordResetExpiry:   os.Getenv("PASSWORD_RESET_EXPIRY"),
+		PasswordResetURL:      os.Getenv("PASSWORD_RESET_URL"),
+
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     os.Getenv("SMTP_PORT"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:     os.Getenv("SMTP_FROM"),
+
+		OrderTaxRate:           os.Getenv("ORDER_TAX_RATE"),
+		OrderServiceChargeRate: os.Getenv("ORDER_SERVICE_CHARGE_RATE"),
+		TaxRounding:            os.Getenv("TAX_ROUNDING"),
+		CashRounding:           os.Getenv("CASH_ROUNDING"),
+
+		QRSessionTTL:           os.Getenv("QR_SESSION_TTL"),
+		QRSessionMaxDuration:   os.Getenv("QR_SESSION_MAX_DURATION"),
+		QRSessionIdleTimeout:   os.Getenv("QR_SESSION_IDLE_TIMEOUT"),
+		QRSessionSweepInterval: os.Getenv("QR_SESSION_SWEEP_INTERVAL"),
+
+		MenuCacheTTL: os.Getenv("MENU_CACHE_TTL"),
+
+		StockDeductOn:        os.Getenv("STOCK_DEDUCT_ON"),
+		ReorderCheckInterval: os.Getenv("REORDER_CHECK_INTERVAL"),
+		ExpiryCheckInterval:  os.Getenv("EXPIRY_CHECK_INTERVAL"),
+		ExpiryAlertDays:      os.Getenv("EXPIRY_ALERT_DAYS"),
+
+		LoyaltySpendPerPoint: os.Getenv("LOYALTY_SPEND_PER_POINT"),
 	}
 
 	EnvConfig = config
@@ -1,15 +1,15 @@
 package seeders
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"restaurant_os/internal/password"
 )
 
 type Seeder struct {
@@ -49,9 +49,15 @@ func (s *Seeder) SeedAll() error {
 	if err := s.seedMenuItems(); err != nil {
 		return err
 	}
+	if err := s.seedTaxRules(); err != nil {
+		return err
+	}
 	if err := s.seedInventory(); err != nil {
 		return err
 	}
+	if err := s.seedRecipes(); err != nil {
+		return err
+	}
 	if err := s.seedReservations(); err != nil {
 		return err
 	}
@@ -105,14 +111,14 @@ func (s *Seeder) seedRestaurants() error {
 func (s *Seeder) seedUsers() error {
 	log.Println("Seeding users...")
 
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	hashedPassword, _ := password.Hash("password123")
 
 	users := []models.User{
 		{
 			ID:           1,
 			Name:         "Admin User",
 			Email:        "admin@goldenspoon.com",
-			Password:     string(hashedPassword),
+			Password:     hashedPassword,
 			Phone:        "+91-9876543210",
 			UserType:     models.UserTypeSuperAdmin,
 			RestaurantID: nil,
@@ -123,7 +129,7 @@ func (s *Seeder) seedUsers() error {
 			ID:           2,
 			Name:         "Restaurant Owner",
 			Email:        "owner@goldenspoon.com",
-			Password:     string(hashedPassword),
+			Password:     hashedPassword,
 			Phone:        "+91-9876543211",
 			UserType:     models.UserTypeRestaurant,
 			RestaurantID: uintPtr(1),
@@ -134,7 +140,7 @@ func (s *Seeder) seedUsers() error {
 			ID:           3,
 			Name:         "John Doe",
 			Email:        "john.doe@goldenspoon.com",
-			Password:     string(hashedPassword),
+			Password:     hashedPassword,
 			Phone:        "+91-9876543212",
 			UserType:     models.UserTypeEmployee,
 			Role:         rolePtr(models.RoleManager),
@@ -146,7 +152,7 @@ func (s *Seeder) seedUsers() error {
 			ID:           4,
 			Name:         "Sarah Wilson",
 			Email:        "sarah.wilson@goldenspoon.com",
-			Password:     string(hashedPassword),
+			Password:     hashedPassword,
 			Phone:        "+91-9876543213",
 			UserType:     models.UserTypeEmployee,
 			Role:         rolePtr(models.RoleWaiter),
@@ -158,7 +164,7 @@ func (s *Seeder) seedUsers() error {
 			ID:           5,
 			Name:         "Chef Ravi",
 			Email:        "ravi.chef@goldenspoon.com",
-			Password:     string(hashedPassword),
+			Password:     hashedPassword,
 			Phone:        "+91-9876543214",
 			UserType:     models.UserTypeEmployee,
 			Role:         rolePtr(models.RoleChef),
@@ -174,16 +180,15 @@ func (s *Seeder) seedUsers() error {
 func (s *Seeder) seedBranches() error {
 	log.Println("Seeding branches...")
 
-	openingHours := map[string]interface{}{
-		"monday":    map[string]string{"open": "09:00", "close": "22:00"},
-		"tuesday":   map[string]string{"open": "09:00", "close": "22:00"},
-		"wednesday": map[string]string{"open": "09:00", "close": "22:00"},
-		"thursday":  map[string]string{"open": "09:00", "close": "22:00"},
-		"friday":    map[string]string{"open": "09:00", "close": "23:00"},
-		"saturday":  map[string]string{"open": "09:00", "close": "23:00"},
-		"sunday":    map[string]string{"open": "10:00", "close": "22:00"},
+	openingHours := models.OpeningHours{
+		"monday":    {Open: "09:00", Close: "22:00"},
+		"tuesday":   {Open: "09:00", Close: "22:00"},
+		"wednesday": {Open: "09:00", Close: "22:00"},
+		"thursday":  {Open: "09:00", Close: "22:00"},
+		"friday":    {Open: "09:00", Close: "23:00"},
+		"saturday":  {Open: "09:00", Close: "23:00"},
+		"sunday":    {Open: "10:00", Close: "22:00"},
 	}
-	hoursJSON, _ := json.Marshal(openingHours)
 
 	branches := []models.Branch{
 		{
@@ -195,7 +200,7 @@ func (s *Seeder) seedBranches() error {
 			Email:        "mgroad@goldenspoon.com",
 			ManagerID:    uintPtr(3),
 			IsActive:     true,
-			OpeningHours: string(hoursJSON),
+			OpeningHours: openingHours,
 		},
 		{
 			ID:           2,
@@ -206,7 +211,7 @@ func (s *Seeder) seedBranches() error {
 			Email:        "marinedrive@goldenspoon.com",
 			ManagerID:    uintPtr(3),
 			IsActive:     true,
-			OpeningHours: string(hoursJSON),
+			OpeningHours: openingHours,
 		},
 	}
 
@@ -218,31 +223,44 @@ func (s *Seeder) seedSuppliers() error {
 
 	suppliers := []models.Supplier{
 		{
-			ID:       1,
-			Name:     "Fresh Vegetables Supplier",
-			Contact:  "Rajesh Kumar",
-			Phone:    "+91-9876543230",
-			Email:    "rajesh@freshveggies.com",
-			Address:  "Vegetable Market, Ernakulam",
-			IsActive: true,
+			ID:           1,
+			RestaurantID: uintPtr(1),
+			Name:         "Fresh Vegetables Supplier",
+			Contact:      "Rajesh Kumar",
+			Phone:        "+91-9876543230",
+			Email:        "rajesh@freshveggies.com",
+			Address:      "Vegetable Market, Ernakulam",
+			IsActive:     true,
 		},
 		{
-			ID:       2,
-			Name:     "Meat & Seafood Supplier",
-			Contact:  "Mohammed Ali",
-			Phone:    "+91-9876543231",
-			Email:    "ali@meatseafood.com",
-			Address:  "Fish Market, Fort Kochi",
-			IsActive: true,
+			ID:           2,
+			RestaurantID: uintPtr(1),
+			Name:         "Meat & Seafood Supplier",
+			Contact:      "Mohammed Ali",
+			Phone:        "+91-9876543231",
+			Email:        "ali@meatseafood.com",
+			Address:      "Fish Market, Fort Kochi",
+			IsActive:     true,
 		},
 		{
-			ID:       3,
-			Name:     "Dairy Products Supplier",
-			Contact:  "Priya Nair",
-			Phone:    "+91-9876543232",
-			Email:    "priya@dairyproducts.com",
-			Address:  "Dairy Farm, Thrissur",
-			IsActive: true,
+			ID:           3,
+			RestaurantID: uintPtr(1),
+			Name:         "Dairy Products Supplier",
+			Contact:      "Priya Nair",
+			Phone:        "+91-9876543232",
+			Email:        "priya@dairyproducts.com",
+			Address:      "Dairy Farm, Thrissur",
+			IsActive:     true,
+		},
+		{
+			ID:           4,
+			RestaurantID: uintPtr(1),
+			Name:         "Grains Supplier",
+			Contact:      "Suresh Menon",
+			Phone:        "+91-9876543233",
+			Email:        "suresh@grainsupply.com",
+			Address:      "Wholesale Market, Aluva",
+			IsActive:     true,
 		},
 	}
 
@@ -256,35 +274,38 @@ func (s *Seeder) seedCustomers() error {
 		{
 			ID:            1,
 			Name:          "Amit Sharma",
-			Phone:         "+91-9876543240",
+			RestaurantID:  1,
+			Phone:         "+919876543240",
 			Email:         "amit.sharma@email.com",
 			Address:       "Kakkanad, Kochi",
 			BirthDate:     timePtr(time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC)),
 			TotalOrders:   15,
-			TotalSpent:    25000.50,
+			TotalSpent:    money.FromFloat(25000.50),
 			LoyaltyPoints: 250,
 		},
 		{
 			ID:            2,
 			Name:          "Priya Menon",
-			Phone:         "+91-9876543241",
+			RestaurantID:  1,
+			Phone:         "+919876543241",
 			Email:         "priya.menon@email.com",
 			Address:       "Edapally, Kochi",
 			BirthDate:     timePtr(time.Date(1985, 8, 22, 0, 0, 0, 0, time.UTC)),
 			Anniversary:   timePtr(time.Date(2015, 12, 10, 0, 0, 0, 0, time.UTC)),
 			TotalOrders:   8,
-			TotalSpent:    12000.00,
+			TotalSpent:    money.FromFloat(12000.00),
 			LoyaltyPoints: 120,
 		},
 		{
 			ID:            3,
 			Name:          "Rajesh Kumar",
-			Phone:         "+91-9876543242",
+			RestaurantID:  1,
+			Phone:         "+919876543242",
 			Email:         "rajesh.kumar@email.com",
 			Address:       "Panampilly Nagar, Kochi",
 			BirthDate:     timePtr(time.Date(1982, 3, 8, 0, 0, 0, 0, time.UTC)),
 			TotalOrders:   22,
-			TotalSpent:    35000.75,
+			TotalSpent:    money.FromFloat(35000.75),
 			LoyaltyPoints: 350,
 		},
 	}
@@ -394,8 +415,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(1),
 			Name:         "Chicken Tikka",
 			Description:  "Marinated chicken pieces grilled to perfection",
-			Price:        280.00,
-			CostPrice:    150.00,
+			Price:        money.FromFloat(280.00),
+			CostPrice:    money.FromFloat(150.00),
 			Available:    true,
 			IsVegetarian: false,
 			IsVegan:      false,
@@ -413,8 +434,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(1),
 			Name:         "Vegetable Samosa",
 			Description:  "Crispy fried pastry with spiced vegetable filling",
-			Price:        120.00,
-			CostPrice:    60.00,
+			Price:        money.FromFloat(120.00),
+			CostPrice:    money.FromFloat(60.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      true,
@@ -433,8 +454,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(2),
 			Name:         "Butter Chicken",
 			Description:  "Creamy tomato-based curry with tender chicken",
-			Price:        450.00,
-			CostPrice:    250.00,
+			Price:        money.FromFloat(450.00),
+			CostPrice:    money.FromFloat(250.00),
 			Available:    true,
 			IsVegetarian: false,
 			IsVegan:      false,
@@ -452,8 +473,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(2),
 			Name:         "Paneer Makhani",
 			Description:  "Rich and creamy cottage cheese curry",
-			Price:        380.00,
-			CostPrice:    200.00,
+			Price:        money.FromFloat(380.00),
+			CostPrice:    money.FromFloat(200.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      false,
@@ -472,8 +493,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(3),
 			Name:         "Gulab Jamun",
 			Description:  "Soft milk dumplings in sweet syrup",
-			Price:        150.00,
-			CostPrice:    75.00,
+			Price:        money.FromFloat(150.00),
+			CostPrice:    money.FromFloat(75.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      false,
@@ -492,8 +513,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(4),
 			Name:         "Mango Lassi",
 			Description:  "Refreshing yogurt drink with mango",
-			Price:        120.00,
-			CostPrice:    50.00,
+			Price:        money.FromFloat(120.00),
+			CostPrice:    money.FromFloat(50.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      false,
@@ -511,8 +532,8 @@ func (s *Seeder) seedMenuItems() error {
 			CategoryID:   uintPtr(4),
 			Name:         "Masala Chai",
 			Description:  "Traditional spiced tea",
-			Price:        60.00,
-			CostPrice:    20.00,
+			Price:        money.FromFloat(60.00),
+			CostPrice:    money.FromFloat(20.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      false,
@@ -529,6 +550,34 @@ func (s *Seeder) seedMenuItems() error {
 	return s.db.Create(&menuItems).Error
 }
 
+func (s *Seeder) seedTaxRules() error {
+	log.Println("Seeding tax rules...")
+
+	// GST split into its central and state halves, added on top of menu prices
+	rules := []models.TaxRule{
+		{
+			RestaurantID: 1,
+			Name:         "GST 18%",
+			IsActive:     true,
+			Components: []models.TaxComponent{
+				{Name: "CGST", Rate: 9, SortOrder: 0},
+				{Name: "SGST", Rate: 9, SortOrder: 1},
+			},
+		},
+		{
+			RestaurantID: 2,
+			Name:         "GST 18%",
+			IsActive:     true,
+			Components: []models.TaxComponent{
+				{Name: "CGST", Rate: 9, SortOrder: 0},
+				{Name: "SGST", Rate: 9, SortOrder: 1},
+			},
+		},
+	}
+
+	return s.db.Create(&rules).Error
+}
+
 func (s *Seeder) seedInventory() error {
 	log.Println("Seeding inventory...")
 
@@ -543,7 +592,8 @@ func (s *Seeder) seedInventory() error {
 			CurrentStock: 25.5,
 			ReorderLevel: 10.0,
 			MaxLevel:     50.0,
-			UnitCost:     280.00,
+			UnitCost:     money.FromFloat(280.00),
+			SupplierID:   uintPtr(2),
 			SupplierName: "Meat & Seafood Supplier",
 			LastOrdered:  timePtr(time.Now().AddDate(0, 0, -5)),
 			ExpiryDate:   timePtr(time.Now().AddDate(0, 0, 3)),
@@ -558,7 +608,8 @@ func (s *Seeder) seedInventory() error {
 			CurrentStock: 100.0,
 			ReorderLevel: 20.0,
 			MaxLevel:     200.0,
-			UnitCost:     120.00,
+			UnitCost:     money.FromFloat(120.00),
+			SupplierID:   uintPtr(4),
 			SupplierName: "Grains Supplier",
 			LastOrdered:  timePtr(time.Now().AddDate(0, 0, -10)),
 		},
@@ -572,7 +623,8 @@ func (s *Seeder) seedInventory() error {
 			CurrentStock: 50.0,
 			ReorderLevel: 20.0,
 			MaxLevel:     100.0,
-			UnitCost:     65.00,
+			UnitCost:     money.FromFloat(65.00),
+			SupplierID:   uintPtr(3),
 			SupplierName: "Dairy Products Supplier",
 			LastOrdered:  timePtr(time.Now().AddDate(0, 0, -2)),
 			ExpiryDate:   timePtr(time.Now().AddDate(0, 0, 2)),
@@ -587,7 +639,8 @@ func (s *Seeder) seedInventory() error {
 			CurrentStock: 15.0,
 			ReorderLevel: 5.0,
 			MaxLevel:     30.0,
-			UnitCost:     40.00,
+			UnitCost:     money.FromFloat(40.00),
+			SupplierID:   uintPtr(1),
 			SupplierName: "Fresh Vegetables Supplier",
 			LastOrdered:  timePtr(time.Now().AddDate(0, 0, -3)),
 			ExpiryDate:   timePtr(time.Now().AddDate(0, 0, 2)),
@@ -602,14 +655,85 @@ func (s *Seeder) seedInventory() error {
 			CurrentStock: 8.0,
 			ReorderLevel: 3.0,
 			MaxLevel:     15.0,
-			UnitCost:     350.00,
+			UnitCost:     money.FromFloat(350.00),
+			SupplierID:   uintPtr(3),
 			SupplierName: "Dairy Products Supplier",
 			LastOrdered:  timePtr(time.Now().AddDate(0, 0, -1)),
 			ExpiryDate:   timePtr(time.Now().AddDate(0, 0, 3)),
 		},
 	}
 
-	return s.db.Create(&inventory).Error
+	if err := s.db.Create(&inventory).Error; err != nil {
+		return err
+	}
+
+	// Milk also comes in half-litre packs
+	conversion := models.UnitConversion{InventoryID: 3, FromUnit: models.UnitPack, ToUnit: models.UnitML, Factor: 500}
+	if err := s.db.Create(&conversion).Error; err != nil {
+		return err
+	}
+
+	// The ledger of every seeded item starts from its stock on hand
+	openings := make([]models.StockMovement, 0, len(inventory))
+	for _, item := range inventory {
+		openings = append(openings, models.StockMovement{
+			BranchID:     item.BranchID,
+			InventoryID:  item.ID,
+			Type:         models.MovementOpening,
+			Quantity:     item.CurrentStock,
+			BalanceAfter: item.CurrentStock,
+			UnitCost:     item.UnitCost,
+			Reason:       "opening balance",
+		})
+	}
+	if err := s.db.Create(&openings).Error; err != nil {
+		return err
+	}
+
+	// Each opening balance is one batch expiring on the item's expiry date
+	batches := make([]models.StockBatch, 0, len(inventory))
+	for i, item := range inventory {
+		batches = append(batches, models.StockBatch{
+			BranchID:          item.BranchID,
+			InventoryID:       item.ID,
+			ReceivedQuantity:  item.CurrentStock,
+			RemainingQuantity: item.CurrentStock,
+			UnitCost:          item.UnitCost,
+			ExpiryDate:        item.ExpiryDate,
+			ReceivedAt:        openings[i].CreatedAt,
+		})
+	}
+	if err := s.db.Create(&batches).Error; err != nil {
+		return err
+	}
+	for i := range openings {
+		if err := s.db.Model(&openings[i]).Update("batch_id", batches[i].ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Seeder) seedRecipes() error {
+	log.Println("Seeding recipes...")
+
+	line := func(menuItemID, inventoryID uint, quantity float64, unit models.InventoryUnit) models.RecipeIngredient {
+		return models.RecipeIngredient{MenuItemID: uintPtr(menuItemID), InventoryID: inventoryID, Quantity: quantity, Unit: unit}
+	}
+	recipes := []models.RecipeIngredient{
+		line(1, 1, 200, models.UnitGram), // Chicken Tikka
+		line(1, 4, 50, models.UnitGram),
+		line(3, 1, 250, models.UnitGram), // Butter Chicken
+		line(3, 4, 100, models.UnitGram),
+		line(3, 3, 50, models.UnitML),
+		line(4, 5, 200, models.UnitGram), // Paneer Makhani
+		line(4, 4, 100, models.UnitGram),
+		line(4, 3, 50, models.UnitML),
+		line(6, 3, 250, models.UnitML), // Mango Lassi
+		line(7, 3, 150, models.UnitML), // Masala Chai
+	}
+
+	return s.db.Create(&recipes).Error
 }
 
 func (s *Seeder) seedReservations() error {
@@ -708,11 +832,11 @@ func (s *Seeder) seedOrders() error {
 			PaymentStatus:    models.PaymentPending,
 			QRSessionID:      uintPtr(1),
 			IsQROrder:        true,
-			Subtotal:         830.00,
-			TaxAmount:        149.40,
-			DiscountAmount:   0.00,
-			ServiceCharge:    41.50,
-			Total:            1020.90,
+			Subtotal:         money.FromFloat(830.00),
+			TaxAmount:        money.FromFloat(149.40),
+			DiscountAmount:   money.FromFloat(0.00),
+			ServiceCharge:    money.FromFloat(41.50),
+			Total:            money.FromFloat(1020.90),
 			Notes:            "Less spicy please",
 			EstimatedTime:    25,
 			AssignedWaiterID: uintPtr(4),
@@ -723,6 +847,7 @@ func (s *Seeder) seedOrders() error {
 			TableID:       uintPtr(3),
 			BranchID:      1,
 			UserID:        uintPtr(4),
+			CustomerID:    uintPtr(3),
 			CustomerName:  "Rajesh Kumar",
 			CustomerPhone: "+91-9876543242",
 			OrderType:     models.OrderTypeDineIn,
@@ -730,9 +855,9 @@ func (s *Seeder) seedOrders() error {
 			Status:        models.OrderCompleted,
 			PaymentStatus: models.PaymentPaid,
 			IsQROrder:     false,
-			Subtotal:      950.00,
-			TaxAmount:     171.00,
-			Total:         1121.00,
+			Subtotal:      money.FromFloat(950.00),
+			TaxAmount:     money.FromFloat(171.00),
+			Total:         money.FromFloat(1121.00),
 			Notes:         "Regular order",
 			EstimatedTime: 30,
 		},
@@ -749,8 +874,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    1,
 			MenuItemID: 3, // Butter Chicken
 			Quantity:   1,
-			UnitPrice:  450.00,
-			TotalPrice: 450.00,
+			UnitPrice:  money.FromFloat(450.00),
+			TotalPrice: money.FromFloat(450.00),
 			Status:     models.OrderItemPreparing,
 			Notes:      "Less spicy",
 		},
@@ -759,8 +884,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    1,
 			MenuItemID: 1, // Chicken Tikka
 			Quantity:   1,
-			UnitPrice:  280.00,
-			TotalPrice: 280.00,
+			UnitPrice:  money.FromFloat(280.00),
+			TotalPrice: money.FromFloat(280.00),
 			Status:     models.OrderItemReady,
 		},
 		{
@@ -768,8 +893,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    1,
 			MenuItemID: 6, // Mango Lassi
 			Quantity:   1,
-			UnitPrice:  120.00,
-			TotalPrice: 120.00,
+			UnitPrice:  money.FromFloat(120.00),
+			TotalPrice: money.FromFloat(120.00),
 			Status:     models.OrderItemServed,
 		},
 		{
@@ -777,8 +902,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    2,
 			MenuItemID: 4, // Paneer Makhani
 			Quantity:   1,
-			UnitPrice:  380.00,
-			TotalPrice: 380.00,
+			UnitPrice:  money.FromFloat(380.00),
+			TotalPrice: money.FromFloat(380.00),
 			Status:     models.OrderItemServed,
 		},
 		{
@@ -786,8 +911,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    2,
 			MenuItemID: 2, // Vegetable Samosa
 			Quantity:   2,
-			UnitPrice:  120.00,
-			TotalPrice: 240.00,
+			UnitPrice:  money.FromFloat(120.00),
+			TotalPrice: money.FromFloat(240.00),
 			Status:     models.OrderItemServed,
 		},
 		{
@@ -795,8 +920,8 @@ func (s *Seeder) seedOrders() error {
 			OrderID:    2,
 			MenuItemID: 5, // Gulab Jamun
 			Quantity:   2,
-			UnitPrice:  150.00,
-			TotalPrice: 300.00,
+			UnitPrice:  money.FromFloat(150.00),
+			TotalPrice: money.FromFloat(300.00),
 			Status:     models.OrderItemServed,
 		},
 	}
@@ -810,7 +935,7 @@ func (s *Seeder) seedOrders() error {
 		{
 			ID:            1,
 			OrderID:       2,
-			Amount:        1121.00,
+			Amount:        money.FromFloat(1121.00),
 			Method:        models.PaymentUPI,
 			Status:        models.PaymentPaid,
 			TransactionID: "UPI123456789",
@@ -820,7 +945,7 @@ func (s *Seeder) seedOrders() error {
 		{
 			ID:            2,
 			OrderID:       1,
-			Amount:        500.00,
+			Amount:        money.FromFloat(500.00),
 			Method:        models.PaymentCard,
 			Status:        models.PaymentPending,
 			TransactionID: "CARD987654321",
@@ -912,8 +1037,8 @@ func (s *Seeder) seedQRCartItems() error {
 			QRSessionID: 1,
 			MenuItemID:  7, // Masala Chai
 			Quantity:    2,
-			UnitPrice:   60.00,
-			TotalPrice:  120.00,
+			UnitPrice:   money.FromFloat(60.00),
+			TotalPrice:  money.FromFloat(120.00),
 			Notes:       "Extra sugar",
 			AddedAt:     time.Now().Add(-15 * time.Minute),
 		},
@@ -922,8 +1047,8 @@ func (s *Seeder) seedQRCartItems() error {
 			QRSessionID: 1,
 			MenuItemID:  2, // Vegetable Samosa
 			Quantity:    1,
-			UnitPrice:   120.00,
-			TotalPrice:  120.00,
+			UnitPrice:   money.FromFloat(120.00),
+			TotalPrice:  money.FromFloat(120.00),
 			Notes:       "Extra chutney",
 			AddedAt:     time.Now().Add(-10 * time.Minute),
 		},
@@ -1036,8 +1161,8 @@ func (s *Seeder) seedMoreMenuItems() error {
 			CategoryID:   uintPtr(5),
 			Name:         "Dal Makhani",
 			Description:  "Creamy black lentils cooked overnight",
-			Price:        320.00,
-			CostPrice:    180.00,
+			Price:        money.FromFloat(320.00),
+			CostPrice:    money.FromFloat(180.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      false,
@@ -1055,8 +1180,8 @@ func (s *Seeder) seedMoreMenuItems() error {
 			CategoryID:   uintPtr(5),
 			Name:         "Fish Curry",
 			Description:  "Traditional Kerala fish curry with coconut",
-			Price:        420.00,
-			CostPrice:    220.00,
+			Price:        money.FromFloat(420.00),
+			CostPrice:    money.FromFloat(220.00),
 			Available:    true,
 			IsVegetarian: false,
 			IsVegan:      false,
@@ -1075,8 +1200,8 @@ func (s *Seeder) seedMoreMenuItems() error {
 			CategoryID:   uintPtr(1),
 			Name:         "Mutton Seekh Kebab",
 			Description:  "Spiced minced mutton grilled on skewers",
-			Price:        350.00,
-			CostPrice:    200.00,
+			Price:        money.FromFloat(350.00),
+			CostPrice:    money.FromFloat(200.00),
 			Available:    true,
 			IsVegetarian: false,
 			IsVegan:      false,
@@ -1095,8 +1220,8 @@ func (s *Seeder) seedMoreMenuItems() error {
 			CategoryID:   uintPtr(4),
 			Name:         "Fresh Lime Water",
 			Description:  "Refreshing lime juice with mint",
-			Price:        80.00,
-			CostPrice:    30.00,
+			Price:        money.FromFloat(80.00),
+			CostPrice:    money.FromFloat(30.00),
 			Available:    true,
 			IsVegetarian: true,
 			IsVegan:      true,
@@ -1130,9 +1255,9 @@ func (s *Seeder) seedMoreOrders() error {
 			Status:        models.OrderReady,
 			PaymentStatus: models.PaymentPaid,
 			IsQROrder:     false,
-			Subtotal:      540.00,
-			TaxAmount:     97.20,
-			Total:         637.20,
+			Subtotal:      money.FromFloat(540.00),
+			TaxAmount:     money.FromFloat(97.20),
+			Total:         money.FromFloat(637.20),
 			Notes:         "Takeaway order",
 			EstimatedTime: 20,
 		},
@@ -1149,10 +1274,10 @@ func (s *Seeder) seedMoreOrders() error {
 			PaymentStatus:    models.PaymentPending,
 			IsQROrder:        true,
 			QRSessionID:      uintPtr(2),
-			Subtotal:         400.00,
-			TaxAmount:        72.00,
-			ServiceCharge:    20.00,
-			Total:            492.00,
+			Subtotal:         money.FromFloat(400.00),
+			TaxAmount:        money.FromFloat(72.00),
+			ServiceCharge:    money.FromFloat(20.00),
+			Total:            money.FromFloat(492.00),
 			Notes:            "QR Order from mobile",
 			EstimatedTime:    25,
 			AssignedWaiterID: uintPtr(4),
@@ -1170,8 +1295,8 @@ func (s *Seeder) seedMoreOrders() error {
 			OrderID:    3,
 			MenuItemID: 8, // Dal Makhani
 			Quantity:   1,
-			UnitPrice:  320.00,
-			TotalPrice: 320.00,
+			UnitPrice:  money.FromFloat(320.00),
+			TotalPrice: money.FromFloat(320.00),
 			Status:     models.OrderItemReady,
 		},
 		{
@@ -1179,8 +1304,8 @@ func (s *Seeder) seedMoreOrders() error {
 			OrderID:    3,
 			MenuItemID: 7, // Masala Chai
 			Quantity:   2,
-			UnitPrice:  60.00,
-			TotalPrice: 120.00,
+			UnitPrice:  money.FromFloat(60.00),
+			TotalPrice: money.FromFloat(120.00),
 			Status:     models.OrderItemReady,
 		},
 		{
@@ -1188,8 +1313,8 @@ func (s *Seeder) seedMoreOrders() error {
 			OrderID:    3,
 			MenuItemID: 2, // Vegetable Samosa
 			Quantity:   1,
-			UnitPrice:  120.00,
-			TotalPrice: 120.00,
+			UnitPrice:  money.FromFloat(120.00),
+			TotalPrice: money.FromFloat(120.00),
 			Status:     models.OrderItemReady,
 		},
 		{
@@ -1197,8 +1322,8 @@ func (s *Seeder) seedMoreOrders() error {
 			OrderID:    4,
 			MenuItemID: 9, // Fish Curry
 			Quantity:   1,
-			UnitPrice:  420.00,
-			TotalPrice: 420.00,
+			UnitPrice:  money.FromFloat(420.00),
+			TotalPrice: money.FromFloat(420.00),
 			Status:     models.OrderItemPending,
 		},
 	}
@@ -1212,7 +1337,7 @@ func (s *Seeder) seedMoreOrders() error {
 		{
 			ID:          3,
 			OrderID:     3,
-			Amount:      637.20,
+			Amount:      money.FromFloat(637.20),
 			Method:      models.PaymentCash,
 			Status:      models.PaymentPaid,
 			Reference:   "Cash payment for takeaway",
@@ -1230,14 +1355,40 @@ func (s *Seeder) ClearAllData() error {
 	// Delete in reverse order of dependencies
 	tables := []interface{}{
 		&models.QRCodeScan{},
+		&models.QRCartItemModifier{},
 		&models.QRCartItem{},
 		&models.Notification{},
 		&models.Payment{},
+		&models.OrderTaxLine{},
+		&models.OrderItemModifier{},
 		&models.OrderItem{},
+		&models.OrderCombo{},
 		&models.Order{},
 		&models.QRSession{},
 		&models.Reservation{},
+		&models.RecipeIngredient{},
+		&models.StockTransferLine{},
+		&models.StockTransfer{},
+		&models.WasteLog{},
+		&models.StockBatch{},
+		&models.StockTakeLine{},
+		&models.StockTake{},
+		&models.GoodsReceiptLine{},
+		&models.GoodsReceipt{},
+		&models.PurchaseOrderLine{},
+		&models.PurchaseOrder{},
+		&models.StockMovement{},
+		&models.UnitConversion{},
 		&models.Inventory{},
+		&models.TaxComponent{},
+		&models.TaxRule{},
+		&models.MenuSchedule{},
+		&models.ComboSlotOption{},
+		&models.ComboSlot{},
+		&models.Combo{},
+		&models.ModifierOption{},
+		&models.ModifierGroup{},
+		&models.MenuItemVariant{},
 		&models.MenuItem{},
 		&models.MenuCategory{},
 		&models.Table{},
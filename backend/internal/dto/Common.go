@@ -1,6 +1,35 @@
 package dto
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"errors"
+	"reflect"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrBranchForbidden is returned when a user tries to act on a branch outside their scope
+	ErrBranchForbidden = errors.New("you do not have access to this branch")
+	// ErrBranchRequired is returned when a user not bound to a branch omits branch_id
+	ErrBranchRequired = errors.New("branch_id is required")
+)
+
+// NewValidator returns a request validator that compares money.Money fields by
+// their amount, so tags such as required, gt=0 and min=0 work on them
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if m, ok := field.Interface().(money.Money); ok {
+			return m.Float64()
+		}
+		return nil
+	}, money.Money{})
+	return v
+}
 
 // ============================================================================
 // COMMON STRUCTS
@@ -33,6 +62,46 @@ type Pagination struct {
 	HasPrev    bool  `json:"has_prev"`
 }
 
+// NewPagination builds pagination metadata for the given page, limit and total count
+func NewPagination(page, limit int, total int64) *Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+	return &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// PaginationQuery represents common pagination query parameters
+type PaginationQuery struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+// Normalize applies defaults (page 1, limit 20) and caps limit at 100
+func (q *PaginationQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = 20
+	}
+	if q.Limit > 100 {
+		q.Limit = 100
+	}
+}
+
+// Offset returns the number of rows to skip for the current page
+func (q *PaginationQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
 // Claims represents JWT claims
 type Claims struct {
 	UserID       uint   `json:"user_id"`
@@ -43,3 +112,52 @@ type Claims struct {
 	BranchID     *uint  `json:"branch_id,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// IsSuperAdmin reports whether the claims belong to a SUPER_ADMIN user
+func (c *Claims) IsSuperAdmin() bool {
+	return c.UserType == "SUPER_ADMIN"
+}
+
+// CanAccessBranch reports whether the user may act on the given branch:
+// SUPER_ADMIN everywhere, a user bound to a branch only there,
+// a restaurant level user on every branch of their restaurant.
+func (c *Claims) CanAccessBranch(branchID, restaurantID uint) bool {
+	if c.IsSuperAdmin() {
+		return true
+	}
+	if c.BranchID != nil {
+		return *c.BranchID == branchID
+	}
+	return c.RestaurantID != nil && *c.RestaurantID == restaurantID
+}
+
+// ScopeBranches restricts a query to the rows whose branch column holds a
+// branch the user may access, by the same rules as CanAccessBranch
+func (c *Claims) ScopeBranches(db *gorm.DB, column string) *gorm.DB {
+	switch {
+	case c.IsSuperAdmin():
+		return db
+	case c.BranchID != nil:
+		return db.Where(column+" = ?", *c.BranchID)
+	case c.RestaurantID != nil:
+		return db.Where(column+" IN (?)",
+			models.DataBase.Model(&models.Branch{}).Select("id").Where("restaurant_id = ?", *c.RestaurantID))
+	default:
+		return db.Where("1 = 0")
+	}
+}
+
+// ResolveBranchID picks the branch a request operates on: the user's own branch
+// when they are bound to one, otherwise the requested branch.
+func (c *Claims) ResolveBranchID(requested *uint) (uint, error) {
+	if c.BranchID != nil && !c.IsSuperAdmin() {
+		if requested != nil && *requested != *c.BranchID {
+			return 0, ErrBranchForbidden
+		}
+		return *c.BranchID, nil
+	}
+	if requested == nil {
+		return 0, ErrBranchRequired
+	}
+	return *requested, nil
+}
@@ -0,0 +1,17 @@
+package jobs
+
+import (
+	"context"
+	inventory_services "restaurant_os/internal/api/inventory/services"
+	purchase_services "restaurant_os/internal/api/purchase/services"
+	qr_services "restaurant_os/internal/api/qr/services"
+)
+
+// StartAll starts every background job of the server process
+func StartAll(ctx context.Context) {
+	Start(ctx,
+		Job{Name: "qr-session-sweeper", Interval: qr_services.SessionSweepInterval(), Run: qr_services.RunSessionSweep},
+		Job{Name: "reorder-check", Interval: purchase_services.ReorderCheckInterval(), Run: purchase_services.RunReorderCheck},
+		Job{Name: "expiry-check", Interval: inventory_services.ExpiryCheckInterval(), Run: inventory_services.RunExpiryCheck},
+	)
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a task the server runs periodically in the background
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Start runs each job on its own ticker until ctx is cancelled.
+// A job runs once immediately, errors are logged and panics are recovered
+// so one failing job never stops the others or the server.
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			log.Printf("job %s: disabled (interval %s)", job.Name, job.Interval)
+			continue
+		}
+		go runJob(ctx, job)
+	}
+}
+
+func runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		runOnce(job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runOnce(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("job %s: panic: %v", job.Name, r)
+		}
+	}()
+	if err := job.Run(); err != nil {
+		log.Printf("job %s: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package mailer sends plain text emails through an SMTP relay.
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"restaurant_os/internal/config"
+	"strings"
+)
+
+// Mailer holds the SMTP relay settings
+type Mailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// FromConfig returns a mailer for the configured SMTP relay, or nil when
+// SMTP_HOST is not set
+func FromConfig(cfg *config.Config) *Mailer {
+	if cfg == nil || strings.TrimSpace(cfg.SMTPHost) == "" {
+		return nil
+	}
+	port := cfg.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+	return &Mailer{
+		Host:     cfg.SMTPHost,
+		Port:     port,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	}
+}
+
+// Send delivers a plain text email to one recipient
+func (m *Mailer) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	msg := strings.Join([]string{
+		"From: " + m.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+	if err := smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, m.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
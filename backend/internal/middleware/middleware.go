@@ -80,11 +80,27 @@ func RequireAuth() fiber.Handler {
 	}
 }
 
+// TokenFromQuery lets clients that cannot set headers (e.g. browser EventSource)
+// pass the access token as ?access_token=; it must run before RequireAuth
+func TokenFromQuery() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// RequireRole allows the request when either the employee role or the user type
+// (e.g. SUPER_ADMIN, which has no employee role) matches one of allowedRoles
 func RequireRole(allowedRoles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userRole, ok := c.Locals("role").(string)
+		userRole, hasRole := c.Locals("role").(string)
+		userType, hasType := c.Locals("userType").(string)
 		fmt.Println("User Role from Context:", userRole)
-		if !ok {
+		if !hasRole && !hasType {
 			errMsg := "User role not found in context"
 			return c.Status(fiber.StatusUnauthorized).JSON(dto.APIResponse{
 				Success: false,
@@ -94,7 +110,7 @@ func RequireRole(allowedRoles ...string) fiber.Handler {
 		}
 
 		for _, role := range allowedRoles {
-			if userRole == role {
+			if (hasRole && userRole == role) || (hasType && userType == role) {
 				return c.Next()
 			}
 		}
@@ -107,3 +123,27 @@ func RequireRole(allowedRoles ...string) fiber.Handler {
 		})
 	}
 }
+
+// GetClaims returns the authenticated user's claims stored by RequireAuth
+func GetClaims(c *fiber.Ctx) *dto.Claims {
+	claims := &dto.Claims{}
+	if userID, ok := c.Locals("userID").(uint); ok {
+		claims.UserID = userID
+	}
+	if email, ok := c.Locals("email").(string); ok {
+		claims.Email = email
+	}
+	if userType, ok := c.Locals("userType").(string); ok {
+		claims.UserType = userType
+	}
+	if role, ok := c.Locals("role").(string); ok {
+		claims.Role = role
+	}
+	if restaurantID, ok := c.Locals("restaurantID").(uint); ok {
+		claims.RestaurantID = &restaurantID
+	}
+	if branchID, ok := c.Locals("branchID").(uint); ok {
+		claims.BranchID = &branchID
+	}
+	return claims
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"restaurant_os/internal/dto"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit allows at most max requests per client IP within each window.
+// Counters are kept in memory, so limits apply per server instance.
+func RateLimit(max int, window time.Duration) fiber.Handler {
+	type bucket struct {
+		count   int
+		resetAt time.Time
+	}
+	var (
+		mu      sync.Mutex
+		buckets = make(map[string]*bucket)
+		sweepAt = time.Now().Add(window)
+	)
+
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+		key := c.IP()
+
+		mu.Lock()
+		if now.After(sweepAt) {
+			for k, b := range buckets {
+				if now.After(b.resetAt) {
+					delete(buckets, k)
+				}
+			}
+			sweepAt = now.Add(window)
+		}
+		b, ok := buckets[key]
+		if !ok || now.After(b.resetAt) {
+			b = &bucket{resetAt: now.Add(window)}
+			buckets[key] = b
+		}
+		b.count++
+		count, resetAt := b.count, b.resetAt
+		mu.Unlock()
+
+		if count > max {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(time.Until(resetAt).Seconds())+1))
+			errMsg := "Too many requests, please try again later"
+			return c.Status(fiber.StatusTooManyRequests).JSON(dto.APIResponse{
+				Success: false,
+				Message: "Rate limit exceeded",
+				Error:   &errMsg,
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditLog is an append-only record of sensitive actions (refunds, voids, overrides, ...)
+type AuditLog struct {
+	ID         uint   `gorm:"primaryKey"`
+	BranchID   *uint  `gorm:"index"`
+	UserID     *uint  // User who performed the action
+	User       *User  `gorm:"foreignKey:UserID"`
+	ApprovedBy *uint  // Manager who approved it, if different
+	Action     string `gorm:"not null;size:50;index"` // e.g. ORDER_REFUND, ORDER_VOID, MANAGER_PIN_FAILED
+	EntityType string `gorm:"size:50"`
+	EntityID   uint
+	Reason     string `gorm:"type:text"`
+	Details    string `gorm:"type:json"` // Action specific data as JSON
+	IPAddress  string `gorm:"size:45"`
+	CreatedAt  time.Time
+}
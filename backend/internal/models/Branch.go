@@ -7,17 +7,17 @@ import (
 
 type Branch struct {
 	gorm.Model
-	ID           uint       `gorm:"primaryKey"`
-	RestaurantID uint       `gorm:"not null"`
-	Restaurant   Restaurant `gorm:"foreignKey:RestaurantID"`
-	Name         string     `gorm:"not null;size:100"`
-	Location     string     `gorm:"type:text"`
-	Phone        string     `gorm:"size:20"`
-	Email        string     `gorm:"size:255"`
-	ManagerID    *uint      // Branch manager
-	Manager      *User      `gorm:"foreignKey:ManagerID"`
-	IsActive     bool       `gorm:"default:true"`
-	OpeningHours string     `gorm:"type:json"` // JSON for flexible hours
+	ID           uint         `gorm:"primaryKey"`
+	RestaurantID uint         `gorm:"not null"`
+	Restaurant   Restaurant   `gorm:"foreignKey:RestaurantID"`
+	Name         string       `gorm:"not null;size:100"`
+	Location     string       `gorm:"type:text"`
+	Phone        string       `gorm:"size:20"`
+	Email        string       `gorm:"size:255"`
+	ManagerID    *uint        // Branch manager
+	Manager      *User        `gorm:"foreignKey:ManagerID"`
+	IsActive     bool         `gorm:"default:true"`
+	OpeningHours OpeningHours `gorm:"type:json"` // Weekly hours in the restaurant's time zone
 	Tables       []Table
 	MenuItems    []MenuItem
 	Orders       []Order
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// Combo is a bundle of menu items sold at one price, e.g. a thali or a meal deal.
+// Each slot is filled with one of its options when the combo is ordered.
+type Combo struct {
+	ID          uint        `gorm:"primaryKey"`
+	BranchID    uint        `gorm:"not null;index"`
+	Branch      Branch      `gorm:"foreignKey:BranchID"`
+	Name        string      `gorm:"not null;size:100"`
+	Description string      `gorm:"type:text"`
+	Price       money.Money `gorm:"not null;type:decimal(10,2)"` // Bundle price before slot upcharges
+	ImageURL    string      `gorm:"size:255"`
+	Available   bool        `gorm:"default:true"`
+	SortOrder   int         `gorm:"default:0"`
+	Slots       []ComboSlot
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// ComboSlot is one choice within a combo, e.g. "Starter" or "Drink"
+type ComboSlot struct {
+	ID        uint   `gorm:"primaryKey"`
+	ComboID   uint   `gorm:"not null;index"`
+	Name      string `gorm:"not null;size:100"`
+	SortOrder int    `gorm:"default:0"`
+	Options   []ComboSlotOption
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// ComboSlotOption is a menu item that may fill a slot, optionally pinned to a variant
+type ComboSlotOption struct {
+	ID          uint             `gorm:"primaryKey"`
+	ComboSlotID uint             `gorm:"not null;index"`
+	MenuItemID  uint             `gorm:"not null"`
+	MenuItem    MenuItem         `gorm:"foreignKey:MenuItemID"`
+	VariantID   *uint            // Variant served in the combo, the item's default when nil
+	Variant     *MenuItemVariant `gorm:"foreignKey:VariantID"`
+	PriceDelta  money.Money      `gorm:"type:decimal(10,2);default:0"` // Upcharge for premium choices
+	SortOrder   int              `gorm:"default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// OrderCombo is a combo sold on an order. Its price is allocated to the component
+// order items in proportion to their standalone prices, so item level sales and
+// cost reports built on OrderItem stay accurate.
+type OrderCombo struct {
+	ID         uint        `gorm:"primaryKey"`
+	OrderID    uint        `gorm:"not null;index"`
+	ComboID    uint        `gorm:"not null"`
+	Name       string      `gorm:"not null;size:100"` // Copied from the combo when ordered
+	Quantity   int         `gorm:"not null;default:1"`
+	UnitPrice  money.Money `gorm:"type:decimal(10,2);not null"`
+	TotalPrice money.Money `gorm:"type:decimal(10,2);not null"`
+	Notes      string      `gorm:"type:text"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
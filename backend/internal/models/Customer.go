@@ -2,22 +2,41 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"restaurant_os/internal/money"
+	"strings"
 	"time"
 )
 
+// Customer is a guest of a restaurant, identified by phone number within it.
+// TotalOrders, TotalSpent and LoyaltyPoints are kept by the order flow: a
+// completed order adds to them and refunds take back what they returned.
 type Customer struct {
 	ID            uint   `gorm:"primaryKey"`
+	RestaurantID  uint   `gorm:"not null;uniqueIndex:idx_customers_restaurant_phone"`
 	Name          string `gorm:"not null;size:100"`
-	Phone         string `gorm:"unique;size:20"`
+	Phone         string `gorm:"not null;size:20;uniqueIndex:idx_customers_restaurant_phone"` // Stored as NormalizePhone returns it
 	Email         string `gorm:"size:255"`
 	Address       string `gorm:"type:text"`
 	BirthDate     *time.Time
 	Anniversary   *time.Time
-	TotalOrders   int     `gorm:"default:0"`
-	TotalSpent    float64 `gorm:"type:decimal(10,2);default:0"`
-	LoyaltyPoints int     `gorm:"default:0"`
-	Notes         string  `gorm:"type:text"`
+	TotalOrders   int         `gorm:"default:0"`
+	TotalSpent    money.Money `gorm:"type:decimal(10,2);default:0"`
+	LoyaltyPoints int         `gorm:"default:0"`
+	LastOrderAt   *time.Time
+	Notes         string `gorm:"type:text"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
+
+// NormalizePhone strips the spaces and punctuation people type into phone
+// numbers, keeping digits and a leading +, so the same number always matches
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
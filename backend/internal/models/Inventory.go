@@ -1,7 +1,10 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"restaurant_os/internal/money"
 	"time"
 )
 
@@ -17,22 +20,106 @@ const (
 	UnitBottle InventoryUnit = "BOTTLE"
 )
 
+var ErrIncompatibleUnits = errors.New("units cannot be converted")
+
+// unitBase maps the metric units to their base unit and the number of base
+// units in one of them. Counted units only convert to themselves.
+var unitBase = map[InventoryUnit]struct {
+	base   InventoryUnit
+	factor float64
+}{
+	UnitKG:    {UnitGram, 1000},
+	UnitGram:  {UnitGram, 1},
+	UnitLiter: {UnitML, 1000},
+	UnitML:    {UnitML, 1},
+}
+
+// IsValid reports whether u is a known unit
+func (u InventoryUnit) IsValid() bool {
+	switch u {
+	case UnitKG, UnitGram, UnitLiter, UnitML, UnitPiece, UnitPack, UnitBottle:
+		return true
+	}
+	return false
+}
+
+// Convert expresses quantity in u as a quantity in the target unit, e.g. 250 GRAM is 0.25 KG
+func (u InventoryUnit) Convert(quantity float64, to InventoryUnit) (float64, error) {
+	if u == to {
+		return quantity, nil
+	}
+	from, ok1 := unitBase[u]
+	target, ok2 := unitBase[to]
+	if !ok1 || !ok2 || from.base != target.base {
+		return 0, fmt.Errorf("%w: %s to %s", ErrIncompatibleUnits, u, to)
+	}
+	return quantity * from.factor / target.factor, nil
+}
+
+// ConvertUsing converts like Convert, also following per-item conversions in
+// either direction, e.g. 2 PACK is 24 PIECE when 1 PACK = 12 PIECE
+func (u InventoryUnit) ConvertUsing(quantity float64, to InventoryUnit, conversions []UnitConversion) (float64, error) {
+	if len(conversions) == 0 {
+		return u.Convert(quantity, to)
+	}
+	// Breadth-first over the units, tracking how many of each one unit of u makes
+	factors := map[InventoryUnit]float64{u: 1}
+	queue := []InventoryUnit{u}
+	for len(queue) > 0 {
+		unit := queue[0]
+		queue = queue[1:]
+		if unit == to {
+			return quantity * factors[to], nil
+		}
+		visit := func(next InventoryUnit, factor float64) {
+			if _, seen := factors[next]; !seen {
+				factors[next] = factors[unit] * factor
+				queue = append(queue, next)
+			}
+		}
+		for next := range unitBase {
+			if factor, err := unit.Convert(1, next); err == nil {
+				visit(next, factor)
+			}
+		}
+		for _, c := range conversions {
+			switch unit {
+			case c.FromUnit:
+				visit(c.ToUnit, c.Factor)
+			case c.ToUnit:
+				visit(c.FromUnit, 1/c.Factor)
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %s to %s", ErrIncompatibleUnits, u, to)
+}
+
 type Inventory struct {
-	ID           uint          `gorm:"primaryKey"`
-	BranchID     uint          `gorm:"not null"`
-	Branch       Branch        `gorm:"foreignKey:BranchID"`
-	ItemName     string        `gorm:"not null;size:100"`
-	ItemCode     string        `gorm:"size:50"` // SKU or item code
-	Category     string        `gorm:"size:50"`
-	Unit         InventoryUnit `gorm:"type:VARCHAR(20);not null"`
-	CurrentStock float64       `gorm:"type:decimal(10,3);default:0"`
-	ReorderLevel float64       `gorm:"type:decimal(10,3);default:0"`
-	MaxLevel     float64       `gorm:"type:decimal(10,3);default:0"`
-	UnitCost     float64       `gorm:"type:decimal(10,2);default:0"`
-	SupplierName string        `gorm:"size:100"`
-	LastOrdered  *time.Time
-	ExpiryDate   *time.Time
+	ID           uint             `gorm:"primaryKey"`
+	BranchID     uint             `gorm:"not null"`
+	Branch       Branch           `gorm:"foreignKey:BranchID"`
+	ItemName     string           `gorm:"not null;size:100"`
+	ItemCode     string           `gorm:"size:50"` // SKU or item code
+	Category     string           `gorm:"size:50"`
+	Unit         InventoryUnit    `gorm:"type:VARCHAR(20);not null"`
+	CurrentStock float64          `gorm:"type:decimal(10,3);default:0"`
+	ReorderLevel float64          `gorm:"type:decimal(10,3);default:0"`
+	MaxLevel     float64          `gorm:"type:decimal(10,3);default:0"`
+	UnitCost     money.Money      `gorm:"type:decimal(10,2);default:0"`
+	SupplierID   *uint            `gorm:"index"`
+	Supplier     *Supplier        `gorm:"foreignKey:SupplierID"`
+	SupplierName string           `gorm:"size:100"` // Kept in step with the linked supplier's name
+	LastOrdered  *time.Time       // Set when a purchase order for the item is sent
+	ExpiryDate   *time.Time       // Earliest expiry of the batches on hand, kept in step by the stock ledger
+	LowStockAt   *time.Time       // Set when the reorder job flags the item, cleared once it is restocked above the reorder level
+	Conversions  []UnitConversion `gorm:"foreignKey:InventoryID"` // Per-item conversions on top of the metric ones
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
+
+// ToStockUnit converts a quantity in unit to the unit the item is stocked in,
+// with the metric conversions and the item's Conversions, which must be loaded
+func (i *Inventory) ToStockUnit(quantity float64, unit InventoryUnit) (float64, error) {
+	return unit.ConvertUsing(quantity, i.Unit, i.Conversions)
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// MenuItemVariant is a size or version of a menu item with its own price, e.g.
+// "Regular" and "Large". An item with variants is always ordered as one of them.
+type MenuItemVariant struct {
+	ID         uint        `gorm:"primaryKey"`
+	MenuItemID uint        `gorm:"not null;index"`
+	Name       string      `gorm:"not null;size:50"`
+	Price      money.Money `gorm:"not null;type:decimal(10,2)"` // Replaces the item price
+	IsDefault  bool        `gorm:"default:false"`               // Used when an order does not pick a variant
+	Available  bool        `gorm:"default:true"`
+	SortOrder  int         `gorm:"default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
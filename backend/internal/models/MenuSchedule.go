@@ -0,0 +1,73 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type ScheduleType string
+
+const (
+	// ScheduleAvailability limits ordering to the schedule's window, e.g. a breakfast menu
+	ScheduleAvailability ScheduleType = "AVAILABILITY"
+	// SchedulePrice takes a percentage off the price during the window, e.g. happy hour
+	SchedulePrice ScheduleType = "PRICE"
+)
+
+// MenuSchedule is a recurring weekly time window attached to a menu category or
+// item, evaluated in the restaurant's time zone. A category or item with
+// availability schedules can only be ordered while one of them is active.
+type MenuSchedule struct {
+	ID              uint          `gorm:"primaryKey"`
+	BranchID        uint          `gorm:"not null;index"`
+	Branch          Branch        `gorm:"foreignKey:BranchID"`
+	Name            string        `gorm:"not null;size:100"`
+	Type            ScheduleType  `gorm:"type:VARCHAR(20);not null"`
+	CategoryID      *uint         `gorm:"index"` // Exactly one of CategoryID and MenuItemID is set
+	Category        *MenuCategory `gorm:"foreignKey:CategoryID"`
+	MenuItemID      *uint         `gorm:"index"`
+	MenuItem        *MenuItem     `gorm:"foreignKey:MenuItemID"`
+	Days            string        `gorm:"size:100"`        // Comma separated day names, empty for every day
+	StartTime       string        `gorm:"not null;size:5"` // HH:MM
+	EndTime         string        `gorm:"not null;size:5"` // HH:MM, before StartTime for windows past midnight
+	DiscountPercent float64       `gorm:"default:0"`       // Price schedules only
+	IsActive        bool          `gorm:"default:true"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// Weekdays returns the days the schedule runs on, nil for every day
+func (s *MenuSchedule) Weekdays() (map[time.Weekday]bool, error) {
+	if strings.TrimSpace(s.Days) == "" {
+		return nil, nil
+	}
+	days := map[time.Weekday]bool{}
+	for _, name := range strings.Split(s.Days, ",") {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+// ActiveAt reports whether the schedule's window contains the given local time.
+// A window that ends past midnight belongs to the day it starts on.
+func (s *MenuSchedule) ActiveAt(at time.Time) bool {
+	if !s.IsActive {
+		return false
+	}
+	days, err := s.Weekdays()
+	if err != nil {
+		return false
+	}
+	start, err1 := ParseClock(s.StartTime)
+	end, err2 := ParseClock(s.EndTime)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return inWindow(at, days, start, end)
+}
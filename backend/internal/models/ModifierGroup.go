@@ -0,0 +1,56 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// ModifierGroup is a set of choices attached to a menu item, e.g. "Choose spice level"
+// or "Add-ons". Customers pick between MinSelections and MaxSelections options.
+type ModifierGroup struct {
+	ID            uint   `gorm:"primaryKey"`
+	MenuItemID    uint   `gorm:"not null;index"`
+	Name          string `gorm:"not null;size:100"`
+	IsRequired    bool   `gorm:"default:false"` // At least one option must be picked
+	MinSelections int    `gorm:"default:0"`
+	MaxSelections int    `gorm:"default:0"` // 0 = no limit
+	SortOrder     int    `gorm:"default:0"`
+	Options       []ModifierOption
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+type ModifierOption struct {
+	ID              uint        `gorm:"primaryKey"`
+	ModifierGroupID uint        `gorm:"not null;index"`
+	Name            string      `gorm:"not null;size:100"`
+	PriceDelta      money.Money `gorm:"type:decimal(10,2);default:0"` // Added to the unit price, may be negative
+	Available       bool        `gorm:"default:true"`
+	SortOrder       int         `gorm:"default:0"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// OrderItemModifier is a modifier option chosen on an order line. Names and prices
+// are copied so that later menu changes never alter placed orders.
+type OrderItemModifier struct {
+	ID               uint        `gorm:"primaryKey"`
+	OrderItemID      uint        `gorm:"not null;index"`
+	ModifierOptionID uint        `gorm:"not null"`
+	GroupName        string      `gorm:"not null;size:100"`
+	Name             string      `gorm:"not null;size:100"`
+	PriceDelta       money.Money `gorm:"type:decimal(10,2);default:0"`
+}
+
+// QRCartItemModifier is a modifier option chosen on a QR cart line
+type QRCartItemModifier struct {
+	ID               uint        `gorm:"primaryKey"`
+	QRCartItemID     uint        `gorm:"not null;index"`
+	ModifierOptionID uint        `gorm:"not null"`
+	GroupName        string      `gorm:"not null;size:100"`
+	Name             string      `gorm:"not null;size:100"`
+	PriceDelta       money.Money `gorm:"type:decimal(10,2);default:0"`
+}
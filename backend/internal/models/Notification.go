@@ -8,12 +8,13 @@ type NotificationType string
 type NotificationStatus string
 
 const (
-	NotificationNewOrder       NotificationType = "NEW_ORDER"
-	NotificationOrderReady     NotificationType = "ORDER_READY"
-	NotificationPaymentPending NotificationType = "PAYMENT_PENDING"
-	NotificationQRSession      NotificationType = "QR_SESSION"
-	NotificationInventoryLow   NotificationType = "INVENTORY_LOW"
-	NotificationReservation    NotificationType = "RESERVATION"
+	NotificationNewOrder        NotificationType = "NEW_ORDER"
+	NotificationOrderReady      NotificationType = "ORDER_READY"
+	NotificationPaymentPending  NotificationType = "PAYMENT_PENDING"
+	NotificationQRSession       NotificationType = "QR_SESSION"
+	NotificationInventoryLow    NotificationType = "INVENTORY_LOW"
+	NotificationInventoryExpiry NotificationType = "INVENTORY_EXPIRY"
+	NotificationReservation     NotificationType = "RESERVATION"
 )
 
 const (
@@ -39,6 +40,7 @@ type Notification struct {
 	OrderID     *uint
 	TableID     *uint
 	QRSessionID *uint
+	InventoryID *uint
 
 	ReadAt    *time.Time
 	SentAt    *time.Time
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidOpeningHours = errors.New("invalid opening hours")
+	ErrInvalidClock        = errors.New("time must be HH:MM between 00:00 and 23:59")
+	ErrInvalidWeekday      = errors.New("unknown day of the week")
+)
+
+// weekdays maps the accepted day names and abbreviations to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseWeekday parses a day name such as "monday" or "mon"
+func ParseWeekday(name string) (time.Weekday, error) {
+	day, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidWeekday, name)
+	}
+	return day, nil
+}
+
+// ParseClock parses an "HH:MM" time of day into minutes after midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidClock, value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// inWindow reports whether at falls in the daily window from start to end, in
+// minutes after midnight. A window that ends at or before it starts runs past
+// midnight and belongs to the day it starts on; days nil means every day.
+func inWindow(at time.Time, days map[time.Weekday]bool, start, end int) bool {
+	minute := at.Hour()*60 + at.Minute()
+	today := at.Weekday()
+	yesterday := (today + 6) % 7
+	if end > start {
+		return (days == nil || days[today]) && minute >= start && minute < end
+	}
+	return ((days == nil || days[today]) && minute >= start) ||
+		((days == nil || days[yesterday]) && minute < end)
+}
+
+// DayHours is the opening time of a branch on one day of the week
+type DayHours struct {
+	Open   string `json:"open,omitempty"`  // HH:MM
+	Close  string `json:"close,omitempty"` // HH:MM, before Open when the branch closes after midnight
+	Closed bool   `json:"closed,omitempty"`
+}
+
+// OpeningHours is the weekly opening times of a branch keyed by lower case day
+// name, e.g. {"monday": {"open": "09:00", "close": "22:00"}}. It is stored as JSON.
+// Days that are missing or closed have no service; a branch without opening hours
+// is always open.
+type OpeningHours map[string]DayHours
+
+// Validate checks the day names and times
+func (h OpeningHours) Validate() error {
+	for name, day := range h {
+		weekday, err := ParseWeekday(name)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidOpeningHours, err)
+		}
+		if strings.ToLower(weekday.String()) != name {
+			return fmt.Errorf("%w: use the full lower case day name %q", ErrInvalidOpeningHours, strings.ToLower(weekday.String()))
+		}
+		if day.Closed {
+			continue
+		}
+		opens, err := ParseClock(day.Open)
+		if err != nil {
+			return fmt.Errorf("%w: %s open: %v", ErrInvalidOpeningHours, name, err)
+		}
+		closes, err := ParseClock(day.Close)
+		if err != nil {
+			return fmt.Errorf("%w: %s close: %v", ErrInvalidOpeningHours, name, err)
+		}
+		if opens == closes {
+			return fmt.Errorf("%w: %s opens and closes at the same time", ErrInvalidOpeningHours, name)
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether the branch is open at the given local time
+func (h OpeningHours) IsOpen(at time.Time) bool {
+	if len(h) == 0 {
+		return true
+	}
+	for name, day := range h {
+		weekday, err := ParseWeekday(name)
+		if err != nil || day.Closed {
+			continue
+		}
+		opens, err1 := ParseClock(day.Open)
+		closes, err2 := ParseClock(day.Close)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if inWindow(at, map[time.Weekday]bool{weekday: true}, opens, closes) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value stores the opening hours as JSON; empty hours are stored as NULL
+func (h OpeningHours) Value() (driver.Value, error) {
+	if len(h) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads opening hours stored as JSON. Legacy free-text hours that are not
+// JSON are logged and read as no hours, so the branch still loads.
+func (h *OpeningHours) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("%w: unsupported type %T", ErrInvalidOpeningHours, src)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		*h = nil
+		return nil
+	}
+	var hours OpeningHours
+	if err := json.Unmarshal(data, &hours); err != nil {
+		log.Printf("ignoring unreadable opening hours %q: %v", data, err)
+		*h = nil
+		return nil
+	}
+	*h = hours
+	return nil
+}
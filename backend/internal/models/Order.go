@@ -2,6 +2,7 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"restaurant_os/internal/money"
 	"time"
 )
 
@@ -21,6 +22,50 @@ const (
 	OrderRefunded  OrderStatus = "REFUNDED"
 )
 
+// orderTransitions lists the statuses an order may move to from each status
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderConfirmed, OrderCancelled},
+	OrderConfirmed: {OrderPreparing, OrderCancelled},
+	OrderPreparing: {OrderReady, OrderCancelled},
+	OrderReady:     {OrderServed, OrderCompleted}, // takeaway orders skip SERVED
+	OrderServed:    {OrderCompleted},
+	OrderCompleted: {OrderRefunded},
+	OrderCancelled: {OrderRefunded}, // refund of a prepaid, cancelled order
+	OrderRefunded:  {},
+}
+
+// orderProgress ranks the forward statuses so derived statuses never move an order backwards
+var orderProgress = map[OrderStatus]int{
+	OrderPending:   0,
+	OrderConfirmed: 1,
+	OrderPreparing: 2,
+	OrderReady:     3,
+	OrderServed:    4,
+	OrderCompleted: 5,
+}
+
+// CanTransitionTo reports whether an order may move from s to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether no further kitchen/service progress can happen
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderCompleted || s == OrderCancelled || s == OrderRefunded
+}
+
+// IsAheadOf reports whether s is further along the normal order flow than other
+func (s OrderStatus) IsAheadOf(other OrderStatus) bool {
+	rank, ok := orderProgress[s]
+	otherRank, otherOk := orderProgress[other]
+	return ok && otherOk && rank > otherRank
+}
+
 const (
 	OrderTypeDineIn   OrderType = "DINE_IN"
 	OrderTypeTakeaway OrderType = "TAKEAWAY"
@@ -53,6 +98,8 @@ type Order struct {
 	Branch        Branch        `gorm:"foreignKey:BranchID"`
 	UserID        *uint         // Waiter/Cashier who placed (null for QR orders)
 	User          *User         `gorm:"foreignKey:UserID"`
+	CustomerID    *uint         `gorm:"index"` // Linked from the customer phone when the order is placed
+	Customer      *Customer     `gorm:"foreignKey:CustomerID"`
 	CustomerName  string        `gorm:"size:100"`
 	CustomerPhone string        `gorm:"size:20"`
 	CustomerEmail string        `gorm:"size:255"`
@@ -67,15 +114,19 @@ type Order struct {
 	IsQROrder   bool       `gorm:"default:false"`
 
 	// Financial fields
-	Subtotal       float64 `gorm:"type:decimal(10,2);default:0"`
-	TaxAmount      float64 `gorm:"type:decimal(10,2);default:0"`
-	DiscountAmount float64 `gorm:"type:decimal(10,2);default:0"`
-	ServiceCharge  float64 `gorm:"type:decimal(10,2);default:0"`
-	Total          float64 `gorm:"type:decimal(10,2);default:0"`
+	Subtotal       money.Money `gorm:"type:decimal(10,2);default:0"`
+	TaxAmount      money.Money `gorm:"type:decimal(10,2);default:0"`
+	DiscountAmount money.Money `gorm:"type:decimal(10,2);default:0"`
+	ServiceCharge  money.Money `gorm:"type:decimal(10,2);default:0"`
+	Total          money.Money `gorm:"type:decimal(10,2);default:0"`
+	// Tax already contained in tax-inclusive menu prices; TaxAmount only holds tax added on top
+	IncludedTaxAmount money.Money `gorm:"type:decimal(10,2);default:0"`
+	TaxLines          []OrderTaxLine
 
 	Notes         string `gorm:"type:text"`
 	EstimatedTime int    `gorm:"default:0"` // minutes
 	OrderItems    []OrderItem
+	Combos        []OrderCombo
 	Payments      []Payment
 
 	// Staff assignment for QR orders
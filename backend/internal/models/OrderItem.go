@@ -1,6 +1,7 @@
 package models
 
 import (
+	"restaurant_os/internal/money"
 	"time"
 )
 
@@ -14,17 +15,75 @@ const (
 	OrderItemCancelled OrderItemStatus = "CANCELLED"
 )
 
+// orderItemTransitions lists the statuses an order item may move to from each status
+var orderItemTransitions = map[OrderItemStatus][]OrderItemStatus{
+	OrderItemPending:   {OrderItemPreparing, OrderItemCancelled},
+	OrderItemPreparing: {OrderItemReady, OrderItemCancelled},
+	OrderItemReady:     {OrderItemServed},
+	OrderItemServed:    {},
+	OrderItemCancelled: {},
+}
+
+// CanTransitionTo reports whether an order item may move from s to next
+func (s OrderItemStatus) CanTransitionTo(next OrderItemStatus) bool {
+	for _, allowed := range orderItemTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// DeriveOrderStatus computes the order status implied by its items' statuses.
+// Cancelled items are ignored unless every item is cancelled. The second
+// return value is false when the items do not imply anything (all pending).
+func DeriveOrderStatus(items []OrderItem) (OrderStatus, bool) {
+	var active, pending, ready, served int
+	for _, item := range items {
+		switch item.Status {
+		case OrderItemCancelled:
+			continue
+		case OrderItemPending:
+			pending++
+		case OrderItemReady:
+			ready++
+		case OrderItemServed:
+			served++
+		}
+		active++
+	}
+
+	switch {
+	case len(items) > 0 && active == 0:
+		return OrderCancelled, true
+	case active == 0 || pending == active:
+		return "", false
+	case served == active:
+		return OrderServed, true
+	case ready+served == active:
+		return OrderReady, true
+	default:
+		return OrderPreparing, true
+	}
+}
+
 type OrderItem struct {
-	ID         uint            `gorm:"primaryKey"`
-	OrderID    uint            `gorm:"not null"`
-	Order      Order           `gorm:"foreignKey:OrderID"`
-	MenuItemID uint            `gorm:"not null"`
-	MenuItem   MenuItem        `gorm:"foreignKey:MenuItemID"`
-	Quantity   int             `gorm:"not null;default:1"`
-	UnitPrice  float64         `gorm:"type:decimal(10,2);not null"`
-	TotalPrice float64         `gorm:"type:decimal(10,2);not null"`
-	Status     OrderItemStatus `gorm:"type:VARCHAR(20);default:'PENDING'"`
-	Notes      string          `gorm:"type:text"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID              uint     `gorm:"primaryKey"`
+	OrderID         uint     `gorm:"not null"`
+	Order           Order    `gorm:"foreignKey:OrderID"`
+	MenuItemID      uint     `gorm:"not null"`
+	MenuItem        MenuItem `gorm:"foreignKey:MenuItemID"`
+	VariantID       *uint
+	VariantName     string          `gorm:"size:50"` // Copied from the variant when ordered
+	OrderComboID    *uint           `gorm:"index"`   // Set when the item was sold as part of a combo
+	Quantity        int             `gorm:"not null;default:1"`
+	UnitPrice       money.Money     `gorm:"type:decimal(10,2);not null"`
+	TotalPrice      money.Money     `gorm:"type:decimal(10,2);not null"`
+	Status          OrderItemStatus `gorm:"type:VARCHAR(20);default:'PENDING'"`
+	Notes           string          `gorm:"type:text"`
+	StockDeductedAt *time.Time      // Set once the recipe quantities are taken out of stock
+	TaxLines        []OrderTaxLine  `gorm:"foreignKey:OrderItemID"`
+	Modifiers       []OrderItemModifier
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// OrderStatusHistory records every status change of an order or one of its items.
+// OrderItemID is nil for order level changes.
+type OrderStatusHistory struct {
+	ID            uint   `gorm:"primaryKey"`
+	OrderID       uint   `gorm:"not null;index"`
+	Order         Order  `gorm:"foreignKey:OrderID"`
+	OrderItemID   *uint  `gorm:"index"`
+	FromStatus    string `gorm:"type:VARCHAR(20);not null"`
+	ToStatus      string `gorm:"type:VARCHAR(20);not null"`
+	ChangedBy     *uint  // User who made the change, null for system derived changes
+	ChangedByUser *User  `gorm:"foreignKey:ChangedBy"`
+	Reason        string `gorm:"type:text"`
+	CreatedAt     time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetToken is a single-use, expiring token for the forgot-password flow.
+// Only a SHA-256 hash of the token is persisted.
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	User      User      `gorm:"foreignKey:UserID"`
+	TokenHash string    `gorm:"unique;not null;size:64"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	IPAddress string `gorm:"size:45"` // IPv4 or IPv6 of the requester
+	CreatedAt time.Time
+}
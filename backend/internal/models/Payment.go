@@ -1,6 +1,7 @@
 package models
 
 import (
+	"restaurant_os/internal/money"
 	"time"
 )
 
@@ -15,16 +16,22 @@ const (
 )
 
 type Payment struct {
-	ID              uint          `gorm:"primaryKey"`
-	OrderID         uint          `gorm:"not null"`
-	Order           Order         `gorm:"foreignKey:OrderID"`
-	Amount          float64       `gorm:"type:decimal(10,2);not null"`
-	Method          PaymentMethod `gorm:"type:VARCHAR(20);not null"`
-	Status          PaymentStatus `gorm:"type:VARCHAR(20);default:'PENDING'"`
-	TransactionID   string        `gorm:"size:100"` // For digital payments
-	Reference       string        `gorm:"size:100"`
-	ProcessedBy     *uint         // User who processed
-	ProcessedByUser *User         `gorm:"foreignKey:ProcessedBy"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID             uint          `gorm:"primaryKey"`
+	OrderID        uint          `gorm:"not null"`
+	Order          Order         `gorm:"foreignKey:OrderID"`
+	Amount         money.Money   `gorm:"type:decimal(10,2);not null"`  // Amount applied to the order
+	TenderedAmount money.Money   `gorm:"type:decimal(10,2);default:0"` // Cash handed over by the customer
+	ChangeDue      money.Money   `gorm:"type:decimal(10,2);default:0"` // Cash returned to the customer
+	CashRounding   money.Money   `gorm:"type:decimal(10,2);default:0"` // Collected minus applied when a cash settlement is rounded to the smallest coin
+	Method         PaymentMethod `gorm:"type:VARCHAR(20);not null"`
+	Status         PaymentStatus `gorm:"type:VARCHAR(20);default:'PENDING'"`
+	TransactionID  string        `gorm:"size:100"` // For digital payments
+	Reference      string        `gorm:"size:100"`
+	ProcessedBy    *uint         // User who processed
+	// Refund payments carry a negative amount and point at the payment they reverse
+	OriginalPaymentID *uint
+	RefundID          *uint
+	ProcessedByUser   *User `gorm:"foreignKey:ProcessedBy"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderSent              PurchaseOrderStatus = "SENT"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderCancelled         PurchaseOrderStatus = "CANCELLED"
+)
+
+// CanReceive reports whether goods can still be received against a purchase order
+func (s PurchaseOrderStatus) CanReceive() bool {
+	return s == PurchaseOrderSent || s == PurchaseOrderPartiallyReceived
+}
+
+type PurchaseOrder struct {
+	ID           uint                `gorm:"primaryKey"`
+	BranchID     uint                `gorm:"not null;index"`
+	Branch       Branch              `gorm:"foreignKey:BranchID"`
+	SupplierID   uint                `gorm:"not null;index"`
+	Supplier     Supplier            `gorm:"foreignKey:SupplierID"`
+	PONumber     string              `gorm:"uniqueIndex;not null;size:30"`
+	Status       PurchaseOrderStatus `gorm:"type:VARCHAR(20);not null;default:'DRAFT';index"`
+	ExpectedDate *time.Time
+	Notes        string      `gorm:"type:text"`
+	Total        money.Money `gorm:"type:decimal(12,2);default:0"` // Ordered quantities at the ordered unit costs
+	RaisedBy     *uint       // Nil for suggestions raised by the reorder job
+	RaisedByUser *User       `gorm:"foreignKey:RaisedBy"`
+	IsSuggested  bool        `gorm:"default:false"` // Draft generated by the reorder job to top stock up
+	SentAt       *time.Time
+	ReceivedAt   *time.Time // Set when the last line is fully received
+	CancelledAt  *time.Time
+	CancelReason string `gorm:"type:text"`
+	Lines        []PurchaseOrderLine
+	Receipts     []GoodsReceipt
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// PurchaseOrderLine is an inventory item ordered in its stock unit
+type PurchaseOrderLine struct {
+	ID               uint        `gorm:"primaryKey"`
+	PurchaseOrderID  uint        `gorm:"not null;index"`
+	InventoryID      uint        `gorm:"not null;index"`
+	Inventory        Inventory   `gorm:"foreignKey:InventoryID"`
+	Quantity         float64     `gorm:"type:decimal(12,3);not null"`
+	ReceivedQuantity float64     `gorm:"type:decimal(12,3);default:0"`
+	UnitCost         money.Money `gorm:"type:decimal(10,2);not null"`
+	LineTotal        money.Money `gorm:"type:decimal(12,2);not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Outstanding returns the quantity still to be received
+func (l *PurchaseOrderLine) Outstanding() float64 {
+	if l.ReceivedQuantity >= l.Quantity {
+		return 0
+	}
+	return l.Quantity - l.ReceivedQuantity
+}
+
+// GoodsReceipt (GRN) records a delivery against a purchase order; each line
+// posts a PURCHASE_RECEIPT stock movement
+type GoodsReceipt struct {
+	ID                uint          `gorm:"primaryKey"`
+	PurchaseOrderID   uint          `gorm:"not null;index"`
+	PurchaseOrder     PurchaseOrder `gorm:"foreignKey:PurchaseOrderID"`
+	BranchID          uint          `gorm:"not null;index"`
+	SupplierID        uint          `gorm:"not null;index"` // Copied from the purchase order for spend reports
+	GRNNumber         string        `gorm:"uniqueIndex;not null;size:30"`
+	SupplierInvoiceNo string        `gorm:"size:50"`
+	Notes             string        `gorm:"type:text"`
+	Total             money.Money   `gorm:"type:decimal(12,2);not null"`
+	ReceivedBy        uint          `gorm:"not null"`
+	ReceivedByUser    User          `gorm:"foreignKey:ReceivedBy"`
+	ReceivedAt        time.Time     `gorm:"not null;index"`
+	Lines             []GoodsReceiptLine
+	CreatedAt         time.Time
+}
+
+type GoodsReceiptLine struct {
+	ID                  uint        `gorm:"primaryKey"`
+	GoodsReceiptID      uint        `gorm:"not null;index"`
+	PurchaseOrderLineID uint        `gorm:"not null;index"`
+	InventoryID         uint        `gorm:"not null"`
+	Inventory           Inventory   `gorm:"foreignKey:InventoryID"`
+	Quantity            float64     `gorm:"type:decimal(12,3);not null"`
+	UnitCost            money.Money `gorm:"type:decimal(10,2);not null"` // Invoiced cost, may differ from the ordered cost
+	LineTotal           money.Money `gorm:"type:decimal(12,2);not null"`
+	BatchNumber         string      `gorm:"size:50"` // Supplier lot number
+	ExpiryDate          *time.Time
+	BatchID             *uint // Stock batch the delivery opened
+	StockMovementID     *uint
+	CreatedAt           time.Time
+}
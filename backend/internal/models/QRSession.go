@@ -2,6 +2,7 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"restaurant_os/internal/money"
 	"time"
 )
 
@@ -52,10 +53,13 @@ type QRCartItem struct {
 	QRSession   QRSession `gorm:"foreignKey:QRSessionID"`
 	MenuItemID  uint      `gorm:"not null"`
 	MenuItem    MenuItem  `gorm:"foreignKey:MenuItemID"`
-	Quantity    int       `gorm:"not null;default:1"`
-	UnitPrice   float64   `gorm:"type:decimal(10,2);not null"`
-	TotalPrice  float64   `gorm:"type:decimal(10,2);not null"`
-	Notes       string    `gorm:"type:text"` // Special instructions
+	VariantID   *uint
+	VariantName string      `gorm:"size:50"`
+	Quantity    int         `gorm:"not null;default:1"`
+	UnitPrice   money.Money `gorm:"type:decimal(10,2);not null"`
+	TotalPrice  money.Money `gorm:"type:decimal(10,2);not null"`
+	Notes       string      `gorm:"type:text"` // Special instructions
+	Modifiers   []QRCartItemModifier
 	AddedAt     time.Time `gorm:"not null"`
 	UpdatedAt   time.Time
 }
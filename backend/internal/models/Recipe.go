@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// RecipeIngredient is the quantity of an inventory item used by one portion of a
+// menu item, or by one modifier option when it is picked. Item lines with a
+// VariantID are only used for that variant, on top of the item's shared lines.
+type RecipeIngredient struct {
+	ID               uint             `gorm:"primaryKey"`
+	MenuItemID       *uint            `gorm:"index"` // Exactly one of MenuItemID and ModifierOptionID is set
+	VariantID        *uint            `gorm:"index"`
+	Variant          *MenuItemVariant `gorm:"foreignKey:VariantID"`
+	ModifierOptionID *uint            `gorm:"index"`
+	InventoryID      uint             `gorm:"not null;index"`
+	Inventory        Inventory        `gorm:"foreignKey:InventoryID"`
+	Quantity         float64          `gorm:"type:decimal(10,3);not null"`
+	Unit             InventoryUnit    `gorm:"type:VARCHAR(20);not null"` // Converted to the inventory unit when stock is deducted
+	SortOrder        int              `gorm:"default:0"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken stores issued refresh tokens so they can be rotated and revoked.
+// Only a SHA-256 hash of the token is persisted. Tokens issued from the same
+// login share a FamilyID; presenting an already rotated token revokes the family.
+type RefreshToken struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index"`
+	User         User      `gorm:"foreignKey:UserID"`
+	TokenHash    string    `gorm:"unique;not null;size:64"`
+	FamilyID     string    `gorm:"not null;size:36;index"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	RevokedAt    *time.Time
+	ReplacedByID *uint         // Token issued when this one was rotated
+	ReplacedBy   *RefreshToken `gorm:"foreignKey:ReplacedByID"`
+	UserAgent    string        `gorm:"type:text"`
+	IPAddress    string        `gorm:"size:45"` // IPv4 or IPv6
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsActive reports whether the token has been neither revoked nor expired.
+func (t *RefreshToken) IsActive() bool {
+	return t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
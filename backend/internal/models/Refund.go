@@ -0,0 +1,62 @@
+package models
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+)
+
+type RefundType string
+type RefundReason string
+type ApprovalMethod string
+
+const (
+	RefundTypeRefund RefundType = "REFUND" // Money returned on a paid order
+	RefundTypeVoid   RefundType = "VOID"   // Items or order removed before payment
+)
+
+const (
+	RefundReasonCustomerComplaint RefundReason = "CUSTOMER_COMPLAINT"
+	RefundReasonWrongItem         RefundReason = "WRONG_ITEM"
+	RefundReasonQualityIssue      RefundReason = "QUALITY_ISSUE"
+	RefundReasonDuplicateOrder    RefundReason = "DUPLICATE_ORDER"
+	RefundReasonPricingError      RefundReason = "PRICING_ERROR"
+	RefundReasonServiceDelay      RefundReason = "SERVICE_DELAY"
+	RefundReasonOther             RefundReason = "OTHER"
+)
+
+const (
+	ApprovalSelf ApprovalMethod = "SELF" // Requested by a manager with their own token
+	ApprovalPIN  ApprovalMethod = "PIN"  // Manager PIN override on a cashier's request
+)
+
+type Refund struct {
+	ID             uint           `gorm:"primaryKey"`
+	OrderID        uint           `gorm:"not null;index"`
+	Order          Order          `gorm:"foreignKey:OrderID"`
+	BranchID       uint           `gorm:"not null"`
+	Type           RefundType     `gorm:"type:VARCHAR(20);not null"`
+	ReasonCode     RefundReason   `gorm:"type:VARCHAR(30);not null"`
+	Notes          string         `gorm:"type:text"`
+	Amount         money.Money    `gorm:"type:decimal(10,2);not null"` // Money returned (REFUND) or removed from the bill (VOID)
+	IsFull         bool           `gorm:"default:false"`
+	Restock        bool           `gorm:"default:false"` // Returned items go back to stock
+	RequestedBy    uint           `gorm:"not null"`
+	RequestedUser  User           `gorm:"foreignKey:RequestedBy"`
+	ApprovedBy     uint           `gorm:"not null"`
+	ApprovedUser   User           `gorm:"foreignKey:ApprovedBy"`
+	ApprovalMethod ApprovalMethod `gorm:"type:VARCHAR(10);not null"`
+	Items          []RefundItem
+	Payments       []Payment // Negative payments created by a refund
+	CreatedAt      time.Time
+}
+
+type RefundItem struct {
+	ID          uint        `gorm:"primaryKey"`
+	RefundID    uint        `gorm:"not null;index"`
+	OrderItemID uint        `gorm:"not null;index"`
+	OrderItem   OrderItem   `gorm:"foreignKey:OrderItemID"`
+	Quantity    int         `gorm:"not null"`
+	Amount      money.Money `gorm:"type:decimal(10,2);not null"` // Line share including tax, service charge and discount
+	Restocked   bool        `gorm:"default:false"`
+	CreatedAt   time.Time
+}
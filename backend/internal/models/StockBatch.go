@@ -0,0 +1,31 @@
+package models
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+)
+
+// StockBatch is one delivery, or the opening balance, of an inventory item.
+// Stock going out draws batches down first in, first out; stock coming in
+// without a delivery, such as restocked refunds or count gains, is not batched.
+type StockBatch struct {
+	ID                uint        `gorm:"primaryKey"`
+	BranchID          uint        `gorm:"not null;index"`
+	InventoryID       uint        `gorm:"not null;index"`
+	Inventory         Inventory   `gorm:"foreignKey:InventoryID"`
+	BatchNumber       string      `gorm:"size:50"` // Supplier lot number, optional
+	ReceivedQuantity  float64     `gorm:"type:decimal(12,3);not null"`
+	RemainingQuantity float64     `gorm:"type:decimal(12,3);not null;index"`
+	UnitCost          money.Money `gorm:"type:decimal(10,2);default:0"` // Cost the batch was received at
+	ExpiryDate        *time.Time  `gorm:"index"`
+	ReceivedAt        time.Time   `gorm:"not null;index"`
+	ExpiryNotifiedAt  *time.Time  // Set when the expiry job flags the batch
+	DepletedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// IsExpired reports whether the batch is past its expiry date at now
+func (b *StockBatch) IsExpired(now time.Time) bool {
+	return b.ExpiryDate != nil && !now.Before(b.ExpiryDate.AddDate(0, 0, 1))
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+)
+
+type StockMovementType string
+
+const (
+	MovementOpening        StockMovementType = "OPENING" // Stock on hand when the ledger of an item started
+	MovementPurchase       StockMovementType = "PURCHASE_RECEIPT"
+	MovementSale           StockMovementType = "SALE" // Recipe usage of sold items; positive when refunded items are restocked
+	MovementWaste          StockMovementType = "WASTE"
+	MovementTransferIn     StockMovementType = "TRANSFER_IN"
+	MovementTransferOut    StockMovementType = "TRANSFER_OUT"
+	MovementStockTake      StockMovementType = "STOCK_TAKE"
+	MovementSupplierReturn StockMovementType = "SUPPLIER_RETURN"
+	MovementAdjustment     StockMovementType = "ADJUSTMENT" // Manual correction with a reason
+)
+
+// StockMovement is one entry of the append-only inventory ledger. The current
+// stock of an inventory item is the sum of its movements.
+type StockMovement struct {
+	ID            uint              `gorm:"primaryKey"`
+	BranchID      uint              `gorm:"not null;index"`
+	InventoryID   uint              `gorm:"not null;index"`
+	Inventory     Inventory         `gorm:"foreignKey:InventoryID"`
+	Type          StockMovementType `gorm:"type:VARCHAR(30);not null;index"`
+	Quantity      float64           `gorm:"type:decimal(12,3);not null"` // Signed, in the inventory unit
+	BalanceAfter  float64           `gorm:"type:decimal(12,3);not null"`
+	UnitCost      money.Money       `gorm:"type:decimal(10,2);default:0"` // Values the movement at the time it was posted
+	Reason        string            `gorm:"type:text"`
+	ReferenceType string            `gorm:"size:30"` // e.g. order_item or refund
+	ReferenceID   *uint
+	BatchID       *uint `gorm:"index"` // Batch opened by stock coming in, or the one chosen to draw from
+	PostedBy      *uint
+	PostedByUser  *User     `gorm:"foreignKey:PostedBy"`
+	CreatedAt     time.Time `gorm:"index"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"math"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+type StockTakeStatus string
+
+const (
+	StockTakeInProgress StockTakeStatus = "IN_PROGRESS" // Counts are being entered
+	StockTakeSubmitted  StockTakeStatus = "SUBMITTED"   // Every line is counted, waiting for approval
+	StockTakeApproved   StockTakeStatus = "APPROVED"    // Variances were posted to the stock ledger
+	StockTakeCancelled  StockTakeStatus = "CANCELLED"
+)
+
+// StockTake is a count of a branch's stock, optionally of one inventory category.
+// Expected quantities and unit costs are frozen when the take starts so that
+// sales during the count do not move the variance.
+type StockTake struct {
+	ID             uint            `gorm:"primaryKey"`
+	BranchID       uint            `gorm:"not null;index"`
+	Branch         Branch          `gorm:"foreignKey:BranchID"`
+	Category       string          `gorm:"size:50"` // Empty counts every category
+	Status         StockTakeStatus `gorm:"type:VARCHAR(20);not null;default:'IN_PROGRESS';index"`
+	Notes          string          `gorm:"type:text"`
+	StartedBy      uint            `gorm:"not null"`
+	StartedByUser  User            `gorm:"foreignKey:StartedBy"`
+	StartedAt      time.Time       `gorm:"not null;index"`
+	SubmittedAt    *time.Time
+	ApprovedBy     *uint
+	ApprovedByUser *User `gorm:"foreignKey:ApprovedBy"`
+	ApprovedAt     *time.Time
+	CancelledAt    *time.Time
+	Lines          []StockTakeLine
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type StockTakeLine struct {
+	ID               uint        `gorm:"primaryKey"`
+	StockTakeID      uint        `gorm:"not null;index"`
+	InventoryID      uint        `gorm:"not null;index"`
+	Inventory        Inventory   `gorm:"foreignKey:InventoryID"`
+	ExpectedQuantity float64     `gorm:"type:decimal(12,3);not null"` // Stock on hand when the take started
+	UnitCost         money.Money `gorm:"type:decimal(10,2);not null"` // Unit cost when the take started
+	CountedQuantity  *float64    `gorm:"type:decimal(12,3)"`          // In the inventory unit
+	CountedBy        *uint
+	CountedAt        *time.Time
+	Notes            string `gorm:"type:text"`
+	StockMovementID  *uint  // STOCK_TAKE movement posted on approval
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Variance returns counted minus expected to the stored 3 decimals, zero while
+// the line is not counted
+func (l *StockTakeLine) Variance() float64 {
+	if l.CountedQuantity == nil {
+		return 0
+	}
+	return math.Round((*l.CountedQuantity-l.ExpectedQuantity)*1000) / 1000
+}
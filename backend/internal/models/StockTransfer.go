@@ -0,0 +1,71 @@
+package models
+
+import (
+	"math"
+	"restaurant_os/internal/money"
+	"time"
+)
+
+type StockTransferStatus string
+
+const (
+	TransferRequested  StockTransferStatus = "REQUESTED"  // Waiting for the sending branch to dispatch
+	TransferDispatched StockTransferStatus = "DISPATCHED" // Stock has left the sending branch and is in transit
+	TransferReceived   StockTransferStatus = "RECEIVED"   // The receiving branch checked the stock in
+	TransferCancelled  StockTransferStatus = "CANCELLED"
+)
+
+// StockTransfer moves stock between two branches of the same restaurant, e.g.
+// from a central kitchen to its outlets. Dispatch posts TRANSFER_OUT movements
+// on the sending branch and receipt posts TRANSFER_IN movements on the
+// receiving branch, both at the sending branch's unit cost.
+type StockTransfer struct {
+	ID               uint                `gorm:"primaryKey"`
+	TransferNumber   string              `gorm:"uniqueIndex;not null;size:30"`
+	FromBranchID     uint                `gorm:"not null;index"`
+	FromBranch       Branch              `gorm:"foreignKey:FromBranchID"`
+	ToBranchID       uint                `gorm:"not null;index"`
+	ToBranch         Branch              `gorm:"foreignKey:ToBranchID"`
+	Status           StockTransferStatus `gorm:"type:VARCHAR(20);not null;default:'REQUESTED';index"`
+	Notes            string              `gorm:"type:text"`
+	RequestedBy      uint                `gorm:"not null"`
+	RequestedByUser  User                `gorm:"foreignKey:RequestedBy"`
+	DispatchedBy     *uint
+	DispatchedByUser *User `gorm:"foreignKey:DispatchedBy"`
+	DispatchedAt     *time.Time
+	ReceivedBy       *uint
+	ReceivedByUser   *User `gorm:"foreignKey:ReceivedBy"`
+	ReceivedAt       *time.Time
+	CancelledAt      *time.Time
+	CancelReason     string `gorm:"type:text"`
+	Lines            []StockTransferLine
+	CreatedAt        time.Time `gorm:"index"`
+	UpdatedAt        time.Time
+}
+
+// StockTransferLine is one item of a transfer. Quantities are in the unit of
+// the sending branch's item and converted on receipt.
+type StockTransferLine struct {
+	ID                 uint        `gorm:"primaryKey"`
+	StockTransferID    uint        `gorm:"not null;index"`
+	FromInventoryID    uint        `gorm:"not null;index"`
+	FromInventory      Inventory   `gorm:"foreignKey:FromInventoryID"`
+	ToInventoryID      *uint       `gorm:"index"` // Nil until receipt when the receiving branch does not stock the item yet
+	ToInventory        *Inventory  `gorm:"foreignKey:ToInventoryID"`
+	RequestedQuantity  float64     `gorm:"type:decimal(12,3);not null"`
+	DispatchedQuantity float64     `gorm:"type:decimal(12,3);default:0"`
+	ReceivedQuantity   float64     `gorm:"type:decimal(12,3);default:0"`
+	UnitCost           money.Money `gorm:"type:decimal(10,2);default:0"` // Transfer cost: the sending branch's unit cost at dispatch
+	ExpiryDate         *time.Time  // Expiry of the dispatched stock, carried to the receiving batch
+	DiscrepancyReason  string      `gorm:"size:200"` // Why less arrived than was dispatched
+	OutMovementID      *uint
+	InMovementID       *uint
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Shortfall returns how much of the dispatched quantity did not arrive, once
+// received, to the stored 3 decimals
+func (l *StockTransferLine) Shortfall() float64 {
+	return math.Round((l.DispatchedQuantity-l.ReceivedQuantity)*1000) / 1000
+}
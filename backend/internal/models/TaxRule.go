@@ -0,0 +1,74 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"restaurant_os/internal/money"
+	"strings"
+	"time"
+)
+
+// TaxRule is a configurable tax made of one or more components (e.g. CGST + SGST).
+// Rules apply to a whole restaurant, a branch, a menu category or a single menu item;
+// the most specific active rule wins.
+type TaxRule struct {
+	ID               uint          `gorm:"primaryKey"`
+	RestaurantID     uint          `gorm:"not null;index"`
+	Restaurant       Restaurant    `gorm:"foreignKey:RestaurantID"`
+	BranchID         *uint         `gorm:"index"` // nil = every branch of the restaurant
+	Branch           *Branch       `gorm:"foreignKey:BranchID"`
+	CategoryID       *uint         // Limit to a menu category
+	Category         *MenuCategory `gorm:"foreignKey:CategoryID"`
+	MenuItemID       *uint         // Limit to a single menu item
+	MenuItem         *MenuItem     `gorm:"foreignKey:MenuItemID"`
+	Name             string        `gorm:"not null;size:100"`
+	IsInclusive      bool          `gorm:"default:false"` // Menu prices already include this tax
+	TaxServiceCharge bool          `gorm:"default:false"` // Restaurant/branch-wide rules: also tax the service charge
+	ExemptOrderTypes string        `gorm:"size:100"`      // Comma-separated order types, e.g. "TAKEAWAY,DELIVERY"
+	IsActive         bool          `gorm:"default:true"`
+	Components       []TaxComponent
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+}
+
+type TaxComponent struct {
+	ID        uint    `gorm:"primaryKey"`
+	TaxRuleID uint    `gorm:"not null;index"`
+	Name      string  `gorm:"not null;size:50"`           // e.g. CGST, SGST, VAT
+	Rate      float64 `gorm:"type:decimal(6,3);not null"` // percent
+	SortOrder int     `gorm:"default:0"`
+}
+
+// TotalRate is the sum of the component rates in percent
+func (r *TaxRule) TotalRate() float64 {
+	var total float64
+	for _, c := range r.Components {
+		total += c.Rate
+	}
+	return total
+}
+
+// IsExempt reports whether orders of the given type are exempt from this rule
+func (r *TaxRule) IsExempt(orderType OrderType) bool {
+	for _, t := range strings.Split(r.ExemptOrderTypes, ",") {
+		if strings.TrimSpace(t) == string(orderType) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderTaxLine is one computed tax component on an order line, or on the
+// service charge when OrderItemID is nil
+type OrderTaxLine struct {
+	ID            uint        `gorm:"primaryKey"`
+	OrderID       uint        `gorm:"not null;index"`
+	OrderItemID   *uint       `gorm:"index"`
+	TaxRuleID     *uint       // nil when the configured fallback rate was used
+	Name          string      `gorm:"not null;size:50"`
+	Rate          float64     `gorm:"type:decimal(6,3);not null"`
+	TaxableAmount money.Money `gorm:"type:decimal(10,2);not null"`
+	Amount        money.Money `gorm:"type:decimal(10,2);not null"`
+	IsInclusive   bool        `gorm:"default:false"`
+	CreatedAt     time.Time
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// UnitConversion converts between units of one inventory item that have no
+// fixed ratio, e.g. 1 PACK = 12 PIECE or 1 BOTTLE = 750 ML
+type UnitConversion struct {
+	ID          uint          `gorm:"primaryKey"`
+	InventoryID uint          `gorm:"not null;uniqueIndex:idx_unit_conversion"`
+	FromUnit    InventoryUnit `gorm:"type:VARCHAR(20);not null;uniqueIndex:idx_unit_conversion"`
+	ToUnit      InventoryUnit `gorm:"type:VARCHAR(20);not null;uniqueIndex:idx_unit_conversion"`
+	Factor      float64       `gorm:"type:decimal(14,6);not null"` // ToUnit quantity in one FromUnit
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
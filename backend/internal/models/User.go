@@ -33,6 +33,7 @@ type User struct {
 	Name         string        `gorm:"not null;size:100"`
 	Email        string        `gorm:"unique;not null;size:255"`
 	Password     string        `gorm:"not null;size:255"`
+	PinHash      string        `gorm:"size:255"` // Manager approval PIN (bcrypt)
 	Phone        string        `gorm:"size:20"`
 	UserType     UserType      `gorm:"type:VARCHAR(20);not null"`
 	Role         *EmployeeRole `gorm:"type:VARCHAR(20)"`
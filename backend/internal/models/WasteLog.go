@@ -0,0 +1,35 @@
+package models
+
+import (
+	"restaurant_os/internal/money"
+	"time"
+)
+
+type WasteReason string
+
+const (
+	WasteExpired  WasteReason = "EXPIRED"
+	WasteSpoiled  WasteReason = "SPOILED"
+	WasteDropped  WasteReason = "DROPPED"
+	WasteOverPrep WasteReason = "OVER_PREP"
+)
+
+// WasteLog records stock thrown away and what it cost. Each entry posts a
+// WASTE movement to the stock ledger.
+type WasteLog struct {
+	ID              uint        `gorm:"primaryKey"`
+	BranchID        uint        `gorm:"not null;index"`
+	InventoryID     uint        `gorm:"not null;index"`
+	Inventory       Inventory   `gorm:"foreignKey:InventoryID"`
+	BatchID         *uint       `gorm:"index"` // Batch the waste came from, FIFO when not given
+	Batch           *StockBatch `gorm:"foreignKey:BatchID"`
+	Reason          WasteReason `gorm:"type:VARCHAR(20);not null;index"`
+	Quantity        float64     `gorm:"type:decimal(12,3);not null"` // In the inventory unit
+	UnitCost        money.Money `gorm:"type:decimal(10,2);default:0"`
+	Cost            money.Money `gorm:"type:decimal(10,2);default:0"`
+	Notes           string      `gorm:"type:text"`
+	StockMovementID *uint
+	LoggedBy        uint      `gorm:"not null"`
+	LoggedByUser    User      `gorm:"foreignKey:LoggedBy"`
+	CreatedAt       time.Time `gorm:"index"`
+}
@@ -58,6 +58,38 @@ func ConnectDB(cfg *config.Config) error {
 		&Reservation{},
 		&Supplier{},
 		&Table{},
+		&RefreshToken{},
+		&PasswordResetToken{},
+		&OrderStatusHistory{},
+		&Refund{},
+		&RefundItem{},
+		&AuditLog{},
+		&TaxRule{},
+		&TaxComponent{},
+		&OrderTaxLine{},
+		&MenuItemVariant{},
+		&ModifierGroup{},
+		&ModifierOption{},
+		&OrderItemModifier{},
+		&QRCartItemModifier{},
+		&Combo{},
+		&ComboSlot{},
+		&ComboSlotOption{},
+		&OrderCombo{},
+		&MenuSchedule{},
+		&RecipeIngredient{},
+		&StockMovement{},
+		&PurchaseOrder{},
+		&PurchaseOrderLine{},
+		&GoodsReceipt{},
+		&GoodsReceiptLine{},
+		&StockTake{},
+		&StockTakeLine{},
+		&StockBatch{},
+		&WasteLog{},
+		&UnitConversion{},
+		&StockTransfer{},
+		&StockTransferLine{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate tables: %w", err)
@@ -0,0 +1,63 @@
+package money
+
+import "strings"
+
+// Currency describes how amounts in an ISO currency are rounded
+type Currency struct {
+	Code     string
+	Decimals int   // Minor digits in use, at most 2 as amounts are stored with two decimals
+	CashStep Money // Smallest coin in circulation; cash totals are rounded to it
+}
+
+// currencies lists the currencies whose precision or cash rounding differ from
+// two decimals with 0.01 coins; anything else uses that default
+var currencies = map[string]Currency{
+	"INR": {Code: "INR", Decimals: 2, CashStep: FromMinor(100)},
+	"CHF": {Code: "CHF", Decimals: 2, CashStep: FromMinor(5)},
+	"AUD": {Code: "AUD", Decimals: 2, CashStep: FromMinor(5)},
+	"NZD": {Code: "NZD", Decimals: 2, CashStep: FromMinor(10)},
+	"CAD": {Code: "CAD", Decimals: 2, CashStep: FromMinor(5)},
+	"SEK": {Code: "SEK", Decimals: 2, CashStep: FromMinor(100)},
+	"AED": {Code: "AED", Decimals: 2, CashStep: FromMinor(25)},
+	"JPY": {Code: "JPY", Decimals: 0, CashStep: FromMinor(100)},
+	"KRW": {Code: "KRW", Decimals: 0, CashStep: FromMinor(1000)},
+}
+
+// CurrencyOf returns the rounding rules for an ISO currency code
+func CurrencyOf(code string) Currency {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if c, ok := currencies[code]; ok {
+		return c
+	}
+	return Currency{Code: code, Decimals: 2, CashStep: FromMinor(1)}
+}
+
+// WithCashStep returns the currency with a different cash rounding step;
+// a zero or negative step keeps the currency's own
+func (c Currency) WithCashStep(step Money) Currency {
+	if step.IsPositive() {
+		c.CashStep = step
+	}
+	return c
+}
+
+// unit is the smallest amount the currency uses, in hundredths
+func (c Currency) unit() int64 {
+	if c.Decimals <= 0 {
+		return Scale
+	}
+	if c.Decimals == 1 {
+		return 10
+	}
+	return 1
+}
+
+// Round rounds an exact amount to the currency's precision
+func (c Currency) Round(f Fraction) Money {
+	return f.RoundTo(c.unit())
+}
+
+// RoundCash rounds an amount to what can be paid in cash
+func (c Currency) RoundCash(m Money) Money {
+	return m.RoundTo(c.CashStep)
+}
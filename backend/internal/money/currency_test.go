@@ -0,0 +1,75 @@
+package money
+
+import "testing"
+
+func TestCurrencyOf(t *testing.T) {
+	tests := []struct {
+		code     string
+		want     string
+		decimals int
+		cashStep int64
+	}{
+		{code: "INR", want: "INR", decimals: 2, cashStep: 100},
+		{code: " chf ", want: "CHF", decimals: 2, cashStep: 5},
+		{code: "JPY", want: "JPY", decimals: 0, cashStep: 100},
+		{code: "usd", want: "USD", decimals: 2, cashStep: 1},
+		{code: "", want: "", decimals: 2, cashStep: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c := CurrencyOf(tt.code)
+			if c.Code != tt.want || c.Decimals != tt.decimals || c.CashStep.Minor() != tt.cashStep {
+				t.Errorf("CurrencyOf(%q) = %s, %d decimals, cash step %d; want %s, %d, %d",
+					tt.code, c.Code, c.Decimals, c.CashStep.Minor(), tt.want, tt.decimals, tt.cashStep)
+			}
+		})
+	}
+}
+
+func TestCurrencyRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		amount   int64
+		want     int64
+	}{
+		{name: "two decimals keep hundredths", currency: CurrencyOf("USD"), amount: 1234, want: 1234},
+		{name: "no decimals round down", currency: CurrencyOf("JPY"), amount: 12345, want: 12300},
+		{name: "no decimals half rounds up", currency: CurrencyOf("JPY"), amount: 12350, want: 12400},
+		{name: "one decimal", currency: Currency{Code: "XXX", Decimals: 1}, amount: 1235, want: 1240},
+		{name: "one decimal negative", currency: Currency{Code: "XXX", Decimals: 1}, amount: -1235, want: -1240},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.currency.Round(FromMinor(tt.amount).Exact()).Minor(); got != tt.want {
+				t.Errorf("%s Round(%d) = %d, want %d", tt.currency.Code, tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundCash(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		amount   int64
+		want     int64
+	}{
+		{name: "rupees up", currency: CurrencyOf("INR"), amount: 61360, want: 61400},
+		{name: "rupees half up", currency: CurrencyOf("INR"), amount: 61350, want: 61400},
+		{name: "rupees down", currency: CurrencyOf("INR"), amount: 61349, want: 61300},
+		{name: "five rappen down", currency: CurrencyOf("CHF"), amount: 102, want: 100},
+		{name: "five rappen up", currency: CurrencyOf("CHF"), amount: 103, want: 105},
+		{name: "quarter dirham", currency: CurrencyOf("AED"), amount: 1013, want: 1025},
+		{name: "cents unchanged", currency: CurrencyOf("USD"), amount: 1234, want: 1234},
+		{name: "override step", currency: CurrencyOf("USD").WithCashStep(FromMinor(10)), amount: 1234, want: 1230},
+		{name: "zero override keeps step", currency: CurrencyOf("CHF").WithCashStep(Zero), amount: 103, want: 105},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.currency.RoundCash(FromMinor(tt.amount)).Minor(); got != tt.want {
+				t.Errorf("%s RoundCash(%d) = %d, want %d", tt.currency.Code, tt.amount, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package money
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Fraction is an exact, unrounded amount in hundredths. It lets callers decide
+// where rounding happens, e.g. once per tax line or once per invoice.
+// The zero value is 0.
+type Fraction struct {
+	r *big.Rat
+}
+
+func (f Fraction) rat() *big.Rat {
+	if f.r == nil {
+		return new(big.Rat)
+	}
+	return f.r
+}
+
+// Add returns f + o
+func (f Fraction) Add(o Fraction) Fraction {
+	return Fraction{r: new(big.Rat).Add(f.rat(), o.rat())}
+}
+
+// Round rounds to whole hundredths, halves away from zero
+func (f Fraction) Round() Money {
+	return Money{minor: roundRat(f.rat(), 1)}
+}
+
+// RoundTo rounds to a multiple of step hundredths, halves away from zero.
+// A zero or negative step rounds to hundredths.
+func (f Fraction) RoundTo(step int64) Money {
+	if step <= 0 {
+		step = 1
+	}
+	return Money{minor: roundRat(f.rat(), step) * step}
+}
+
+// floor returns the largest whole number of hundredths not above f
+func (f Fraction) floor() int64 {
+	r := f.rat()
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() < 0 {
+		q.Sub(q, big.NewInt(1))
+	}
+	return q.Int64()
+}
+
+// Distribute rounds each part so the results add up exactly to total, using the
+// largest remainder method: every part is floored, then the hundredths still
+// missing go to the parts with the largest fractional remainders (earlier parts
+// win ties). total should be the rounded sum of the parts.
+func Distribute(total Money, parts []Fraction) []Money {
+	result := make([]Money, len(parts))
+	if len(parts) == 0 {
+		return result
+	}
+
+	type remainder struct {
+		index int
+		rest  *big.Rat
+	}
+	rests := make([]remainder, len(parts))
+	var allocated int64
+	for i, p := range parts {
+		floor := p.floor()
+		result[i] = Money{minor: floor}
+		allocated += floor
+		rests[i] = remainder{index: i, rest: new(big.Rat).Sub(p.rat(), new(big.Rat).SetInt64(floor))}
+	}
+	sort.SliceStable(rests, func(a, b int) bool {
+		return rests[a].rest.Cmp(rests[b].rest) > 0
+	})
+
+	missing := total.minor - allocated
+	for i := 0; missing > 0; i = (i + 1) % len(rests) {
+		result[rests[i].index].minor++
+		missing--
+	}
+	for i := len(rests) - 1; missing < 0; i = (i - 1 + len(rests)) % len(rests) {
+		result[rests[i].index].minor--
+		missing++
+	}
+	return result
+}
+
+// roundRat returns r / step rounded half away from zero
+func roundRat(r *big.Rat, step int64) int64 {
+	q := new(big.Rat).Quo(r, new(big.Rat).SetInt64(step))
+	num, den := new(big.Int).Abs(q.Num()), q.Denom()
+	// (2*num + den) / (2*den) rounds the magnitude half up
+	n := new(big.Int).Add(new(big.Int).Mul(num, big.NewInt(2)), den)
+	n.Quo(n, new(big.Int).Mul(den, big.NewInt(2)))
+	if q.Sign() < 0 {
+		n.Neg(n)
+	}
+	return n.Int64()
+}
@@ -0,0 +1,77 @@
+package money
+
+import "testing"
+
+func TestFractionRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		num, den int64
+		want     int64
+	}{
+		{name: "half rounds up", amount: 5, num: 1, den: 2, want: 3},
+		{name: "negative half rounds away from zero", amount: -5, num: 1, den: 2, want: -3},
+		{name: "below half rounds down", amount: 1, num: 1, den: 3, want: 0},
+		{name: "above half rounds up", amount: 2, num: 1, den: 3, want: 1},
+		{name: "exact", amount: 1000, num: 3, den: 4, want: 750},
+		{name: "zero denominator", amount: 1000, num: 1, den: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMinor(tt.amount).MulFrac(tt.num, tt.den).Round().Minor(); got != tt.want {
+				t.Errorf("%d * %d/%d rounded = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFractionRoundTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount, step int64
+		want         int64
+	}{
+		{name: "half a step rounds up", amount: 150, step: 100, want: 200},
+		{name: "below half a step rounds down", amount: 149, step: 100, want: 100},
+		{name: "negative half a step", amount: -150, step: 100, want: -200},
+		{name: "tenths", amount: 1235, step: 10, want: 1240},
+		{name: "zero step rounds to hundredths", amount: 149, step: 0, want: 149},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMinor(tt.amount).Exact().RoundTo(tt.step).Minor(); got != tt.want {
+				t.Errorf("RoundTo(%d, %d) = %d, want %d", tt.amount, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	third := FromMinor(10000).MulFrac(1, 3)
+	half := FromMinor(3).MulFrac(1, 2)
+	tests := []struct {
+		name  string
+		total int64
+		parts []Fraction
+		want  []int64
+	}{
+		{name: "missing hundredth goes to the first tie", total: 10000, parts: []Fraction{third, third, third}, want: []int64{3334, 3333, 3333}},
+		{name: "both halves round up", total: 3, parts: []Fraction{half, half}, want: []int64{2, 1}},
+		{name: "total below the floors takes from the last", total: 1, parts: []Fraction{half, half}, want: []int64{1, 0}},
+		{name: "floors already add up", total: 2, parts: []Fraction{half, half}, want: []int64{1, 1}},
+		{name: "no parts", total: 100, parts: nil, want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distribute(FromMinor(tt.total), tt.parts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Distribute returned %d parts, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].Minor() != tt.want[i] {
+					t.Errorf("part %d = %d, want %d", i, got[i].Minor(), tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Package money provides an exact fixed-point amount type for prices, totals and
+// payments. Amounts are held as an integer count of hundredths of the major unit
+// (cents, paisa), matching the decimal(10,2) columns they are stored in, so sums
+// never drift the way float64 arithmetic does.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units in one major unit
+const Scale = 100
+
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+// Money is an exact amount with two decimal places. The zero value is 0.00.
+type Money struct {
+	minor int64
+}
+
+// Zero is 0.00
+var Zero = Money{}
+
+// FromMinor returns the amount of the given number of hundredths
+func FromMinor(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// FromFloat converts a float such as a decoded JSON number, rounding half away
+// from zero to two decimals. Values with at most two decimals convert exactly.
+func FromFloat(v float64) Money {
+	return Money{minor: int64(math.Round(v * Scale))}
+}
+
+// Parse reads a decimal string like "12.5", "-0.05" or "100" exactly.
+// More than two decimals is an error rather than a silent rounding.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || len(frac) > 2 || !isDigits(whole) || !isDigits(frac) {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	frac = (frac + "00")[:2]
+	if whole == "" {
+		whole = "0"
+	}
+	major, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	minor, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	m := Money{minor: major*Scale + minor}
+	if neg {
+		m = m.Neg()
+	}
+	return m, nil
+}
+
+// isDigits reports whether s holds only ASCII digits, so that a sign can only
+// lead the whole amount
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Minor returns the amount in hundredths
+func (m Money) Minor() int64 { return m.minor }
+
+// Float64 returns the amount as a float, for display or interop only
+func (m Money) Float64() float64 { return float64(m.minor) / Scale }
+
+// String formats the amount with exactly two decimals, e.g. "-0.28"
+func (m Money) String() string {
+	sign, minor := "", m.minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/Scale, minor%Scale)
+}
+
+func (m Money) Add(o Money) Money { return Money{minor: m.minor + o.minor} }
+func (m Money) Sub(o Money) Money { return Money{minor: m.minor - o.minor} }
+func (m Money) Neg() Money        { return Money{minor: -m.minor} }
+
+// Mul multiplies by a whole quantity
+func (m Money) Mul(qty int) Money { return Money{minor: m.minor * int64(qty)} }
+
+func (m Money) IsZero() bool     { return m.minor == 0 }
+func (m Money) IsPositive() bool { return m.minor > 0 }
+func (m Money) IsNegative() bool { return m.minor < 0 }
+
+// Cmp returns -1, 0 or 1 when m is less than, equal to or greater than o
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.minor < o.minor:
+		return -1
+	case m.minor > o.minor:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) LessThan(o Money) bool    { return m.minor < o.minor }
+func (m Money) GreaterThan(o Money) bool { return m.minor > o.minor }
+
+// Min returns the smaller of a and b
+func Min(a, b Money) Money {
+	if a.minor < b.minor {
+		return a
+	}
+	return b
+}
+
+// Max returns the larger of a and b
+func Max(a, b Money) Money {
+	if a.minor > b.minor {
+		return a
+	}
+	return b
+}
+
+// Sum adds amounts
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, a := range amounts {
+		total.minor += a.minor
+	}
+	return total
+}
+
+// Percent returns the exact, unrounded rate percent of m. Rates are taken to
+// three decimals, matching the decimal(6,3) rate columns.
+func (m Money) Percent(rate float64) Fraction {
+	return m.MulFrac(rateMilli(rate), 100*1000)
+}
+
+// MulRate returns rate percent of m rounded half away from zero
+func (m Money) MulRate(rate float64) Money {
+	return m.Percent(rate).Round()
+}
+
+// MulFrac returns the exact, unrounded value of m * num / den
+func (m Money) MulFrac(num, den int64) Fraction {
+	if den == 0 {
+		return Fraction{}
+	}
+	r := new(big.Rat).SetInt64(m.minor)
+	return Fraction{r: r.Mul(r, big.NewRat(num, den))}
+}
+
+// Exact returns m as a fraction
+func (m Money) Exact() Fraction {
+	return Fraction{r: new(big.Rat).SetInt64(m.minor)}
+}
+
+// RoundTo rounds to the nearest multiple of step, halves away from zero.
+// A zero or negative step leaves the amount unchanged.
+func (m Money) RoundTo(step Money) Money {
+	if step.minor <= 0 {
+		return m
+	}
+	return m.MulFrac(1, step.minor).Round().Mul(int(step.minor))
+}
+
+// Allocate splits m over the weights in proportion, using the largest remainder
+// method so the parts always add up to m exactly. With no positive weight the
+// whole amount goes to the last part.
+func (m Money) Allocate(weights []Money) []Money {
+	if len(weights) == 0 {
+		return nil
+	}
+	var total int64
+	for _, w := range weights {
+		total += w.minor
+	}
+	parts := make([]Fraction, len(weights))
+	if total <= 0 {
+		parts[len(parts)-1] = m.Exact()
+		return Distribute(m, parts)
+	}
+	for i, w := range weights {
+		parts[i] = m.MulFrac(w.minor, total)
+	}
+	return Distribute(m, parts)
+}
+
+// Value stores the amount as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a decimal column; drivers return strings, bytes, integers or floats
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Zero
+	case float64:
+		*m = FromFloat(v)
+	case float32:
+		*m = FromFloat(float64(v))
+	case int64:
+		*m = FromMinor(v * Scale)
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	return nil
+}
+
+// scanString accepts more than two decimals from the database, e.g. SUM over a
+// numeric column, and rounds them
+func (m *Money) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err == nil {
+		*m = parsed
+		return nil
+	}
+	f, ferr := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if ferr != nil {
+		return err
+	}
+	*m = FromFloat(f)
+	return nil
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or numeric string exactly
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*m = Zero
+		return nil
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// rateMilli converts a percent rate to thousandths of a percent
+func rateMilli(rate float64) int64 {
+	return int64(math.Round(rate * 1000))
+}
@@ -0,0 +1,206 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "12.5", want: 1250},
+		{in: "-0.05", want: -5},
+		{in: "100", want: 10000},
+		{in: "+3.10", want: 310},
+		{in: ".5", want: 50},
+		{in: "1.", want: 100},
+		{in: " 7 ", want: 700},
+		{in: "-0", want: 0},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1.+5", wantErr: true},
+		{in: "+-5", wantErr: true},
+		{in: "-+5", wantErr: true},
+		{in: "--5", wantErr: true},
+		{in: "1,50", wantErr: true},
+		{in: "1.5x", wantErr: true},
+		{in: "1e3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("Parse(%q) = %v, %v; want ErrInvalidAmount", tt.in, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) returned %v", tt.in, err)
+			}
+			if got.Minor() != tt.want {
+				t.Errorf("Parse(%q) = %d hundredths, want %d", tt.in, got.Minor(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want int64
+	}{
+		{in: 0.1 + 0.2, want: 30},
+		{in: 19.99, want: 1999},
+		{in: 0.125, want: 13},
+		{in: -0.125, want: -13},
+		{in: 0.004, want: 0},
+	}
+	for _, tt := range tests {
+		if got := FromFloat(tt.in).Minor(); got != tt.want {
+			t.Errorf("FromFloat(%v) = %d hundredths, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 5, want: "0.05"},
+		{in: -5, want: "-0.05"},
+		{in: 123456, want: "1234.56"},
+	}
+	for _, tt := range tests {
+		if got := FromMinor(tt.in).String(); got != tt.want {
+			t.Errorf("FromMinor(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMulRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   float64
+		want   int64
+	}{
+		{name: "exact", amount: 1000, rate: 18, want: 180},
+		{name: "half rounds up", amount: 25, rate: 10, want: 3},
+		{name: "negative half rounds away from zero", amount: -25, rate: 10, want: -3},
+		{name: "below half rounds down", amount: 24, rate: 10, want: 2},
+		{name: "three decimal rate", amount: 10000, rate: 2.125, want: 213},
+		{name: "zero rate", amount: 999, rate: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMinor(tt.amount).MulRate(tt.rate).Minor(); got != tt.want {
+				t.Errorf("MulRate(%d, %v) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount, step int64
+		want         int64
+	}{
+		{name: "to whole units", amount: 61360, step: 100, want: 61400},
+		{name: "half a unit rounds up", amount: 61350, step: 100, want: 61400},
+		{name: "below half a unit rounds down", amount: 61349, step: 100, want: 61300},
+		{name: "to five hundredths down", amount: 7, step: 5, want: 5},
+		{name: "to five hundredths up", amount: 8, step: 5, want: 10},
+		{name: "negative", amount: -3, step: 5, want: -5},
+		{name: "zero step leaves the amount", amount: 1234, step: 0, want: 1234},
+		{name: "negative step leaves the amount", amount: 1234, step: -5, want: 1234},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMinor(tt.amount).RoundTo(FromMinor(tt.step)).Minor(); got != tt.want {
+				t.Errorf("RoundTo(%d, %d) = %d, want %d", tt.amount, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		weights []int64
+		want    []int64
+	}{
+		{name: "even thirds", amount: 1000, weights: []int64{1, 1, 1}, want: []int64{334, 333, 333}},
+		{name: "largest remainder first", amount: 5, weights: []int64{1, 2}, want: []int64{2, 3}},
+		{name: "negative amount", amount: -1000, weights: []int64{1, 1, 1}, want: []int64{-333, -333, -334}},
+		{name: "no positive weight", amount: 1000, weights: []int64{0, 0}, want: []int64{0, 1000}},
+		{name: "zero amount", amount: 0, weights: []int64{3, 7}, want: []int64{0, 0}},
+		{name: "no weights", amount: 1000, weights: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := make([]Money, len(tt.weights))
+			for i, w := range tt.weights {
+				weights[i] = FromMinor(w)
+			}
+			got := FromMinor(tt.amount).Allocate(weights)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Allocate returned %d parts, want %d", len(got), len(tt.want))
+			}
+			var sum int64
+			for i := range got {
+				sum += got[i].Minor()
+				if got[i].Minor() != tt.want[i] {
+					t.Errorf("part %d = %d, want %d", i, got[i].Minor(), tt.want[i])
+				}
+			}
+			if len(got) > 0 && sum != tt.amount {
+				t.Errorf("parts add up to %d, want %d", sum, tt.amount)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    int64
+		wantErr bool
+	}{
+		{name: "nil", src: nil, want: 0},
+		{name: "float", src: 12.34, want: 1234},
+		{name: "int", src: int64(12), want: 1200},
+		{name: "string", src: "12.34", want: 1234},
+		{name: "bytes", src: []byte("-0.50"), want: -50},
+		{name: "bad string", src: "12.3.4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %v, want an error", tt.src, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) returned %v", tt.src, err)
+			}
+			if m.Minor() != tt.want {
+				t.Errorf("Scan(%v) = %d hundredths, want %d", tt.src, m.Minor(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package notify creates the in-app notifications raised by background jobs.
+package notify
+
+import (
+	"fmt"
+	"restaurant_os/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BranchManagers sends a copy of base to each active manager of a branch, or a
+// single copy to the whole branch when it has none, and returns how many
+// notifications were created
+func BranchManagers(tx *gorm.DB, branchID uint, base models.Notification) (int, error) {
+	var managerIDs []uint
+	err := tx.Model(&models.User{}).
+		Where("branch_id = ? AND role = ? AND is_active = ?", branchID, models.RoleManager, true).
+		Pluck("id", &managerIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("error fetching branch managers: %w", err)
+	}
+
+	base.BranchID = branchID
+	notifications := make([]models.Notification, 0, len(managerIDs))
+	for _, id := range managerIDs {
+		n := base
+		userID := id
+		n.UserID = &userID
+		notifications = append(notifications, n)
+	}
+	if len(notifications) == 0 {
+		notifications = append(notifications, base)
+	}
+	if err := tx.Create(&notifications).Error; err != nil {
+		return 0, fmt.Errorf("error creating notification: %w", err)
+	}
+	return len(notifications), nil
+}
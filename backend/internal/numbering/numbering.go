@@ -0,0 +1,53 @@
+// Package numbering hands out the human-readable document numbers of a branch,
+// such as order, purchase order, goods receipt and transfer numbers.
+package numbering
+
+import (
+	"fmt"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/schedule"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Next returns the next number of a kind of document for a branch and the
+// current day in its restaurant's time zone, e.g. ORD-20250614-1-0007.
+// Sequences are padded to four digits and keep counting past 9999. Callers
+// serialize concurrent numbering, by locking a row or by retrying on the
+// unique index of column.
+func Next(tx *gorm.DB, model interface{}, column, kind string, branchID uint) (string, error) {
+	var timeZone string
+	err := tx.Model(&models.Branch{}).
+		Select("restaurants.time_zone").
+		Joins("JOIN restaurants ON restaurants.id = branches.restaurant_id").
+		Where("branches.id = ?", branchID).
+		Scan(&timeZone).Error
+	if err != nil {
+		return "", fmt.Errorf("error generating %s number: %w", kind, err)
+	}
+	day := time.Now().In(schedule.Location(timeZone)).Format("20060102")
+	prefix := fmt.Sprintf("%s-%s-%d-", kind, day, branchID)
+
+	// Longer sequences are larger, so ordering by length first finds the
+	// numeric maximum where a plain string order would stop at 9999
+	var last string
+	err = tx.Unscoped().Model(model).
+		Where(column+" LIKE ?", prefix+"%").
+		Order("LENGTH("+column+") DESC, "+column+" DESC").
+		Limit(1).
+		Pluck(column, &last).Error
+	if err != nil {
+		return "", fmt.Errorf("error generating %s number: %w", kind, err)
+	}
+
+	seq := 1
+	if last != "" {
+		if n, err := strconv.Atoi(strings.TrimPrefix(last, prefix)); err == nil {
+			seq = n + 1
+		}
+	}
+	return fmt.Sprintf("%s%04d", prefix, seq), nil
+}
@@ -0,0 +1,133 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/config"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const defaultCost = 12
+
+// Policy describes the rules a new password has to satisfy
+type Policy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// PolicyError lists every rule a password failed
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password does not meet policy: " + strings.Join(e.Violations, "; ")
+}
+
+// CurrentPolicy builds the password policy from configuration, falling back to defaults
+func CurrentPolicy() Policy {
+	policy := Policy{
+		MinLength:    8,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+	cfg := config.EnvConfig
+	if cfg == nil {
+		return policy
+	}
+	if n, err := strconv.Atoi(cfg.PasswordMinLength); err == nil && n > 0 {
+		policy.MinLength = n
+	}
+	policy.RequireUpper = parseBool(cfg.PasswordRequireUpper, policy.RequireUpper)
+	policy.RequireLower = parseBool(cfg.PasswordRequireLower, policy.RequireLower)
+	policy.RequireDigit = parseBool(cfg.PasswordRequireDigit, policy.RequireDigit)
+	policy.RequireSymbol = parseBool(cfg.PasswordRequireSymbol, policy.RequireSymbol)
+	return policy
+}
+
+// Validate checks a password against the policy and returns a *PolicyError on failure
+func (p Policy) Validate(password string) error {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var violations []string
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	// bcrypt ignores everything after 72 bytes
+	if len(password) > 72 {
+		violations = append(violations, "must be at most 72 bytes")
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// Hash hashes a password with bcrypt using the configured cost
+func Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), cost())
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// Check reports whether password matches the bcrypt hash
+func Check(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
+
+// IsPolicyError reports whether err is a password policy violation
+func IsPolicyError(err error) bool {
+	var policyErr *PolicyError
+	return errors.As(err, &policyErr)
+}
+
+func cost() int {
+	if config.EnvConfig != nil {
+		if n, err := strconv.Atoi(config.EnvConfig.PasswordBcryptCost); err == nil && n >= bcrypt.MinCost && n <= bcrypt.MaxCost {
+			return n
+		}
+	}
+	return defaultCost
+}
+
+func parseBool(value string, fallback bool) bool {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	return fallback
+}
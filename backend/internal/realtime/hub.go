@@ -0,0 +1,77 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published by the order flow
+const (
+	EventOrderCreated     = "order.created"
+	EventOrderUpdated     = "order.updated"
+	EventOrderItemUpdated = "order_item.updated"
+)
+
+// Event is a change notification for a branch. Subscribers reload whatever
+// they display from the referenced IDs so events stay small.
+type Event struct {
+	Type        string    `json:"type"`
+	BranchID    uint      `json:"branch_id"`
+	OrderID     uint      `json:"order_id,omitempty"`
+	OrderItemID uint      `json:"order_item_id,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// subscriberBuffer is how many events a slow subscriber may lag behind before events are dropped
+const subscriberBuffer = 64
+
+type hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan Event]struct{}
+}
+
+var defaultHub = &hub{subscribers: make(map[uint]map[chan Event]struct{})}
+
+// Subscribe registers a listener for a branch's events. The returned function
+// must be called to unsubscribe; it closes the channel.
+func Subscribe(branchID uint) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	defaultHub.mu.Lock()
+	if defaultHub.subscribers[branchID] == nil {
+		defaultHub.subscribers[branchID] = make(map[chan Event]struct{})
+	}
+	defaultHub.subscribers[branchID][ch] = struct{}{}
+	defaultHub.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			defaultHub.mu.Lock()
+			delete(defaultHub.subscribers[branchID], ch)
+			if len(defaultHub.subscribers[branchID]) == 0 {
+				delete(defaultHub.subscribers, branchID)
+			}
+			defaultHub.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish delivers an event to every subscriber of its branch without blocking;
+// subscribers whose buffer is full miss the event.
+func Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	defaultHub.mu.RLock()
+	defer defaultHub.mu.RUnlock()
+	for ch := range defaultHub.subscribers[event.BranchID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -3,6 +3,18 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	auth "restaurant_os/internal/api/auth/routes"
+	branch "restaurant_os/internal/api/branch/routes"
+	customer "restaurant_os/internal/api/customer/routes"
+	inventory "restaurant_os/internal/api/inventory/routes"
+	kds "restaurant_os/internal/api/kds/routes"
+	menu "restaurant_os/internal/api/menu/routes"
+	order "restaurant_os/internal/api/order/routes"
+	payment "restaurant_os/internal/api/payment/routes"
+	purchase "restaurant_os/internal/api/purchase/routes"
+	qr "restaurant_os/internal/api/qr/routes"
+	recipe "restaurant_os/internal/api/recipe/routes"
+	tax "restaurant_os/internal/api/tax/routes"
+	transfer "restaurant_os/internal/api/transfer/routes"
 	user "restaurant_os/internal/api/user/routes"
 )
 
@@ -10,7 +22,21 @@ func RegisterRoutes(app *fiber.App) {
 	// Initialize all route groups
 	api := app.Group("/api/v1")
 
+	// Features attach auth to their own prefix group: middleware on an
+	// empty-prefix group would also run for every route registered after it
 	auth.RegisterAuthRoutes(api)
 	user.RegisterUserRoutes(api)
+	order.RegisterOrderRoutes(api)
+	payment.RegisterPaymentRoutes(api)
+	kds.RegisterKDSRoutes(api)
+	qr.RegisterQRRoutes(api)
+	tax.RegisterTaxRoutes(api)
+	menu.RegisterMenuRoutes(api)
+	branch.RegisterBranchRoutes(api)
+	recipe.RegisterRecipeRoutes(api)
+	inventory.RegisterInventoryRoutes(api)
+	purchase.RegisterPurchaseRoutes(api)
+	transfer.RegisterTransferRoutes(api)
+	customer.RegisterCustomerRoutes(api)
 
 }
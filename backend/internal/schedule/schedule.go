@@ -0,0 +1,143 @@
+// Package schedule evaluates menu schedules and branch opening hours in the
+// restaurant's time zone.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"time"
+	_ "time/tzdata" // Restaurant time zones must resolve on hosts without zoneinfo
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotScheduled = errors.New("menu item is not served at this time")
+	ErrBranchClosed = errors.New("branch is closed at this time")
+)
+
+// RuleSet holds the active menu schedules and the opening hours of one branch
+type RuleSet struct {
+	Location     *time.Location
+	Currency     money.Currency // Sets the rounding precision of scheduled prices
+	OpeningHours models.OpeningHours
+
+	items      map[uint][]models.MenuSchedule
+	categories map[uint][]models.MenuSchedule
+}
+
+// LoadRules loads the active schedules and opening hours of a branch
+func LoadRules(tx *gorm.DB, branchID uint) (*RuleSet, error) {
+	var branch models.Branch
+	if err := tx.Preload("Restaurant").First(&branch, branchID).Error; err != nil {
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+
+	var schedules []models.MenuSchedule
+	if err := tx.Where("branch_id = ? AND is_active = ?", branchID, true).Order("id ASC").Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("error fetching menu schedules: %w", err)
+	}
+
+	rules := &RuleSet{
+		Location:     Location(branch.Restaurant.TimeZone),
+		Currency:     money.CurrencyOf(branch.Restaurant.Currency),
+		OpeningHours: branch.OpeningHours,
+		items:        map[uint][]models.MenuSchedule{},
+		categories:   map[uint][]models.MenuSchedule{},
+	}
+	for _, s := range schedules {
+		switch {
+		case s.MenuItemID != nil:
+			rules.items[*s.MenuItemID] = append(rules.items[*s.MenuItemID], s)
+		case s.CategoryID != nil:
+			rules.categories[*s.CategoryID] = append(rules.categories[*s.CategoryID], s)
+		}
+	}
+	return rules, nil
+}
+
+// Location resolves a restaurant time zone, falling back to UTC when it is unknown
+func Location(timeZone string) *time.Location {
+	if loc, err := time.LoadLocation(timeZone); err == nil && timeZone != "" {
+		return loc
+	}
+	return time.UTC
+}
+
+// IsEmpty reports whether no schedule applies to the branch's menu
+func (r *RuleSet) IsEmpty() bool {
+	return len(r.items) == 0 && len(r.categories) == 0
+}
+
+// IsOpen reports whether the branch is open at the given time
+func (r *RuleSet) IsOpen(at time.Time) bool {
+	return r.OpeningHours.IsOpen(at.In(r.Location))
+}
+
+// CategoryAvailable reports whether a category's availability schedules, if it
+// has any, allow ordering at the given time
+func (r *RuleSet) CategoryAvailable(categoryID uint, at time.Time) bool {
+	return available(r.categories[categoryID], at.In(r.Location))
+}
+
+// Available reports whether an item may be ordered at the given time: both its
+// own availability schedules and its category's must allow it
+func (r *RuleSet) Available(item *models.MenuItem, at time.Time) bool {
+	if item.CategoryID != nil && !r.CategoryAvailable(*item.CategoryID, at) {
+		return false
+	}
+	return available(r.items[item.ID], at.In(r.Location))
+}
+
+// Check returns ErrNotScheduled when an item may not be ordered at the given time
+func (r *RuleSet) Check(item *models.MenuItem, at time.Time) error {
+	if !r.Available(item, at) {
+		return fmt.Errorf("%w: %s", ErrNotScheduled, item.Name)
+	}
+	return nil
+}
+
+// Discount returns the largest percentage off of the item's and its category's
+// price schedules active at the given time. Discounts do not stack.
+func (r *RuleSet) Discount(item *models.MenuItem, at time.Time) float64 {
+	local := at.In(r.Location)
+	best := 0.0
+	schedules := r.items[item.ID]
+	if item.CategoryID != nil {
+		schedules = append(schedules[:len(schedules):len(schedules)], r.categories[*item.CategoryID]...)
+	}
+	for i := range schedules {
+		s := &schedules[i]
+		if s.Type == models.SchedulePrice && s.DiscountPercent > best && s.ActiveAt(local) {
+			best = s.DiscountPercent
+		}
+	}
+	return best
+}
+
+// Price applies the item's scheduled discount to a price, rounded to the currency
+func (r *RuleSet) Price(item *models.MenuItem, price money.Money, at time.Time) money.Money {
+	discount := r.Discount(item, at)
+	if discount <= 0 {
+		return price
+	}
+	return price.Sub(r.Currency.Round(price.Percent(discount)))
+}
+
+// available reports whether one of the availability schedules is active, or there are none
+func available(schedules []models.MenuSchedule, local time.Time) bool {
+	restricted := false
+	for i := range schedules {
+		s := &schedules[i]
+		if s.Type != models.ScheduleAvailability {
+			continue
+		}
+		if s.ActiveAt(local) {
+			return true
+		}
+		restricted = true
+	}
+	return !restricted
+}
@@ -0,0 +1,100 @@
+package stock
+
+import (
+	"errors"
+	"fmt"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBatchNotFound = errors.New("stock batch not found")
+	ErrBatchShort    = errors.New("not enough stock left in batch")
+)
+
+// opensBatch reports whether stock coming in with a movement of type t is a
+// delivery that starts a batch of its own
+func opensBatch(t models.StockMovementType) bool {
+	switch t {
+	case models.MovementOpening, models.MovementPurchase, models.MovementTransferIn:
+		return true
+	}
+	return false
+}
+
+func openBatch(tx *gorm.DB, inventory *models.Inventory, quantity float64, unitCost money.Money, number string, expiry *time.Time) (*models.StockBatch, error) {
+	batch := &models.StockBatch{
+		BranchID:          inventory.BranchID,
+		InventoryID:       inventory.ID,
+		BatchNumber:       number,
+		ReceivedQuantity:  quantity,
+		RemainingQuantity: quantity,
+		UnitCost:          unitCost,
+		ExpiryDate:        expiry,
+		ReceivedAt:        time.Now(),
+	}
+	if err := tx.Omit("Inventory").Create(batch).Error; err != nil {
+		return nil, fmt.Errorf("error opening stock batch: %w", err)
+	}
+	return batch, nil
+}
+
+// drawBatches takes quantity out of the batches of an inventory item, oldest
+// first, or all of it out of batchID when one is given. Stock beyond what the
+// batches hold is unbatched and only moves the current stock.
+func drawBatches(tx *gorm.DB, inventory *models.Inventory, quantity float64, batchID *uint) error {
+	db := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("inventory_id = ? AND remaining_quantity > 0", inventory.ID)
+	if batchID != nil {
+		db = db.Where("id = ?", *batchID)
+	}
+	var batches []models.StockBatch
+	if err := db.Order("received_at ASC, id ASC").Find(&batches).Error; err != nil {
+		return fmt.Errorf("error fetching stock batches: %w", err)
+	}
+	if batchID != nil {
+		if len(batches) == 0 {
+			return fmt.Errorf("%w: batch %d of %s is used up or does not exist", ErrBatchNotFound, *batchID, inventory.ItemName)
+		}
+		if remaining := batches[0].RemainingQuantity; quantity > remaining {
+			return fmt.Errorf("%w: batch %d has %g %s left", ErrBatchShort, *batchID, remaining, inventory.Unit)
+		}
+	}
+
+	now := time.Now()
+	for _, batch := range batches {
+		if quantity <= 0 {
+			break
+		}
+		taken := min(quantity, batch.RemainingQuantity)
+		remaining := RoundQuantity(batch.RemainingQuantity - taken)
+		updates := map[string]interface{}{"remaining_quantity": remaining}
+		if remaining <= 0 {
+			updates["depleted_at"] = now
+		}
+		if err := tx.Model(&models.StockBatch{}).Where("id = ?", batch.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("error updating stock batch: %w", err)
+		}
+		quantity = RoundQuantity(quantity - taken)
+	}
+	return nil
+}
+
+// refreshExpiry sets the expiry date of an inventory item to the earliest
+// expiry of its batches on hand
+func refreshExpiry(tx *gorm.DB, inventoryID uint) error {
+	var batch models.StockBatch
+	err := tx.Where("inventory_id = ? AND remaining_quantity > 0 AND expiry_date IS NOT NULL", inventoryID).
+		Order("expiry_date ASC").Limit(1).Find(&batch).Error
+	if err != nil {
+		return fmt.Errorf("error fetching stock batches: %w", err)
+	}
+	if err := tx.Model(&models.Inventory{}).Where("id = ?", inventoryID).Update("expiry_date", batch.ExpiryDate).Error; err != nil {
+		return fmt.Errorf("error updating expiry date: %w", err)
+	}
+	return nil
+}
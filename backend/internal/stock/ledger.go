@@ -0,0 +1,208 @@
+package stock
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInventoryNotFound = errors.New("inventory item not found")
+
+// Movement is a stock change to post to the ledger
+type Movement struct {
+	InventoryID   uint
+	Type          models.StockMovementType
+	Quantity      float64      // Signed, in the inventory unit
+	UnitCost      *money.Money // Defaults to the inventory unit cost
+	Reason        string
+	ReferenceType string
+	ReferenceID   *uint
+	PostedBy      *uint
+	BatchID       *uint      // Stock going out: take all of it from this batch instead of FIFO
+	BatchNumber   string     // Stock coming in: lot number of the batch it opens
+	ExpiryDate    *time.Time // Stock coming in: expiry of the batch it opens
+}
+
+// Post appends a movement to the ledger and applies it to the current stock of
+// its inventory item, whose row stays locked for the rest of the transaction
+func Post(tx *gorm.DB, m Movement) (*models.StockMovement, error) {
+	inventory, err := lockInventory(tx, m.InventoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureOpening(tx, inventory); err != nil {
+		return nil, err
+	}
+
+	balance := RoundQuantity(inventory.CurrentStock + m.Quantity)
+	movement := &models.StockMovement{
+		BranchID:      inventory.BranchID,
+		InventoryID:   inventory.ID,
+		Type:          m.Type,
+		Quantity:      RoundQuantity(m.Quantity),
+		BalanceAfter:  balance,
+		UnitCost:      inventory.UnitCost,
+		Reason:        m.Reason,
+		ReferenceType: m.ReferenceType,
+		ReferenceID:   m.ReferenceID,
+		PostedBy:      m.PostedBy,
+	}
+	if m.UnitCost != nil {
+		movement.UnitCost = *m.UnitCost
+	}
+
+	switch {
+	case movement.Quantity < 0:
+		if err := drawBatches(tx, inventory, -movement.Quantity, m.BatchID); err != nil {
+			return nil, err
+		}
+		movement.BatchID = m.BatchID
+	case movement.Quantity > 0 && opensBatch(m.Type):
+		batch, err := openBatch(tx, inventory, movement.Quantity, movement.UnitCost, m.BatchNumber, m.ExpiryDate)
+		if err != nil {
+			return nil, err
+		}
+		movement.BatchID = &batch.ID
+	}
+
+	if err := tx.Model(inventory).Update("current_stock", balance).Error; err != nil {
+		return nil, fmt.Errorf("error updating stock: %w", err)
+	}
+	if err := tx.Omit("Inventory", "PostedByUser").Create(movement).Error; err != nil {
+		return nil, fmt.Errorf("error recording stock movement: %w", err)
+	}
+	if err := refreshExpiry(tx, inventory.ID); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// Receive posts stock coming in at unitCost, as a PURCHASE_RECEIPT movement
+// unless m has another type, and moves the unit cost of the item to the
+// weighted average of the stock on hand and the received quantity. Stock at or
+// below zero carries no value, so the received cost replaces it.
+func Receive(tx *gorm.DB, m Movement, unitCost money.Money) (*models.StockMovement, error) {
+	inventory, err := lockInventory(tx, m.InventoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	average := unitCost
+	if onHand := inventory.CurrentStock; onHand > 0 {
+		held := inventory.UnitCost.MulFrac(int64(math.Round(onHand*quantityScale)), quantityScale)
+		received := unitCost.MulFrac(int64(math.Round(m.Quantity*quantityScale)), quantityScale)
+		average = PerUnit(held.Add(received).Round(), onHand+m.Quantity)
+	}
+
+	if m.Type == "" {
+		m.Type = models.MovementPurchase
+	}
+	m.UnitCost = &unitCost
+	movement, err := Post(tx, m)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.Inventory{}).Where("id = ?", inventory.ID).Update("unit_cost", average).Error; err != nil {
+		return nil, fmt.Errorf("error updating unit cost: %w", err)
+	}
+	return movement, nil
+}
+
+// Reconcile resets the current stock of an inventory item to the sum of its
+// movements and returns the stock before and after
+func Reconcile(tx *gorm.DB, inventoryID uint) (before, ledger float64, err error) {
+	inventory, err := lockInventory(tx, inventoryID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := ensureOpening(tx, inventory); err != nil {
+		return 0, 0, err
+	}
+	if ledger, err = LedgerBalance(tx, inventory.ID); err != nil {
+		return 0, 0, err
+	}
+	before = inventory.CurrentStock
+	if ledger != before {
+		if err := tx.Model(inventory).Update("current_stock", ledger).Error; err != nil {
+			return 0, 0, fmt.Errorf("error updating stock: %w", err)
+		}
+	}
+	return before, ledger, nil
+}
+
+// LedgerBalance returns the sum of the movements of an inventory item
+func LedgerBalance(tx *gorm.DB, inventoryID uint) (float64, error) {
+	var sum *float64
+	err := tx.Model(&models.StockMovement{}).Where("inventory_id = ?", inventoryID).Select("SUM(quantity)").Scan(&sum).Error
+	if err != nil {
+		return 0, fmt.Errorf("error summing stock movements: %w", err)
+	}
+	if sum == nil {
+		return 0, nil
+	}
+	return RoundQuantity(*sum), nil
+}
+
+// ensureOpening records the stock of an item that has no movements yet as its
+// opening balance, so that the ledger always adds up to the current stock
+func ensureOpening(tx *gorm.DB, inventory *models.Inventory) error {
+	var count int64
+	if err := tx.Model(&models.StockMovement{}).Where("inventory_id = ?", inventory.ID).Count(&count).Error; err != nil {
+		return fmt.Errorf("error counting stock movements: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	opening := &models.StockMovement{
+		BranchID:     inventory.BranchID,
+		InventoryID:  inventory.ID,
+		Type:         models.MovementOpening,
+		Quantity:     RoundQuantity(inventory.CurrentStock),
+		BalanceAfter: RoundQuantity(inventory.CurrentStock),
+		UnitCost:     inventory.UnitCost,
+		Reason:       "opening balance",
+	}
+	if opening.Quantity > 0 {
+		batch, err := openBatch(tx, inventory, opening.Quantity, opening.UnitCost, "", inventory.ExpiryDate)
+		if err != nil {
+			return err
+		}
+		opening.BatchID = &batch.ID
+	}
+	if err := tx.Omit("Inventory", "PostedByUser").Create(opening).Error; err != nil {
+		return fmt.Errorf("error recording opening balance: %w", err)
+	}
+	return nil
+}
+
+func lockInventory(tx *gorm.DB, id uint) (*models.Inventory, error) {
+	var inventory models.Inventory
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&inventory, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInventoryNotFound
+		}
+		return nil, fmt.Errorf("error fetching inventory item: %w", err)
+	}
+	return &inventory, nil
+}
+
+// RoundQuantity rounds to the three decimals stock quantities are stored with
+func RoundQuantity(q float64) float64 {
+	return math.Round(q*1000) / 1000
+}
+
+// PerUnit returns the unit cost at which quantity is worth total
+func PerUnit(total money.Money, quantity float64) money.Money {
+	return total.MulFrac(quantityScale, int64(math.Round(quantity*quantityScale))).Round()
+}
+
+// Value prices a quantity in the stock unit at unitCost, keeping its sign
+func Value(unitCost money.Money, quantity float64) money.Money {
+	return unitCost.MulFrac(int64(math.Round(quantity*quantityScale)), quantityScale).Round()
+}
@@ -0,0 +1,161 @@
+// Package stock turns menu item recipes into inventory usage, deducting stock
+// as order items are cooked and costing recipes at the inventory unit costs.
+package stock
+
+import (
+	"fmt"
+	"math"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// quantityScale is the precision, in parts per unit, at which quantities are costed
+const quantityScale = 1_000_000
+
+// Usage maps inventory IDs to quantities in each inventory item's own unit
+type Usage map[uint]float64
+
+// DeductOn returns the order item status at which recipe quantities leave stock:
+// PREPARING when the kitchen fires the item (the default) or SERVED
+func DeductOn() models.OrderItemStatus {
+	if config.EnvConfig != nil && strings.EqualFold(strings.TrimSpace(config.EnvConfig.StockDeductOn), "SERVED") {
+		return models.OrderItemServed
+	}
+	return models.OrderItemPreparing
+}
+
+// LoadRecipe returns the recipe lines used by one portion of a menu item with the
+// given variant and modifier options, with their inventory items
+func LoadRecipe(tx *gorm.DB, menuItemID uint, variantID *uint, optionIDs []uint) ([]models.RecipeIngredient, error) {
+	db := tx.Preload("Inventory").Preload("Inventory.Conversions")
+	if variantID != nil {
+		db = db.Where("menu_item_id = ? AND (variant_id IS NULL OR variant_id = ?)", menuItemID, *variantID)
+	} else {
+		db = db.Where("menu_item_id = ? AND variant_id IS NULL", menuItemID)
+	}
+	if len(optionIDs) > 0 {
+		db = db.Or("modifier_option_id IN ?", optionIDs)
+	}
+
+	var lines []models.RecipeIngredient
+	if err := db.Order("sort_order ASC, id ASC").Find(&lines).Error; err != nil {
+		return nil, fmt.Errorf("error fetching recipe: %w", err)
+	}
+	return lines, nil
+}
+
+// UsageOf adds up recipe lines for the given number of portions, converted to the
+// unit each inventory item is stocked in, which needs the items' conversions
+// loaded. Lines of deleted inventory items are skipped.
+func UsageOf(lines []models.RecipeIngredient, portions int) (Usage, error) {
+	usage := Usage{}
+	for _, line := range lines {
+		if line.Inventory.ID == 0 {
+			continue
+		}
+		qty, err := line.Inventory.ToStockUnit(line.Quantity, line.Unit)
+		if err != nil {
+			return nil, fmt.Errorf("recipe line for %s: %w", line.Inventory.ItemName, err)
+		}
+		usage[line.InventoryID] += qty * float64(portions)
+	}
+	return usage, nil
+}
+
+// Cost returns the theoretical cost of recipe lines for one portion at the
+// current inventory unit costs
+func Cost(lines []models.RecipeIngredient) (money.Money, error) {
+	var total money.Fraction
+	for _, line := range lines {
+		if line.Inventory.ID == 0 {
+			continue
+		}
+		qty, err := line.Inventory.ToStockUnit(line.Quantity, line.Unit)
+		if err != nil {
+			return money.Zero, fmt.Errorf("recipe line for %s: %w", line.Inventory.ItemName, err)
+		}
+		total = total.Add(line.Inventory.UnitCost.MulFrac(int64(math.Round(qty*quantityScale)), quantityScale))
+	}
+	return total.Round(), nil
+}
+
+// DeductOrderItem takes the recipe quantities of an order item, its variant and
+// its modifiers out of stock as SALE movements. It runs once per item; stock
+// may go negative so that the kitchen is never blocked by an inaccurate count.
+func DeductOrderItem(tx *gorm.DB, item *models.OrderItem, userID *uint) error {
+	if item.StockDeductedAt != nil {
+		return nil
+	}
+
+	usage, err := orderItemUsage(tx, item, item.Quantity)
+	if err != nil {
+		return err
+	}
+	if err := postUsage(tx, usage, -1, "order_item", item.ID, userID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := tx.Model(item).Update("stock_deducted_at", now).Error; err != nil {
+		return fmt.Errorf("error updating order item: %w", err)
+	}
+	item.StockDeductedAt = &now
+	return nil
+}
+
+// RestockOrderItem puts the recipe usage of quantity refunded portions of an
+// order item back in stock. Items whose stock was never deducted are skipped.
+func RestockOrderItem(tx *gorm.DB, item *models.OrderItem, quantity int, refundID uint, userID *uint) error {
+	if item.StockDeductedAt == nil || quantity <= 0 {
+		return nil
+	}
+	usage, err := orderItemUsage(tx, item, quantity)
+	if err != nil {
+		return err
+	}
+	return postUsage(tx, usage, 1, "refund", refundID, userID)
+}
+
+// orderItemUsage returns the stock used by portions of an order item and its modifiers
+func orderItemUsage(tx *gorm.DB, item *models.OrderItem, portions int) (Usage, error) {
+	var optionIDs []uint
+	err := tx.Model(&models.OrderItemModifier{}).Where("order_item_id = ?", item.ID).Pluck("modifier_option_id", &optionIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching order item modifiers: %w", err)
+	}
+	lines, err := LoadRecipe(tx, item.MenuItemID, item.VariantID, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+	return UsageOf(lines, portions)
+}
+
+// postUsage posts one SALE movement per inventory item, signed by sign
+func postUsage(tx *gorm.DB, usage Usage, sign float64, refType string, refID uint, userID *uint) error {
+	// A fixed order keeps concurrent postings from deadlocking
+	ids := make([]uint, 0, len(usage))
+	for id := range usage {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		_, err := Post(tx, Movement{
+			InventoryID:   id,
+			Type:          models.MovementSale,
+			Quantity:      sign * usage[id],
+			ReferenceType: refType,
+			ReferenceID:   &refID,
+			PostedBy:      userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"restaurant_os/internal/config"
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Rounding decides where tax amounts are rounded
+type Rounding string
+
+const (
+	RoundPerLine    Rounding = "LINE"    // Every tax line is rounded on its own
+	RoundPerInvoice Rounding = "INVOICE" // Each tax component is rounded once over the whole order
+)
+
+// Line is one computed tax component
+type Line struct {
+	RuleID      *uint
+	Name        string
+	Rate        float64
+	Taxable     money.Money
+	Amount      money.Money
+	IsInclusive bool
+
+	exact money.Fraction // Unrounded amount, kept for invoice rounding
+	gross money.Money    // Amount the rule was applied to
+}
+
+// RuleSet holds the active tax rules that can apply to one branch
+type RuleSet struct {
+	Currency money.Currency // Currency of the restaurant, sets the rounding precision
+	Rounding Rounding
+
+	rules    []models.TaxRule
+	fallback *models.TaxRule
+}
+
+// LoadRules loads the active rules of the branch's restaurant that apply to the branch.
+// When no rule matches an item, the configured ORDER_TAX_RATE is used as a single exclusive tax.
+func LoadRules(tx *gorm.DB, branchID uint) (*RuleSet, error) {
+	var branch models.Branch
+	if err := tx.Preload("Restaurant").First(&branch, branchID).Error; err != nil {
+		return nil, fmt.Errorf("error fetching branch: %w", err)
+	}
+
+	var rules []models.TaxRule
+	err := tx.Preload("Components", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order ASC, id ASC")
+	}).
+		Where("restaurant_id = ? AND is_active = ?", branch.RestaurantID, true).
+		Where("branch_id IS NULL OR branch_id = ?", branchID).
+		Order("id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tax rules: %w", err)
+	}
+
+	return &RuleSet{
+		Currency: money.CurrencyOf(branch.Restaurant.Currency),
+		Rounding: CurrentRounding(),
+		rules:    rules,
+		fallback: &models.TaxRule{
+			Name:       "Tax",
+			Components: []models.TaxComponent{{Name: "TAX", Rate: fallbackRate()}},
+		},
+	}, nil
+}
+
+// CurrentRounding reads TAX_ROUNDING, defaulting to per-line rounding
+func CurrentRounding() Rounding {
+	if config.EnvConfig != nil && strings.EqualFold(strings.TrimSpace(config.EnvConfig.TaxRounding), string(RoundPerInvoice)) {
+		return RoundPerInvoice
+	}
+	return RoundPerLine
+}
+
+// For returns the most specific rule for a menu item:
+// item+branch, item, category+branch, category, branch, restaurant, then the fallback rate
+func (rs *RuleSet) For(item *models.MenuItem) *models.TaxRule {
+	best, bestScore := rs.fallback, -1
+	for i := range rs.rules {
+		rule := &rs.rules[i]
+		score := 0
+		switch {
+		case rule.MenuItemID != nil:
+			if *rule.MenuItemID != item.ID {
+				continue
+			}
+			score = 4
+		case rule.CategoryID != nil:
+			if item.CategoryID == nil || *rule.CategoryID != *item.CategoryID {
+				continue
+			}
+			score = 2
+		}
+		if rule.BranchID != nil {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+	return best
+}
+
+// ServiceChargeRule returns the branch- or restaurant-wide rule that taxes the service charge, if any
+func (rs *RuleSet) ServiceChargeRule() *models.TaxRule {
+	var best *models.TaxRule
+	for i := range rs.rules {
+		rule := &rs.rules[i]
+		if rule.MenuItemID != nil || rule.CategoryID != nil || !rule.TaxServiceCharge {
+			continue
+		}
+		if best == nil || (best.BranchID == nil && rule.BranchID != nil) {
+			best = rule
+		}
+	}
+	return best
+}
+
+// Apply computes the tax components of a rule on an amount, each rounded to the
+// currency's precision. For inclusive rules the amount already contains the tax,
+// which is backed out so that the taxable base plus the components equal the
+// amount exactly. Exempt order types yield no lines.
+func (rs *RuleSet) Apply(rule *models.TaxRule, amount money.Money, orderType models.OrderType) []Line {
+	if rule == nil || rule.IsExempt(orderType) || len(rule.Components) == 0 {
+		return nil
+	}
+
+	var ruleID *uint
+	if rule.ID != 0 {
+		id := rule.ID
+		ruleID = &id
+	}
+
+	lines := make([]Line, 0, len(rule.Components))
+	for _, c := range rule.Components {
+		exact := amount.Percent(c.Rate)
+		if rule.IsInclusive {
+			// amount * rate / (100 + total rate), in thousandths of a percent
+			exact = amount.MulFrac(milli(c.Rate), 100*1000+milli(rule.TotalRate()))
+		}
+		lines = append(lines, Line{
+			RuleID:      ruleID,
+			Name:        c.Name,
+			Rate:        c.Rate,
+			Amount:      rs.Currency.Round(exact),
+			IsInclusive: rule.IsInclusive,
+			exact:       exact,
+			gross:       amount,
+		})
+	}
+	setTaxable(lines)
+	return lines
+}
+
+// Finalize applies invoice rounding to the tax lines of one order, given per
+// order line (plus one group for the service charge). Each component's exact
+// amounts are summed over the order, rounded once, and spread back over the
+// lines so they still add up. With per-line rounding the lines are left as is.
+func (rs *RuleSet) Finalize(groups ...[]Line) {
+	if rs.Rounding != RoundPerInvoice {
+		return
+	}
+
+	type key struct {
+		ruleID      uint
+		name        string
+		rate        float64
+		isInclusive bool
+	}
+	type member struct{ group, line int }
+	members := map[key][]member{}
+	var order []key
+	for g, lines := range groups {
+		for i, l := range lines {
+			k := key{name: l.Name, rate: l.Rate, isInclusive: l.IsInclusive}
+			if l.RuleID != nil {
+				k.ruleID = *l.RuleID
+			}
+			if _, ok := members[k]; !ok {
+				order = append(order, k)
+			}
+			members[k] = append(members[k], member{g, i})
+		}
+	}
+
+	for _, k := range order {
+		var total money.Fraction
+		parts := make([]money.Fraction, 0, len(members[k]))
+		for _, m := range members[k] {
+			exact := groups[m.group][m.line].exact
+			total = total.Add(exact)
+			parts = append(parts, exact)
+		}
+		amounts := money.Distribute(rs.Currency.Round(total), parts)
+		for i, m := range members[k] {
+			groups[m.group][m.line].Amount = amounts[i]
+		}
+	}
+	for _, lines := range groups {
+		setTaxable(lines)
+	}
+}
+
+// Exclusive returns a copy of the rule that always adds tax on top, used for
+// charges the restaurant adds itself such as the service charge
+func Exclusive(rule *models.TaxRule) *models.TaxRule {
+	if rule == nil {
+		return nil
+	}
+	copied := *rule
+	copied.IsInclusive = false
+	return &copied
+}
+
+// Sum splits lines into tax added on top and tax already included in prices
+func Sum(lines []Line) (exclusive, inclusive money.Money) {
+	for _, l := range lines {
+		if l.IsInclusive {
+			inclusive = inclusive.Add(l.Amount)
+		} else {
+			exclusive = exclusive.Add(l.Amount)
+		}
+	}
+	return exclusive, inclusive
+}
+
+// setTaxable sets the taxable base of the lines of one rule application: the
+// amount itself for exclusive taxes, the amount less the included tax otherwise
+func setTaxable(lines []Line) {
+	var included money.Money
+	for _, l := range lines {
+		if l.IsInclusive {
+			included = included.Add(l.Amount)
+		}
+	}
+	for i := range lines {
+		lines[i].Taxable = lines[i].gross
+		if lines[i].IsInclusive {
+			lines[i].Taxable = lines[i].gross.Sub(included)
+		}
+	}
+}
+
+func fallbackRate() float64 {
+	if config.EnvConfig != nil {
+		if rate, err := strconv.ParseFloat(config.EnvConfig.OrderTaxRate, 64); err == nil && rate >= 0 {
+			return rate
+		}
+	}
+	return 18
+}
+
+// milli converts a percent rate to thousandths of a percent
+func milli(rate float64) int64 {
+	return int64(math.Round(rate * 1000))
+}
@@ -0,0 +1,167 @@
+package tax
+
+import (
+	"restaurant_os/internal/models"
+	"restaurant_os/internal/money"
+	"testing"
+)
+
+func gst(inclusive bool) *models.TaxRule {
+	return &models.TaxRule{
+		ID:          7,
+		Name:        "GST",
+		IsInclusive: inclusive,
+		Components:  []models.TaxComponent{{Name: "CGST", Rate: 9}, {Name: "SGST", Rate: 9}},
+	}
+}
+
+func vat(rate float64, inclusive bool) *models.TaxRule {
+	return &models.TaxRule{Name: "VAT", IsInclusive: inclusive, Components: []models.TaxComponent{{Name: "VAT", Rate: rate}}}
+}
+
+func TestApply(t *testing.T) {
+	rs := &RuleSet{Currency: money.CurrencyOf("INR"), Rounding: RoundPerLine}
+	tests := []struct {
+		name      string
+		rule      *models.TaxRule
+		amount    int64
+		orderType models.OrderType
+		amounts   []int64
+		taxable   []int64
+	}{
+		{name: "exclusive components", rule: gst(false), amount: 24000, amounts: []int64{2160, 2160}, taxable: []int64{24000, 24000}},
+		{name: "inclusive backed out exactly", rule: gst(true), amount: 11800, amounts: []int64{900, 900}, taxable: []int64{10000, 10000}},
+		{name: "inclusive rounded per component", rule: gst(true), amount: 10000, amounts: []int64{763, 763}, taxable: []int64{8474, 8474}},
+		{name: "exclusive half rounds up", rule: vat(5, false), amount: 50, amounts: []int64{3}, taxable: []int64{50}},
+		{name: "three decimal rate", rule: vat(2.125, false), amount: 10000, amounts: []int64{213}, taxable: []int64{10000}},
+		{name: "zero amount", rule: gst(false), amount: 0, amounts: []int64{0, 0}, taxable: []int64{0, 0}},
+		{name: "exempt order type", rule: &models.TaxRule{ExemptOrderTypes: "TAKEAWAY, DELIVERY", Components: []models.TaxComponent{{Name: "VAT", Rate: 5}}},
+			amount: 10000, orderType: models.OrderTypeDelivery},
+		{name: "no rule", rule: nil, amount: 10000},
+		{name: "no components", rule: &models.TaxRule{Name: "Empty"}, amount: 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderType := tt.orderType
+			if orderType == "" {
+				orderType = models.OrderTypeDineIn
+			}
+			lines := rs.Apply(tt.rule, money.FromMinor(tt.amount), orderType)
+			if len(lines) != len(tt.amounts) {
+				t.Fatalf("Apply returned %d lines, want %d", len(lines), len(tt.amounts))
+			}
+			for i, l := range lines {
+				if l.Amount.Minor() != tt.amounts[i] || l.Taxable.Minor() != tt.taxable[i] {
+					t.Errorf("line %d (%s) = %d on %d, want %d on %d",
+						i, l.Name, l.Amount.Minor(), l.Taxable.Minor(), tt.amounts[i], tt.taxable[i])
+				}
+				if l.IsInclusive != tt.rule.IsInclusive {
+					t.Errorf("line %d inclusive = %t, want %t", i, l.IsInclusive, tt.rule.IsInclusive)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRuleID(t *testing.T) {
+	rs := &RuleSet{Currency: money.CurrencyOf("USD")}
+	if lines := rs.Apply(gst(false), money.FromMinor(100), models.OrderTypeDineIn); lines[0].RuleID == nil || *lines[0].RuleID != 7 {
+		t.Errorf("stored rule: RuleID = %v, want 7", lines[0].RuleID)
+	}
+	if lines := rs.Apply(vat(5, false), money.FromMinor(100), models.OrderTypeDineIn); lines[0].RuleID != nil {
+		t.Errorf("fallback rule: RuleID = %d, want nil", *lines[0].RuleID)
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		rounding  Rounding
+		inclusive bool
+		amounts   []int64
+		taxable   []int64
+	}{
+		// 5% of 0.10 is 0.005 a line: 0.01 each when rounded per line, 0.02 over the order
+		{name: "per line", rounding: RoundPerLine, amounts: []int64{1, 1, 1}, taxable: []int64{10, 10, 10}},
+		{name: "per invoice", rounding: RoundPerInvoice, amounts: []int64{1, 1, 0}, taxable: []int64{10, 10, 10}},
+		// 0.10 includes 0.00476 of 5% tax: nothing per line, 0.01 over the order
+		{name: "inclusive per line", rounding: RoundPerLine, inclusive: true, amounts: []int64{0, 0, 0}, taxable: []int64{10, 10, 10}},
+		{name: "inclusive per invoice", rounding: RoundPerInvoice, inclusive: true, amounts: []int64{1, 0, 0}, taxable: []int64{9, 10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &RuleSet{Currency: money.CurrencyOf("USD"), Rounding: tt.rounding}
+			groups := make([][]Line, 3)
+			for i := range groups {
+				groups[i] = rs.Apply(vat(5, tt.inclusive), money.FromMinor(10), models.OrderTypeDineIn)
+			}
+			rs.Finalize(groups...)
+			for i, g := range groups {
+				if g[0].Amount.Minor() != tt.amounts[i] || g[0].Taxable.Minor() != tt.taxable[i] {
+					t.Errorf("group %d = %d on %d, want %d on %d",
+						i, g[0].Amount.Minor(), g[0].Taxable.Minor(), tt.amounts[i], tt.taxable[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	branchID, categoryID, itemID := uint(1), uint(5), uint(10)
+	rs := &RuleSet{
+		rules: []models.TaxRule{
+			{ID: 1, Name: "restaurant"},
+			{ID: 2, Name: "branch", BranchID: &branchID},
+			{ID: 3, Name: "category", CategoryID: &categoryID},
+			{ID: 4, Name: "item", MenuItemID: &itemID},
+		},
+		fallback: vat(18, false),
+	}
+	otherCategory := uint(6)
+	tests := []struct {
+		name string
+		item models.MenuItem
+		want string
+	}{
+		{name: "item rule wins", item: models.MenuItem{ID: 10, CategoryID: &categoryID}, want: "item"},
+		{name: "category rule", item: models.MenuItem{ID: 11, CategoryID: &categoryID}, want: "category"},
+		{name: "branch over restaurant", item: models.MenuItem{ID: 12, CategoryID: &otherCategory}, want: "branch"},
+		{name: "no category", item: models.MenuItem{ID: 13}, want: "branch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rs.For(&tt.item); got.Name != tt.want {
+				t.Errorf("For(item %d) = %s, want %s", tt.item.ID, got.Name, tt.want)
+			}
+		})
+	}
+
+	empty := &RuleSet{fallback: vat(18, false)}
+	if got := empty.For(&models.MenuItem{ID: 1}); got.Name != "VAT" {
+		t.Errorf("For with no rules = %s, want the fallback", got.Name)
+	}
+}
+
+func TestSum(t *testing.T) {
+	lines := []Line{
+		{Amount: money.FromMinor(100)},
+		{Amount: money.FromMinor(250), IsInclusive: true},
+		{Amount: money.FromMinor(5)},
+		{Amount: money.FromMinor(-1), IsInclusive: true},
+	}
+	exclusive, inclusive := Sum(lines)
+	if exclusive.Minor() != 105 || inclusive.Minor() != 249 {
+		t.Errorf("Sum = %d exclusive, %d inclusive; want 105, 249", exclusive.Minor(), inclusive.Minor())
+	}
+}
+
+func TestExclusive(t *testing.T) {
+	if Exclusive(nil) != nil {
+		t.Error("Exclusive(nil) should be nil")
+	}
+	rule := gst(true)
+	copied := Exclusive(rule)
+	if copied.IsInclusive || !rule.IsInclusive {
+		t.Errorf("Exclusive = inclusive %t, original inclusive %t; want false, true", copied.IsInclusive, rule.IsInclusive)
+	}
+}